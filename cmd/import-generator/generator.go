@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// generator enumerates the resources and collects the import blocks and the configuration of them.
+type generator struct {
+	provider            *schema.Provider
+	enterpriseProjectID string
+
+	// addresses records the generated resource addresses to keep them unique.
+	addresses map[string]bool
+	imports   strings.Builder
+	resources strings.Builder
+}
+
+func newGenerator(provider *schema.Provider, enterpriseProjectID string) *generator {
+	return &generator{
+		provider:            provider,
+		enterpriseProjectID: enterpriseProjectID,
+		addresses:           make(map[string]bool),
+	}
+}
+
+// generate enumerates the resources of the kind and returns the number of them.
+func (g *generator) generate(ctx context.Context, k kind) (int, error) {
+	dataSource, ok := g.provider.DataSourcesMap[k.DataSource]
+	if !ok {
+		return 0, fmt.Errorf("the data source %s is not found", k.DataSource)
+	}
+	resource, ok := g.provider.ResourcesMap[k.ResourceType]
+	if !ok {
+		return 0, fmt.Errorf("the resource %s is not found", k.ResourceType)
+	}
+
+	d := dataSource.Data(nil)
+	for key, value := range k.Filters {
+		if err := d.Set(key, value); err != nil {
+			return 0, fmt.Errorf("error setting the filter %s: %s", key, err)
+		}
+	}
+	if _, ok := dataSource.Schema["enterprise_project_id"]; ok && g.enterpriseProjectID != "" {
+		if err := d.Set("enterprise_project_id", g.enterpriseProjectID); err != nil {
+			return 0, fmt.Errorf("error setting the filter enterprise_project_id: %s", err)
+		}
+	}
+	if err := readDataSource(ctx, dataSource, d, g.provider.Meta()); err != nil {
+		return 0, err
+	}
+
+	items, _ := d.Get(k.ListAttribute).([]interface{})
+	count := 0
+	for _, raw := range items {
+		item, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := item["id"].(string)
+		if id == "" {
+			continue
+		}
+
+		name, _ := item["name"].(string)
+		address := g.newAddress(k.ResourceType, name, id)
+		writeImportBlock(&g.imports, address, id)
+		writeResourceBlock(&g.resources, address, resource.Schema, item, k.Renames)
+		count++
+	}
+	return count, nil
+}
+
+// newAddress returns a unique address of the resource, the local name is generated by the resource name or ID.
+func (g *generator) newAddress(resourceType, name, id string) string {
+	localName := toLocalName(name)
+	if localName == "" {
+		localName = toLocalName(id)
+	}
+
+	address := fmt.Sprintf("%s.%s", resourceType, localName)
+	for i := 2; g.addresses[address]; i++ {
+		address = fmt.Sprintf("%s.%s_%d", resourceType, localName, i)
+	}
+	g.addresses[address] = true
+	return address
+}
+
+func readDataSource(ctx context.Context, dataSource *schema.Resource, d *schema.ResourceData, meta interface{}) error {
+	var diags diag.Diagnostics
+	switch {
+	case dataSource.ReadContext != nil:
+		diags = dataSource.ReadContext(ctx, d, meta)
+	case dataSource.ReadWithoutTimeout != nil:
+		diags = dataSource.ReadWithoutTimeout(ctx, d, meta)
+	default:
+		return fmt.Errorf("the data source does not implement the read context function")
+	}
+
+	for _, v := range diags {
+		if v.Severity == diag.Error {
+			return fmt.Errorf("%s %s", v.Summary, v.Detail)
+		}
+	}
+	return nil
+}
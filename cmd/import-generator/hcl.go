@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const indentUnit = "  "
+
+// toLocalName converts the name to a valid local name of Terraform, an empty string is returned if the name does
+// not contain any letters or digits.
+func toLocalName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	result := strings.Trim(b.String(), "_-")
+	if result != "" && !unicode.IsLetter(rune(result[0])) {
+		result = "r_" + result
+	}
+	return result
+}
+
+// quoteString returns the HCL quoted string, the template sequences are escaped to keep the value literal.
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func writeImportBlock(b *strings.Builder, address, id string) {
+	fmt.Fprintf(b, "import {\n%sto = %s\n%sid = %s\n}\n\n", indentUnit, address, indentUnit, quoteString(id))
+}
+
+// writeResourceBlock writes the resource configuration with the attributes of the data source item, the renames maps
+// the top-level argument names of the resource to the attribute names of the item.
+func writeResourceBlock(b *strings.Builder, address string, s map[string]*schema.Schema, item map[string]interface{},
+	renames map[string]string) {
+	parts := strings.SplitN(address, ".", 2)
+	fmt.Fprintf(b, "resource %q %q {\n", parts[0], parts[1])
+	writeBody(b, indentUnit, s, item, renames)
+	b.WriteString("}\n\n")
+}
+
+// isArgument checks whether the attribute can be configured, the computed-only, sensitive, deprecated attributes
+// and region are skipped.
+func isArgument(key string, s *schema.Schema) bool {
+	if key == "region" || s.Sensitive || s.Deprecated != "" {
+		return false
+	}
+	return s.Required || s.Optional
+}
+
+// isConflicting checks whether the attribute conflicts with the written attributes of the same block.
+func isConflicting(s *schema.Schema, written map[string]bool) bool {
+	for _, keys := range [][]string{s.ConflictsWith, s.ExactlyOneOf} {
+		for _, key := range keys {
+			if written[key] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeBody(b *strings.Builder, indent string, s map[string]*schema.Schema, item map[string]interface{},
+	renames map[string]string) {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	type attribute struct {
+		key   string
+		value string
+	}
+	var (
+		attributes []attribute
+		blocks     []attribute
+		missing    []string
+		written    = make(map[string]bool)
+	)
+	for _, key := range keys {
+		if !isArgument(key, s[key]) {
+			// the sensitive arguments, e.g. password, are never returned by the data sources
+			if s[key].Required {
+				missing = append(missing, key)
+			}
+			continue
+		}
+		if isConflicting(s[key], written) {
+			continue
+		}
+
+		itemKey := key
+		if renamed, ok := renames[key]; ok {
+			itemKey = renamed
+		}
+		raw, ok := item[itemKey]
+		if !ok || isEmptyValue(raw) {
+			if s[key].Required {
+				missing = append(missing, key)
+			}
+			continue
+		}
+
+		if elem, ok := s[key].Elem.(*schema.Resource); ok {
+			var nested strings.Builder
+			for _, v := range toSlice(raw) {
+				if m, ok := v.(map[string]interface{}); ok {
+					fmt.Fprintf(&nested, "\n%s%s {\n", indent, key)
+					writeBody(&nested, indent+indentUnit, elem.Schema, m, nil)
+					fmt.Fprintf(&nested, "%s}\n", indent)
+				}
+			}
+			blocks = append(blocks, attribute{key: key, value: nested.String()})
+			written[key] = true
+			continue
+		}
+
+		if value, ok := renderValue(indent, s[key], raw); ok {
+			attributes = append(attributes, attribute{key: key, value: value})
+			written[key] = true
+		}
+	}
+
+	width := 0
+	for _, attr := range attributes {
+		if len(attr.key) > width {
+			width = len(attr.key)
+		}
+	}
+	for _, attr := range attributes {
+		fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, attr.key, attr.value)
+	}
+	for _, key := range missing {
+		fmt.Fprintf(b, "%s# %s is required but cannot be read from the cloud, please complete it\n", indent, key)
+	}
+	for _, block := range blocks {
+		b.WriteString(block.value)
+	}
+}
+
+// renderValue returns the HCL expression of the primitive, list, set or map value, false is returned if the value
+// does not match the schema type.
+func renderValue(indent string, s *schema.Schema, raw interface{}) (string, bool) {
+	switch s.Type {
+	case schema.TypeString:
+		v, ok := raw.(string)
+		return quoteString(v), ok
+	case schema.TypeInt:
+		v, ok := raw.(int)
+		return strconv.Itoa(v), ok
+	case schema.TypeFloat:
+		v, ok := raw.(float64)
+		return strconv.FormatFloat(v, 'f', -1, 64), ok
+	case schema.TypeBool:
+		v, ok := raw.(bool)
+		return strconv.FormatBool(v), ok
+	case schema.TypeList, schema.TypeSet:
+		elem, ok := s.Elem.(*schema.Schema)
+		if !ok {
+			return "", false
+		}
+		values := make([]string, 0)
+		for _, v := range toSlice(raw) {
+			value, ok := renderValue(indent, elem, v)
+			if !ok {
+				return "", false
+			}
+			values = append(values, value)
+		}
+		return "[" + strings.Join(values, ", ") + "]", true
+	case schema.TypeMap:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		elem, ok := s.Elem.(*schema.Schema)
+		if !ok {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			value, ok := renderValue(indent+indentUnit, elem, m[k])
+			if !ok {
+				return "", false
+			}
+			fmt.Fprintf(&b, "%s%s%s = %s\n", indent, indentUnit, quoteString(k), value)
+		}
+		fmt.Fprintf(&b, "%s}", indent)
+		return b.String(), true
+	}
+	return "", false
+}
+
+func toSlice(raw interface{}) []interface{} {
+	switch v := raw.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	}
+	return nil
+}
+
+func isEmptyValue(raw interface{}) bool {
+	switch v := raw.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}, *schema.Set:
+		return len(toSlice(v)) == 0
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+)
+
+func TestToLocalName(t *testing.T) {
+	cases := map[string]string{
+		"vpc-demo":     "vpc-demo",
+		"Demo VPC 01":  "demo_vpc_01",
+		"example.com.": "example_com",
+		"01-instance":  "r_01-instance",
+		"测试":           "",
+	}
+	for name, expected := range cases {
+		if got := toLocalName(name); got != expected {
+			t.Errorf("expected the local name of %q is %q, but got %q", name, expected, got)
+		}
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	cases := map[string]string{
+		`demo`:            `"demo"`,
+		`say "hi"`:        `"say \"hi\""`,
+		"line1\nline2":    `"line1\nline2"`,
+		`${var.x}-%{if}$`: `"$${var.x}-%%{if}$"`,
+	}
+	for s, expected := range cases {
+		if got := quoteString(s); got != expected {
+			t.Errorf("expected the quoted string of %q is %s, but got %s", s, expected, got)
+		}
+	}
+}
+
+func TestWriteResourceBlock(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"region":      {Type: schema.TypeString, Optional: true, Computed: true},
+		"name":        {Type: schema.TypeString, Required: true},
+		"password":    {Type: schema.TypeString, Required: true, Sensitive: true},
+		"size":        {Type: schema.TypeInt, Optional: true},
+		"shared":      {Type: schema.TypeBool, Optional: true},
+		"flavor_id":   {Type: schema.TypeString, Optional: true, ConflictsWith: []string{"flavor_name"}},
+		"flavor_name": {Type: schema.TypeString, Optional: true, ConflictsWith: []string{"flavor_id"}},
+		"status":      {Type: schema.TypeString, Computed: true},
+		"zones": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"tags": {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"network": {
+			Type:     schema.TypeList,
+			Required: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"uuid": {Type: schema.TypeString, Required: true},
+					"mac":  {Type: schema.TypeString, Computed: true},
+				},
+			},
+		},
+	}
+	item := map[string]interface{}{
+		"id":          "0ea2c1b5",
+		"region":      "cn-north-4",
+		"name":        "demo",
+		"size":        10,
+		"shareable":   true,
+		"flavor_id":   "s6.small.1",
+		"flavor_name": "s6.small.1",
+		"status":      "ACTIVE",
+		"zones":       []interface{}{"cn-north-4a"},
+		"tags":        map[string]interface{}{"owner": "terraform"},
+		"network": []interface{}{
+			map[string]interface{}{"uuid": "8b5a4c9d", "mac": "fa:16:3e:00:00:01"},
+		},
+	}
+
+	var b strings.Builder
+	writeResourceBlock(&b, "huaweicloud_demo.demo", s, item, map[string]string{"shared": "shareable"})
+
+	expected := `resource "huaweicloud_demo" "demo" {
+  flavor_id = "s6.small.1"
+  name      = "demo"
+  shared    = true
+  size      = 10
+  tags      = {
+    "owner" = "terraform"
+  }
+  zones     = ["cn-north-4a"]
+  # password is required but cannot be read from the cloud, please complete it
+
+  network {
+    uuid = "8b5a4c9d"
+  }
+}
+
+`
+	if got := b.String(); got != expected {
+		t.Errorf("unexpected resource block, want:\n%s\nbut got:\n%s", expected, got)
+	}
+}
+
+func TestSupportedKinds(t *testing.T) {
+	provider := huaweicloud.Provider()
+	for name, k := range supportedKinds {
+		dataSource, ok := provider.DataSourcesMap[k.DataSource]
+		if !ok {
+			t.Errorf("the data source %s of %s is not found", k.DataSource, name)
+			continue
+		}
+		list, ok := dataSource.Schema[k.ListAttribute]
+		if !ok {
+			t.Errorf("the attribute %s of %s is not found", k.ListAttribute, k.DataSource)
+			continue
+		}
+		elem, ok := list.Elem.(*schema.Resource)
+		if !ok {
+			t.Errorf("the attribute %s of %s is not a list of objects", k.ListAttribute, k.DataSource)
+			continue
+		}
+		if _, ok := elem.Schema["id"]; !ok {
+			t.Errorf("the ID of %s is not found in %s", k.ListAttribute, k.DataSource)
+		}
+
+		resource, ok := provider.ResourcesMap[k.ResourceType]
+		if !ok {
+			t.Errorf("the resource %s of %s is not found", k.ResourceType, name)
+			continue
+		}
+		if resource.Importer == nil {
+			t.Errorf("the resource %s does not support importing", k.ResourceType)
+		}
+		for argument, attribute := range k.Renames {
+			if _, ok := resource.Schema[argument]; !ok {
+				t.Errorf("the argument %s is not found in %s", argument, k.ResourceType)
+			}
+			if _, ok := elem.Schema[attribute]; !ok {
+				t.Errorf("the attribute %s is not found in %s", attribute, k.DataSource)
+			}
+		}
+		for filter := range k.Filters {
+			if _, ok := dataSource.Schema[filter]; !ok {
+				t.Errorf("the filter %s is not found in %s", filter, k.DataSource)
+			}
+		}
+	}
+}
+
+func TestSelectKinds(t *testing.T) {
+	kinds, err := selectKinds("vpc, vpc_subnet")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(kinds) != 2 || kinds[1].ResourceType != "huaweicloud_vpc_subnet" {
+		t.Errorf("unexpected kinds: %v", kinds)
+	}
+
+	if kinds, _ = selectKinds(""); len(kinds) != len(supportedKinds) {
+		t.Errorf("expected all kinds are selected, but got %d", len(kinds))
+	}
+	if _, err = selectKinds("vpc,unknown"); err == nil {
+		t.Errorf("expected an error for the unsupported type")
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// kind describes how to enumerate a type of resources with a data source of the provider.
+type kind struct {
+	// DataSource is the data source to enumerate the resources.
+	DataSource string
+	// ListAttribute is the attribute of the data source which contains the resources.
+	ListAttribute string
+	// ResourceType is the resource to import the enumerated resources, it must support importing by ID.
+	ResourceType string
+	// Filters are the fixed arguments of the data source.
+	Filters map[string]interface{}
+	// Renames maps the argument names of the resource to the attribute names of the data source, if they are not
+	// the same.
+	Renames map[string]string
+}
+
+// supportedKinds are the resources which can be enumerated, the key is the -types name of them.
+var supportedKinds = map[string]kind{
+	"vpc": {
+		DataSource:    "huaweicloud_vpcs",
+		ListAttribute: "vpcs",
+		ResourceType:  "huaweicloud_vpc",
+	},
+	"vpc_subnet": {
+		DataSource:    "huaweicloud_vpc_subnets",
+		ListAttribute: "subnets",
+		ResourceType:  "huaweicloud_vpc_subnet",
+	},
+	"networking_secgroup": {
+		DataSource:    "huaweicloud_networking_secgroups",
+		ListAttribute: "security_groups",
+		ResourceType:  "huaweicloud_networking_secgroup",
+	},
+	"compute_instance": {
+		DataSource:    "huaweicloud_compute_instances",
+		ListAttribute: "instances",
+		ResourceType:  "huaweicloud_compute_instance",
+	},
+	"evs_volume": {
+		DataSource:    "huaweicloud_evs_volumes",
+		ListAttribute: "volumes",
+		ResourceType:  "huaweicloud_evs_volume",
+		Renames: map[string]string{
+			"multiattach": "shareable",
+		},
+	},
+	"rds_instance": {
+		DataSource:    "huaweicloud_rds_instances",
+		ListAttribute: "instances",
+		ResourceType:  "huaweicloud_rds_instance",
+	},
+	"dns_public_zone": {
+		DataSource:    "huaweicloud_dns_zones",
+		ListAttribute: "zones",
+		ResourceType:  "huaweicloud_dns_zone",
+		Filters: map[string]interface{}{
+			"zone_type": "public",
+		},
+	},
+	"dns_private_zone": {
+		DataSource:    "huaweicloud_dns_zones",
+		ListAttribute: "zones",
+		ResourceType:  "huaweicloud_dns_zone",
+		Filters: map[string]interface{}{
+			"zone_type": "private",
+		},
+		Renames: map[string]string{
+			"router": "routers",
+		},
+	},
+}
+
+func supportedKindNames() []string {
+	names := make([]string, 0, len(supportedKinds))
+	for name := range supportedKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectKinds returns the kinds of the comma separated names, or all supported kinds if the names are empty.
+func selectKinds(names string) ([]kind, error) {
+	selected := supportedKindNames()
+	if names != "" {
+		selected = strings.Split(names, ",")
+	}
+
+	kinds := make([]kind, 0, len(selected))
+	for _, name := range selected {
+		k, ok := supportedKinds[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %q, the supported types are: %s", name,
+				strings.Join(supportedKindNames(), ","))
+		}
+		kinds = append(kinds, k)
+	}
+	return kinds, nil
+}
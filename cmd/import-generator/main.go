@@ -0,0 +1,103 @@
+// The import-generator enumerates the existing resources of a region (and an enterprise project) with the data
+// sources of the provider, and writes the Terraform 1.5 import blocks and the starter configuration of them.
+//
+// The credentials are the same as the provider, e.g. HW_ACCESS_KEY and HW_SECRET_KEY:
+//
+//	go run ./cmd/import-generator -region cn-north-4 -types vpc,vpc_subnet -out ./brownfield
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+)
+
+// options are the command line options of import-generator.
+type options struct {
+	region              string
+	enterpriseProjectID string
+	kindNames           string
+	outputDir           string
+}
+
+var (
+	opts        options
+	commandLine flag.FlagSet
+)
+
+func init() {
+	commandLine.Init(os.Args[0], flag.ExitOnError)
+
+	commandLine.StringVar(&opts.region, "region", os.Getenv("HW_REGION_NAME"),
+		"The region to enumerate the resources, defaults to HW_REGION_NAME.")
+	commandLine.StringVar(&opts.enterpriseProjectID, "enterprise_project_id", "",
+		"The enterprise project to enumerate the resources, defaults to all enterprise projects.")
+	commandLine.StringVar(&opts.kindNames, "types", "",
+		fmt.Sprintf("The comma separated resource types to enumerate, defaults to all: %s.",
+			strings.Join(supportedKindNames(), ",")))
+	commandLine.StringVar(&opts.outputDir, "out", ".", "The directory to write imports.tf and resources.tf.")
+
+	commandLine.Usage = func() {
+		fmt.Fprintf(commandLine.Output(), "Usage of %s:\n\n", os.Args[0])
+		commandLine.PrintDefaults()
+	}
+}
+
+func main() {
+	commandLine.Parse(os.Args[1:]) //nolint: errcheck
+
+	if err := run(context.Background(), huaweicloud.Provider(), opts, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run configures the provider, enumerates the resources and writes imports.tf and resources.tf to the output
+// directory, the number of the resources of each type is reported to out.
+func run(ctx context.Context, provider *schema.Provider, opts options, out io.Writer) error {
+	if opts.region == "" {
+		return errors.New("-region or HW_REGION_NAME must be specified")
+	}
+	kinds, err := selectKinds(opts.kindNames)
+	if err != nil {
+		return err
+	}
+
+	diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"region": opts.region,
+	}))
+	if diags.HasError() {
+		return fmt.Errorf("failed to configure the provider: %v", diags)
+	}
+
+	g := newGenerator(provider, opts.enterpriseProjectID)
+	for _, k := range kinds {
+		count, err := g.generate(ctx, k)
+		if err != nil {
+			return fmt.Errorf("failed to enumerate %s: %s", k.ResourceType, err)
+		}
+		fmt.Fprintf(out, "found %d %s\n", count, k.ResourceType)
+	}
+
+	files := map[string]string{
+		"imports.tf":   g.imports.String(),
+		"resources.tf": g.resources.String(),
+	}
+	for name, content := range files {
+		path := filepath.Join(opts.outputDir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %s", path, err)
+		}
+	}
+	return nil
+}
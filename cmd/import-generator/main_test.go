@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/fakecloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/evs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/vpc"
+)
+
+func TestRun_fakeCloud(t *testing.T) {
+	server := fakecloud.NewServer()
+	defer server.Close()
+
+	// the resources to be enumerated are created by another provider, as run configures the provider by itself
+	provider := huaweicloud.ProviderWithConfigureHook(server.Configure)
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"region": fakecloud.DefaultRegion,
+	}))
+	if diags.HasError() {
+		t.Fatalf("error configuring the provider: %v", diags)
+	}
+
+	vpcLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceVirtualPrivateCloudV1(), Meta: provider.Meta()}
+	defer vpcLifecycle.Destroy(t)
+	vpcLifecycle.Apply(t, map[string]interface{}{
+		"name": "vpc-demo",
+		"cidr": "192.168.0.0/16",
+	})
+	subnetLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceVpcSubnetV1(), Meta: provider.Meta()}
+	defer subnetLifecycle.Destroy(t)
+	subnetLifecycle.Apply(t, map[string]interface{}{
+		"name":       "subnet-demo",
+		"cidr":       "192.168.0.0/24",
+		"gateway_ip": "192.168.0.1",
+		"vpc_id":     vpcLifecycle.ID(),
+	})
+	volumeLifecycle := acceptance.ResourceLifecycle{Resource: evs.ResourceEvsVolume(), Meta: provider.Meta()}
+	defer volumeLifecycle.Destroy(t)
+	volumeLifecycle.Apply(t, map[string]interface{}{
+		"name":              "volume-demo",
+		"availability_zone": fakecloud.DefaultRegion + "a",
+		"volume_type":       "SSD",
+		"size":              10,
+	})
+
+	outputDir := t.TempDir()
+	var out bytes.Buffer
+	err := run(context.Background(), huaweicloud.ProviderWithConfigureHook(server.Configure), options{
+		region:    fakecloud.DefaultRegion,
+		kindNames: "vpc,vpc_subnet,evs_volume",
+		outputDir: outputDir,
+	}, &out)
+	if err != nil {
+		t.Fatalf("error running the generator: %s", err)
+	}
+
+	expectedOutput := "found 1 huaweicloud_vpc\nfound 1 huaweicloud_vpc_subnet\nfound 1 huaweicloud_evs_volume\n"
+	if out.String() != expectedOutput {
+		t.Errorf("expected the output:\n%s\nbut got:\n%s", expectedOutput, out.String())
+	}
+
+	imports := readOutputFile(t, outputDir, "imports.tf")
+	for address, id := range map[string]string{
+		"huaweicloud_vpc.vpc-demo":           vpcLifecycle.ID(),
+		"huaweicloud_vpc_subnet.subnet-demo": subnetLifecycle.ID(),
+		"huaweicloud_evs_volume.volume-demo": volumeLifecycle.ID(),
+	} {
+		block := fmt.Sprintf("import {\n  to = %s\n  id = %q\n}\n", address, id)
+		if !strings.Contains(imports, block) {
+			t.Errorf("the import block of %s is not found in imports.tf:\n%s", address, imports)
+		}
+	}
+
+	// the arguments are aligned by the equal signs
+	resources := strings.Join(strings.Fields(readOutputFile(t, outputDir, "resources.tf")), " ")
+	for _, expected := range []string{
+		`resource "huaweicloud_vpc" "vpc-demo" {`,
+		`cidr = "192.168.0.0/16"`,
+		`resource "huaweicloud_vpc_subnet" "subnet-demo" {`,
+		fmt.Sprintf(`vpc_id = %q`, vpcLifecycle.ID()),
+		`resource "huaweicloud_evs_volume" "volume-demo" {`,
+		`volume_type = "SSD"`,
+	} {
+		if !strings.Contains(resources, expected) {
+			t.Errorf("%s is not found in resources.tf: %s", expected, resources)
+		}
+	}
+}
+
+func TestRun_invalidOptions(t *testing.T) {
+	cases := map[string]options{
+		"-region or HW_REGION_NAME must be specified": {kindNames: "vpc"},
+		`unsupported type "vpcs"`:                     {region: fakecloud.DefaultRegion, kindNames: "vpcs"},
+	}
+	for expected, opts := range cases {
+		err := run(context.Background(), huaweicloud.Provider(), opts, &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected the error %q, but got %v", expected, err)
+		}
+	}
+}
+
+func readOutputFile(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("error reading %s: %s", name, err)
+	}
+	return string(content)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"time"
@@ -26,9 +27,36 @@ import (
 const (
 	securityKeyURL     string = "http://169.254.169.254/openstack/latest/securitykey"
 	keyExpiresDuration int64  = 600
-	assumeRoleDuration int32  = 24 * 60 * 60
+	// the metadata API is only reachable in ECS instances, so the connection should fail fast in other environments
+	// and the request should not be blocked for a long time
+	metadataConnectTimeout = time.Second
+	metadataTimeout        = 5 * time.Second
+
+	// the default and valid range of the duration of the temporary credentials obtained by assuming a role
+	assumeRoleDuration    int = 24 * 60 * 60
+	minAssumeRoleDuration int = 15 * 60
+	maxAssumeRoleDuration int = 24 * 60 * 60
 )
 
+// AssumeRole is the agency to be assumed by the current credentials.
+type AssumeRole struct {
+	AgencyName string
+	DomainName string
+	// Duration is the validity period of the temporary credentials in seconds, defaults to 24 hours
+	Duration int
+	// SessionUserName is the name of the user who assumes the role, which is recorded in the CTS traces
+	SessionUserName string
+}
+
+// sourceCredential is the credential before assuming the roles.
+type sourceCredential struct {
+	AccessKey     string
+	SecretKey     string
+	SecurityToken string
+	DomainID      string
+	DomainName    string
+}
+
 // CLI Shared Config
 type SharedConfig struct {
 	Current  string    `json:"current"`
@@ -47,20 +75,57 @@ type Profile struct {
 	AgencyDomainId   string `json:"agencyDomainId"`
 	AgencyDomainName string `json:"agencyDomainName"`
 	AgencyName       string `json:"agencyName"`
+
+	// the external command which prints the credentials in JSON format, only used in credentialProcess mode
+	CredentialProcess string `json:"credentialProcess"`
+
+	// the configurations of IAM Identity Center, only used in SSO mode
+	SsoStartUrl  string `json:"ssoStartUrl"`
+	SsoRegion    string `json:"ssoRegion"`
+	SsoAccountId string `json:"ssoAccountId"`
+	SsoAgencyUrn string `json:"ssoAgencyUrn"`
+	// the URL of the Identity Center portal, defaults to https://portal.identitycenter.{ssoRegion}.myhuaweicloud.com
+	SsoPortalUrl string `json:"ssoPortalUrl"`
 }
 
+// the modes of profile which are supported by the provider
+const (
+	profileModeAKSK              = "AKSK"
+	profileModeCredentialProcess = "credentialProcess"
+	profileModeSSO               = "SSO"
+)
+
 func buildClient(c *Config) error {
 	if c.Token != "" {
 		return buildClientByToken(c)
 	} else if c.AccessKey != "" && c.SecretKey != "" {
-		return buildClientByAKSK(c)
+		return buildClientByCredentialProvider(c)
 	} else if c.Password != "" && (c.Username != "" || c.UserID != "") {
 		return buildClientByPassword(c)
 	} else if c.SharedConfigFile != "" {
 		return buildClientByConfig(c)
 	}
 
-	return buildClientByMeta(c)
+	return buildClientByCredentialProvider(c)
+}
+
+// buildClientByCredentialProvider retrieves the AK/SK credentials from the credential provider, the default provider
+// chain is used if no provider is specified.
+func buildClientByCredentialProvider(c *Config) error {
+	if c.CredentialProvider == nil {
+		c.CredentialProvider = newDefaultCredentialProvider(c)
+	}
+
+	credentials, err := c.CredentialProvider.Retrieve()
+	if err != nil {
+		return fmt.Errorf("Error fetching Auth credentials, AkSk or ECS agency must be provided: %s", err)
+	}
+	c.setCredentials(credentials)
+	if !c.SecurityKeyExpiresAt.IsZero() {
+		log.Printf("[DEBUG] Successfully got the temporary credentials from %s, which will expire at: %s",
+			c.CredentialProvider, c.SecurityKeyExpiresAt)
+	}
+	return buildClientByAKSK(c)
 }
 
 func generateTLSConfig(c *Config) (*tls.Config, error) {
@@ -126,8 +191,14 @@ func genClient(c *Config, ao golangsdk.AuthOptionsProvider) (*golangsdk.Provider
 
 	client.HTTPClient = http.Client{
 		Transport: &LogRoundTripper{
-			Rt:         transport,
-			MaxRetries: c.MaxRetries,
+			Rt: &RateLimitRoundTripper{
+				Rt:       wrapCassette(c, transport),
+				Limiters: c.RateLimiters,
+			},
+			MaxRetries:  c.MaxRetries,
+			RetryPolicy: c.RetryPolicy,
+			Tracer:      c.Tracer,
+			Redactor:    c.Redactor,
 		},
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if client.AKSKAuthOptions.AccessKey != "" {
@@ -142,7 +213,7 @@ func genClient(c *Config, ao golangsdk.AuthOptionsProvider) (*golangsdk.Provider
 
 	if c.MaxRetries > 0 {
 		client.MaxBackoffRetries = uint(c.MaxRetries)
-		client.RetryBackoffFunc = retryBackoffFunc
+		client.RetryBackoffFunc = retryBackoffWithLimiters(c.RateLimiters)
 	}
 
 	// Validate authentication normally.
@@ -251,29 +322,59 @@ func buildClientByAKSK(c *Config) error {
 }
 
 func buildClientByConfig(c *Config) error {
-	profilePath, err := homedir.Expand(c.SharedConfigFile)
+	providerConfig, err := loadSharedProfile(c.SharedConfigFile, c.Profile)
 	if err != nil {
 		return err
 	}
 
-	current := c.Profile
-	var providerConfig Profile
+	// non required fields
+	if providerConfig.Region != "" {
+		c.Region = providerConfig.Region
+	}
+	if providerConfig.DomainId != "" {
+		c.DomainID = providerConfig.DomainId
+	}
+	if providerConfig.ProjectId != "" {
+		c.TenantID = providerConfig.ProjectId
+	}
+	// assume role, the agency of profile is assumed before the agencies configured in the provider
+	if providerConfig.AgencyName != "" && providerConfig.AgencyDomainName != "" {
+		profileRole := AssumeRole{
+			AgencyName: providerConfig.AgencyName,
+			DomainName: providerConfig.AgencyDomainName,
+		}
+		c.AssumeRoles = append([]AssumeRole{profileRole}, c.AssumeRoles...)
+	}
+
+	// the credentials of profile are retrieved by the provider chain, so that they can be reloaded before expiry
+	return buildClientByCredentialProvider(c)
+}
+
+// loadSharedProfile reads the specified profile from the shared config file, the current profile is used if the name
+// is empty.
+func loadSharedProfile(filePath, name string) (*Profile, error) {
+	profilePath, err := homedir.Expand(filePath)
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = os.Stat(profilePath)
 	if os.IsNotExist(err) {
-		return fmt.Errorf("The specified shared config file %s does not exist", profilePath)
+		return nil, fmt.Errorf("The specified shared config file %s does not exist", profilePath)
 	}
 
 	data, err := os.ReadFile(profilePath)
 	if err != nil {
-		return fmt.Errorf("Err reading from shared config file: %s", err)
+		return nil, fmt.Errorf("Err reading from shared config file: %s", err)
 	}
 	sharedConfig := SharedConfig{}
 	err = json.Unmarshal(data, &sharedConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// fetch current from shared config if not specified with provider
+	current := name
 	if current == "" {
 		current = sharedConfig.Current
 	}
@@ -281,35 +382,10 @@ func buildClientByConfig(c *Config) error {
 	// fetch the current profile config
 	for _, v := range sharedConfig.Profiles {
 		if current == v.Name {
-			providerConfig = v
-			break
+			return &v, nil
 		}
 	}
-	if (providerConfig == Profile{}) {
-		return fmt.Errorf("Error finding profile %s from shared config file", current)
-	}
-
-	c.AccessKey = providerConfig.AccessKeyId
-	c.SecretKey = providerConfig.SecretAccessKey
-	// non required fields
-	if providerConfig.Region != "" {
-		c.Region = providerConfig.Region
-	}
-	if providerConfig.DomainId != "" {
-		c.DomainID = providerConfig.DomainId
-	}
-	if providerConfig.ProjectId != "" {
-		c.TenantID = providerConfig.ProjectId
-	}
-	// assume role
-	if providerConfig.AgencyName != "" {
-		c.AssumeRoleAgency = providerConfig.AgencyName
-	}
-	if providerConfig.AgencyDomainName != "" {
-		c.AssumeRoleDomain = providerConfig.AgencyDomainName
-	}
-
-	return buildClientByAKSK(c)
+	return nil, fmt.Errorf("Error finding profile %s from shared config file", current)
 }
 
 func buildClientByPassword(c *Config) error {
@@ -354,63 +430,187 @@ func buildClientByPassword(c *Config) error {
 }
 
 func buildClientByAgency(c *Config) error {
+	if c.sourceCredential == nil {
+		c.sourceCredential = &sourceCredential{
+			AccessKey:     c.AccessKey,
+			SecretKey:     c.SecretKey,
+			SecurityToken: c.SecurityToken,
+			DomainID:      c.DomainID,
+			DomainName:    c.DomainName,
+		}
+	}
+
+	if err := assumeRoles(c); err != nil {
+		return err
+	}
+	return buildClientByAKSK(c)
+}
+
+// assumeRoles assumes the agencies in order, the temporary credentials of the last agency are saved in the config.
+func assumeRoles(c *Config) error {
+	var expiresAt time.Time
+	hopConfig := *c
+	// the credentials used to assume the roles are refreshed before calling this function
+	hopConfig.SecurityKeyExpiresAt = time.Time{}
+	for i, role := range c.AssumeRoles {
+		credential, err := createTemporaryAccessKeyByAgency(&hopConfig, role)
+		if err != nil {
+			return fmt.Errorf("Error Creating temporary accesskey by agency %s of domain %s: %s",
+				role.AgencyName, role.DomainName, err)
+		}
+		log.Printf("[DEBUG] Successfully assumed the agency %s of domain %s (%d/%d), which will expire at: %s",
+			role.AgencyName, role.DomainName, i+1, len(c.AssumeRoles), credential.ExpiresAt)
+
+		hopConfig.AccessKey, hopConfig.SecretKey, hopConfig.SecurityToken = credential.Access, credential.Secret,
+			credential.Securitytoken
+		// the temporary credentials belong to the domain of agency, so the domain ID will be queried by them
+		hopConfig.DomainID = ""
+
+		if t, err := time.Parse(time.RFC3339, credential.ExpiresAt); err == nil {
+			expiresAt = t
+		} else {
+			log.Printf("[WARN] failed to parse the expiration time of the temporary credentials: %s", err)
+		}
+	}
+
+	// the temporary credentials belong to the domain of the last agency, the domain ID will be rediscovered when
+	// loading the config if it fails to be queried here
+	lastRole := c.AssumeRoles[len(c.AssumeRoles)-1]
+	domainID, err := getAuthDomainID(&hopConfig)
+	if err != nil {
+		log.Printf("[WARN] failed to query the domain ID of agency %s: %s", lastRole.AgencyName, err)
+	}
+
+	c.AccessKey, c.SecretKey, c.SecurityToken = hopConfig.AccessKey, hopConfig.SecretKey, hopConfig.SecurityToken
+	c.DomainID, c.DomainName = domainID, lastRole.DomainName
+	c.SecurityKeyExpiresAt = expiresAt
+	return nil
+}
+
+// getAuthDomainID queries the ID of the domain which the credentials belong to.
+func getAuthDomainID(c *Config) (string, error) {
 	client, err := c.HcIamV3Client(c.Region)
 	if err != nil {
-		return fmt.Errorf("Error creating Huaweicloud IAM client: %s", err)
+		return "", fmt.Errorf("Error creating Huaweicloud IAM client: %s", err)
 	}
 
-	request := &iam_model.CreateTemporaryAccessKeyByAgencyRequest{}
-	domainNameAssumeRoleIdentityAssumerole := c.AssumeRoleDomain
-	durationSecondsAssumeRoleIdentityAssumerole := assumeRoleDuration
-	assumeRoleIdentity := &iam_model.IdentityAssumerole{
-		AgencyName:      c.AssumeRoleAgency,
-		DomainName:      &domainNameAssumeRoleIdentityAssumerole,
-		DurationSeconds: &durationSecondsAssumeRoleIdentityAssumerole,
+	response, err := client.KeystoneListAuthDomains(&iam_model.KeystoneListAuthDomainsRequest{})
+	if err != nil {
+		return "", err
+	}
+	if response.Domains == nil || len(*response.Domains) == 0 {
+		return "", fmt.Errorf("domain was not found")
+	}
+	return (*response.Domains)[0].Id, nil
+}
+
+func createTemporaryAccessKeyByAgency(c *Config, role AssumeRole) (*iam_model.Credential, error) {
+	client, err := c.HcIamV3Client(c.Region)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating Huaweicloud IAM client: %s", err)
 	}
-	var listMethodsIdentity = []iam_model.AgencyAuthIdentityMethods{
-		iam_model.GetAgencyAuthIdentityMethodsEnum().ASSUME_ROLE,
+
+	duration := int32(assumeRoleDuration)
+	if role.Duration != 0 {
+		duration = int32(role.Duration)
 	}
-	identityAuth := &iam_model.AgencyAuthIdentity{
-		Methods:    listMethodsIdentity,
-		AssumeRole: assumeRoleIdentity,
+	assumeRoleIdentity := &iam_model.IdentityAssumerole{
+		AgencyName:      role.AgencyName,
+		DomainName:      &role.DomainName,
+		DurationSeconds: &duration,
 	}
-	authbody := &iam_model.AgencyAuth{
-		Identity: identityAuth,
+	if role.SessionUserName != "" {
+		assumeRoleIdentity.SessionUser = &iam_model.AssumeroleSessionuser{
+			Name: &role.SessionUserName,
+		}
 	}
-	request.Body = &iam_model.CreateTemporaryAccessKeyByAgencyRequestBody{
-		Auth: authbody,
+
+	request := &iam_model.CreateTemporaryAccessKeyByAgencyRequest{
+		Body: &iam_model.CreateTemporaryAccessKeyByAgencyRequestBody{
+			Auth: &iam_model.AgencyAuth{
+				Identity: &iam_model.AgencyAuthIdentity{
+					Methods: []iam_model.AgencyAuthIdentityMethods{
+						iam_model.GetAgencyAuthIdentityMethodsEnum().ASSUME_ROLE,
+					},
+					AssumeRole: assumeRoleIdentity,
+				},
+			},
+		},
 	}
 	response, err := client.CreateTemporaryAccessKeyByAgency(request)
 	if err != nil {
-		return fmt.Errorf("Error Creating temporary accesskey by agency: %s", err)
+		return nil, err
 	}
-	c.AccessKey, c.SecretKey, c.SecurityToken = response.Credential.Access, response.Credential.Secret, response.Credential.Securitytoken
-
-	return buildClientByAKSK(c)
+	if response.Credential == nil {
+		return nil, fmt.Errorf("the credential is not found in the response")
+	}
+	return response.Credential, nil
 }
 
+// reloadSecurityKey reloads the credentials which are nearing expiry, the roles are re-assumed if configured,
+// otherwise the credentials are retrieved from the credential provider again.
 func (c *Config) reloadSecurityKey() error {
-	err := getAuthConfigByMeta(c)
+	if c.sourceCredential != nil {
+		return c.reassumeRoles()
+	}
+
+	if c.CredentialProvider == nil {
+		return fmt.Errorf("Error reloading Auth credentials: the credential provider is not found")
+	}
+	credentials, err := c.CredentialProvider.Retrieve()
 	if err != nil {
-		return fmt.Errorf("Error reloading Auth credentials from ECS Metadata API: %s", err)
+		return fmt.Errorf("Error reloading Auth credentials from %s: %s", c.CredentialProvider, err)
+	}
+	c.setCredentials(credentials)
+	log.Printf("Successfully reload the credentials from %s, which will expire at: %s", c.CredentialProvider,
+		c.SecurityKeyExpiresAt)
+	return buildClientByAKSK(c)
+}
+
+func (c *Config) reassumeRoles() error {
+	source := c.sourceCredential
+	c.AccessKey, c.SecretKey, c.SecurityToken = source.AccessKey, source.SecretKey, source.SecurityToken
+	c.DomainID, c.DomainName = source.DomainID, source.DomainName
+	if c.CredentialProvider != nil {
+		credentials, err := c.CredentialProvider.Retrieve()
+		if err != nil {
+			return fmt.Errorf("Error reloading Auth credentials from %s: %s", c.CredentialProvider, err)
+		}
+		c.setCredentials(credentials)
 	}
-	log.Printf("Successfully reload metadata security key, which will expire at: %s", c.SecurityKeyExpiresAt)
+
+	if err := assumeRoles(c); err != nil {
+		return err
+	}
+	log.Printf("Successfully re-assumed the roles, the credentials will expire at: %s", c.SecurityKeyExpiresAt)
 	return buildClientByAKSK(c)
 }
 
-func getAuthConfigByMeta(c *Config) error {
+// setCredentials saves the retrieved credentials in the config.
+func (c *Config) setCredentials(credentials *Credentials) {
+	c.AccessKey, c.SecretKey, c.SecurityToken = credentials.AccessKey, credentials.SecretKey, credentials.SecurityToken
+	c.SecurityKeyExpiresAt = credentials.ExpiresAt
+}
+
+// getCredentialsByMeta fetches the temporary credentials of the ECS agency from the metadata API.
+func getCredentialsByMeta() (*Credentials, error) {
 	req, err := http.NewRequest("GET", securityKeyURL, nil)
 	if err != nil {
-		return fmt.Errorf("Error building metadata API request: %s", err.Error())
+		return nil, fmt.Errorf("Error building metadata API request: %s", err.Error())
 	}
 
-	httpClient := &http.Client{}
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{Timeout: metadataConnectTimeout}).DialContext,
+		},
+		Timeout: metadataTimeout,
+	}
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("Error requesting metadata API: %s", err.Error())
+		return nil, fmt.Errorf("Error requesting metadata API: %s", err.Error())
 	}
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Error requesting metadata API: status code = %d", resp.StatusCode)
+		return nil, fmt.Errorf("Error requesting metadata API: status code = %d", resp.StatusCode)
 	}
 
 	var parsedBody interface{}
@@ -418,48 +618,44 @@ func getAuthConfigByMeta(c *Config) error {
 	defer resp.Body.Close()
 	rawBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("Error parsing metadata API response: %s", err.Error())
+		return nil, fmt.Errorf("Error parsing metadata API response: %s", err.Error())
 	}
 
 	err = json.Unmarshal(rawBody, &parsedBody)
 	if err != nil {
-		return fmt.Errorf("Error unmarshal metadata API, agency_name is empty: %s", err.Error())
+		return nil, fmt.Errorf("Error unmarshal metadata API, agency_name is empty: %s", err.Error())
 	}
 
 	expiresAt, err := jmespath.Search("credential.expires_at", parsedBody)
 	if err != nil {
-		return fmt.Errorf("Error fetching metadata expires_at: %s", err.Error())
+		return nil, fmt.Errorf("Error fetching metadata expires_at: %s", err.Error())
 	}
 	accessKey, err := jmespath.Search("credential.access", parsedBody)
 	if err != nil {
-		return fmt.Errorf("Error fetching metadata access: %s", err.Error())
+		return nil, fmt.Errorf("Error fetching metadata access: %s", err.Error())
 	}
 	secretKey, err := jmespath.Search("credential.secret", parsedBody)
 	if err != nil {
-		return fmt.Errorf("Error fetching metadata secret: %s", err.Error())
+		return nil, fmt.Errorf("Error fetching metadata secret: %s", err.Error())
 	}
 	securityToken, err := jmespath.Search("credential.securitytoken", parsedBody)
 	if err != nil {
-		return fmt.Errorf("Error fetching metadata securitytoken: %s", err.Error())
+		return nil, fmt.Errorf("Error fetching metadata securitytoken: %s", err.Error())
 	}
 
 	if accessKey == nil || secretKey == nil || securityToken == nil || expiresAt == nil {
-		return fmt.Errorf("Error fetching metadata authentication information")
+		return nil, fmt.Errorf("Error fetching metadata authentication information")
 	}
 	expairesTime, err := time.Parse(time.RFC3339, expiresAt.(string))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	c.AccessKey, c.SecretKey, c.SecurityToken, c.SecurityKeyExpiresAt = accessKey.(string), secretKey.(string), securityToken.(string), expairesTime
 
-	return nil
-}
-
-func buildClientByMeta(c *Config) error {
-	err := getAuthConfigByMeta(c)
-	if err != nil {
-		return fmt.Errorf("Error fetching Auth credentials from ECS Metadata API, AkSk or ECS agency must be provided: %s", err)
+	credentials := Credentials{
+		AccessKey:     accessKey.(string),
+		SecretKey:     secretKey.(string),
+		SecurityToken: securityToken.(string),
+		ExpiresAt:     expairesTime,
 	}
-	log.Printf("[DEBUG] Successfully got metadata security key, which will expire at: %s", c.SecurityKeyExpiresAt)
-	return buildClientByAKSK(c)
+	return &credentials, nil
 }
@@ -0,0 +1,365 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// CassetteModeRecord sends the requests to the cloud and records the sanitized interactions to the cassette
+	CassetteModeRecord = "record"
+	// CassetteModeReplay replays the recorded interactions without sending any request to the cloud
+	CassetteModeReplay = "replay"
+)
+
+// CassetteInteraction is a pair of the recorded request and response.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is the recorded request, the headers are not recorded as they contain the signatures.
+type CassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// CassetteResponse is the recorded response, the sensitive headers and fields of body are redacted.
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type cassetteFile struct {
+	Seed         int64                 `json:"seed"`
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// Cassette records the HTTP interactions of a test to a file, and replays them in order so that the test can run
+// offline and deterministically.
+type Cassette struct {
+	Path string
+	Mode string
+	// Seed is used to generate the deterministic random values (e.g. resource names) of the test
+	Seed int64
+	// HostAliases maps the hosts of the requests to the hosts recorded in the cassette, so that the interactions
+	// recorded against the servers listening on random ports (e.g. the fake cloud) can be replayed with stable hosts
+	HostAliases map[string]string
+
+	mu           sync.Mutex
+	interactions []CassetteInteraction
+	used         []bool
+}
+
+// NewCassette creates a cassette in record mode, or loads the recorded cassette in replay mode.
+func NewCassette(path, mode string) (*Cassette, error) {
+	cassette := Cassette{
+		Path: path,
+		Mode: mode,
+	}
+
+	switch mode {
+	case CassetteModeRecord:
+		cassette.Seed = time.Now().UnixNano()
+	case CassetteModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the cassette %s: %s", path, err)
+		}
+
+		var file cassetteFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("error parsing the cassette %s: %s", path, err)
+		}
+		cassette.Seed = file.Seed
+		cassette.interactions = file.Interactions
+		cassette.used = make([]bool, len(file.Interactions))
+	default:
+		return nil, fmt.Errorf("invalid cassette mode %q, only %q and %q are supported", mode, CassetteModeRecord,
+			CassetteModeReplay)
+	}
+	return &cassette, nil
+}
+
+// Save writes the recorded interactions to the cassette file, it does nothing in replay mode.
+func (c *Cassette) Save() error {
+	if c.Mode != CassetteModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	file := cassetteFile{
+		Seed:         c.Seed,
+		Interactions: c.interactions,
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, data, 0600)
+}
+
+// RoundTripper wraps the transport to record or replay the interactions, the sensitive fields are redacted by the
+// redactor before they are recorded.
+func (c *Cassette) RoundTripper(rt http.RoundTripper, redactor *Redactor) http.RoundTripper {
+	return &CassetteRoundTripper{
+		Rt:       rt,
+		Cassette: c,
+		Redactor: redactor,
+	}
+}
+
+// HTTPTransport returns a transport which delegates the requests to the cassette, it is used by the
+// huaweicloud-sdk-go-v3 clients which only accept *http.Transport.
+func (c *Cassette) HTTPTransport(rt http.RoundTripper, redactor *Redactor) *http.Transport {
+	transport := &http.Transport{}
+	cassetteRt := c.RoundTripper(rt, redactor)
+	transport.RegisterProtocol("https", cassetteRt)
+	transport.RegisterProtocol("http", cassetteRt)
+	return transport
+}
+
+func (c *Cassette) record(redactor *Redactor, req *http.Request, reqBody []byte, resp *http.Response,
+	respBody []byte) {
+	headers := make(http.Header)
+	for name, values := range resp.Header {
+		for _, v := range values {
+			if redactor.isSensitiveHeader(name) {
+				v = redactedValue
+			}
+			headers.Add(name, v)
+		}
+	}
+
+	requestURL := req.URL
+	if alias, ok := c.HostAliases[requestURL.Host]; ok {
+		aliasURL := *requestURL
+		aliasURL.Host = alias
+		requestURL = &aliasURL
+	}
+
+	interaction := CassetteInteraction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			URL:    redactor.RedactURL(requestURL),
+			Body:   sanitizeCassetteBody(redactor, reqBody),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    headers,
+			Body:       sanitizeCassetteBody(redactor, respBody),
+		},
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+}
+
+// replay returns the response of the first unused interaction which has the same method and URL, the query is
+// ignored if no such interaction. The last matched interaction is reused when all of them have been used, e.g. the
+// polling requests may be sent more times than they were recorded.
+func (c *Cassette) replay(redactor *Redactor, req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := -1
+	for _, withQuery := range []bool{true, false} {
+		if index = c.match(redactor, req, withQuery, false); index >= 0 {
+			break
+		}
+	}
+	if index < 0 {
+		for _, withQuery := range []bool{true, false} {
+			if index = c.match(redactor, req, withQuery, true); index >= 0 {
+				break
+			}
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("no interaction of %s %s is recorded in the cassette %s", req.Method,
+			redactor.RedactURL(req.URL), c.Path)
+	}
+
+	c.used[index] = true
+	recorded := c.interactions[index].Response
+	resp := http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Headers.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	return &resp, nil
+}
+
+// match returns the index of the first unused (or last used if reused is true) interaction of the request, the
+// sensitive query parameters are redacted before matching as they are redacted in the recorded URLs.
+func (c *Cassette) match(redactor *Redactor, req *http.Request, withQuery, reused bool) int {
+	requestURL, err := url.Parse(redactor.RedactURL(req.URL))
+	if err != nil {
+		return -1
+	}
+	key := cassetteKey(req.Method, requestURL, withQuery)
+	matched := -1
+	for i, interaction := range c.interactions {
+		if c.used[i] != reused {
+			continue
+		}
+
+		recordedURL, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			continue
+		}
+		if recordedURL, err = url.Parse(redactor.RedactURL(recordedURL)); err != nil ||
+			cassetteKey(interaction.Request.Method, recordedURL, withQuery) != key {
+			continue
+		}
+		if !reused {
+			return i
+		}
+		matched = i
+	}
+	return matched
+}
+
+func cassetteKey(method string, u *url.URL, withQuery bool) string {
+	key := fmt.Sprintf("%s %s%s", method, u.Host, u.EscapedPath())
+	if withQuery {
+		// the encoded query is sorted by key
+		key += "?" + u.Query().Encode()
+	}
+	return key
+}
+
+// sanitizeCassetteBody redacts the sensitive fields of JSON and XML body, other content is kept as it is.
+func sanitizeCassetteBody(redactor *Redactor, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return redactor.redactXML(string(body))
+	}
+
+	switch data := data.(type) {
+	case map[string]interface{}:
+		redactCassetteFields(redactor, data)
+	case []interface{}:
+		for _, item := range data {
+			if m, ok := item.(map[string]interface{}); ok {
+				redactCassetteFields(redactor, m)
+			}
+		}
+	default:
+		return string(body)
+	}
+
+	sanitized, err := json.Marshal(data)
+	if err != nil {
+		return string(body)
+	}
+	return string(sanitized)
+}
+
+// redactCassetteFields is similar to maskSecurityFields, but the large strings are kept so that the recorded
+// responses can be replayed.
+func redactCassetteFields(redactor *Redactor, data map[string]interface{}) {
+	for k, val := range data {
+		if redactor.isSecurityFields(k) {
+			data[k] = redactedValue
+			continue
+		}
+
+		switch val := val.(type) {
+		case map[string]interface{}:
+			redactCassetteFields(redactor, val)
+		case []interface{}:
+			for _, item := range val {
+				if m, ok := item.(map[string]interface{}); ok {
+					redactCassetteFields(redactor, m)
+				}
+			}
+		}
+	}
+}
+
+// CassetteRoundTripper records the interactions to the cassette in record mode, or returns the recorded responses
+// without sending the requests in replay mode.
+type CassetteRoundTripper struct {
+	Rt       http.RoundTripper
+	Cassette *Cassette
+	Redactor *Redactor
+}
+
+// RoundTrip records or replays the request.
+func (rt *CassetteRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.Cassette.Mode == CassetteModeReplay {
+		if req.Body != nil {
+			// drain the body so that the request can be reused by the caller
+			_, _ = io.Copy(io.Discard, req.Body)
+			req.Body.Close()
+		}
+		return rt.Cassette.replay(rt.Redactor, req)
+	}
+
+	var reqBody []byte
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = body
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := rt.Rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	rt.Cassette.record(rt.Redactor, req, reqBody, resp, respBody)
+	return resp, nil
+}
+
+// wrapCassette wraps the transport with the cassette of config if it is set.
+func wrapCassette(c *Config, rt http.RoundTripper) http.RoundTripper {
+	if c.Cassette == nil {
+		return rt
+	}
+	log.Printf("[DEBUG] the HTTP interactions are in %s mode with cassette %s", c.Cassette.Mode, c.Cassette.Path)
+	return c.Cassette.RoundTripper(rt, c.Redactor)
+}
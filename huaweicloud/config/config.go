@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -20,6 +21,7 @@ import (
 	"github.com/chnsz/golangsdk/openstack/obs"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/mutexkv"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
 const (
@@ -46,24 +48,72 @@ type Config struct {
 	TenantName          string
 	Token               string
 	SecurityToken       string
-	AssumeRoleAgency    string
-	AssumeRoleDomain    string
 	Cloud               string
 	MaxRetries          int
+	RetryPolicy         *RetryPolicy
 	TerraformVersion    string
 	RegionClient        bool
 	EnterpriseProjectID string
 	SharedConfigFile    string
 	Profile             string
 
-	// metadata security key expires at
+	// AssumeRoles is an ordered list of the agencies to be assumed, each agency is assumed by the temporary
+	// credentials of the previous one
+	AssumeRoles []AssumeRole
+
+	// metadata security key or temporary credentials of the assumed role expires at
 	SecurityKeyExpiresAt time.Time
 
+	// WebIdentity is used to exchange the OIDC ID token for the temporary credentials
+	WebIdentity *WebIdentity
+	// CredentialProcess is the external command which prints the credentials in JSON format
+	CredentialProcess string
+	// CredentialProvider is used to retrieve and reload the AK/SK credentials
+	CredentialProvider CredentialProvider
+
+	// sourceCredential is the credential used to assume the first role, which is saved to re-assume the roles
+	sourceCredential *sourceCredential
+
 	HwClient     *golangsdk.ProviderClient
 	DomainClient *golangsdk.ProviderClient
 
-	// the custom endpoints used to override the default endpoint URL
+	// the custom endpoints used to override the default endpoint URL, the {region} and {project_id} placeholders
+	// in the URL are replaced with the region and project ID of the resource
 	Endpoints map[string]string
+	// RegionEndpoints is a map of the region and the custom endpoints which take precedence over Endpoints in the region
+	RegionEndpoints map[string]map[string]string
+
+	// TraceFile is the path of the file which records one JSON record per API call
+	TraceFile string
+	// Tracer is used to append the records of API calls to the TraceFile
+	Tracer *Tracer
+
+	// Cassette is used to record or replay the HTTP interactions in the offline tests
+	Cassette *Cassette
+
+	// ProviderTags is the provider-level default_tags and ignore_tags configuration
+	ProviderTags *utils.ProviderTagsConfig
+
+	// SensitiveLogFields is a list of the additional fields that need to be redacted in the debug logs
+	SensitiveLogFields []string
+	// Redactor is used to redact the built-in sensitive fields and SensitiveLogFields in the debug logs
+	Redactor *Redactor
+
+	// RateLimits is a map of the service name and the allowed requests per second on the client side
+	RateLimits map[string]int
+	// RateLimiters is used to throttle the requests of each service before sending them to the API
+	RateLimiters *RateLimiters
+
+	// DiscoveryCacheFile is the path of the file which caches the discovered IDs between runs, the cache is disabled
+	// if it is empty
+	DiscoveryCacheFile string
+	// DiscoveryCacheTTL is the time to live of the cached IDs
+	DiscoveryCacheTTL time.Duration
+	// DiscoveryCache is used to load and save the discovered IDs
+	DiscoveryCache *DiscoveryCache
+
+	// PreflightPolicies are the local policies which are evaluated against the planned attributes of the resources
+	PreflightPolicies []PreflightPolicy
 
 	// RegionProjectIDMap is a map which stores the region-projectId pairs,
 	// and region name will be the key and projectID will be the value in this map.
@@ -94,7 +144,53 @@ func (c *Config) LoadAndValidate() error {
 		return fmt.Errorf("max_retries should be a positive value")
 	}
 
-	err := buildClient(c)
+	for _, role := range c.AssumeRoles {
+		if role.Duration != 0 && (role.Duration < minAssumeRoleDuration || role.Duration > maxAssumeRoleDuration) {
+			return fmt.Errorf("the duration of assume role %s should be between %d and %d seconds",
+				role.AgencyName, minAssumeRoleDuration, maxAssumeRoleDuration)
+		}
+	}
+
+	if c.WebIdentity != nil && c.WebIdentity.Duration != 0 &&
+		(c.WebIdentity.Duration < minWebIdentityDuration || c.WebIdentity.Duration > maxWebIdentityDuration) {
+		return fmt.Errorf("the duration of assume_role_with_web_identity should be between %d and %d seconds",
+			minWebIdentityDuration, maxWebIdentityDuration)
+	}
+
+	for srv, limit := range c.RateLimits {
+		if limit <= 0 {
+			return fmt.Errorf("the rate limit of %s should be a positive value", srv)
+		}
+	}
+	c.RateLimiters = NewRateLimiters(c.RateLimits, c.Endpoints)
+	c.Redactor = NewRedactor(c.SensitiveLogFields)
+
+	tracer, err := NewTracer(c.TraceFile)
+	if err != nil {
+		return err
+	}
+	c.Tracer = tracer
+
+	// the fingerprint of identity must be calculated before building the clients, as the credentials may be replaced
+	c.DiscoveryCache, err = newDiscoveryCache(c)
+	if err != nil {
+		return err
+	}
+	cachedEntry, cacheHit := c.DiscoveryCache.Load()
+	tenantID := c.TenantID
+	cacheApplied := c.applyDiscoveryCache(cachedEntry)
+
+	err = buildClient(c)
+	if err != nil && cacheHit && (isAuthFailure(err) || cacheApplied && isProjectRejected(err)) {
+		// the cached IDs may be outdated, invalidate the whole entry and authenticate again if they were used
+		log.Printf("[WARN] failed to authenticate with the discovery cache, invalidate the cache: %s", err)
+		c.DiscoveryCache.Invalidate()
+		cacheHit = false
+		if cacheApplied {
+			c.TenantID = tenantID
+			err = buildClient(c)
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -104,19 +200,40 @@ func (c *Config) LoadAndValidate() error {
 	}
 
 	// Assume role
-	if c.AssumeRoleAgency != "" {
+	if len(c.AssumeRoles) > 0 {
 		err = buildClientByAgency(c)
 		if err != nil {
 			return err
 		}
 	}
 
+	if cacheHit && cachedEntry.isStale(c) {
+		log.Printf("[WARN] the discovery cache conflicts with the authenticated identity, invalidate the cache")
+		c.DiscoveryCache.Invalidate()
+		cacheHit = false
+	}
+	if cacheHit {
+		for region, projectID := range cachedEntry.Projects {
+			c.RegionProjectIDMap[region] = projectID
+		}
+		if c.DomainID == "" && cachedEntry.DomainID != "" {
+			c.DomainID = cachedEntry.DomainID
+			if c.DomainClient.AKSKAuthOptions.AccessKey != "" {
+				c.DomainClient.AKSKAuthOptions.DomainID = c.DomainID
+			}
+		}
+		if c.UserID == "" && c.Username != "" {
+			c.UserID = cachedEntry.UserID
+		}
+	}
+
 	if c.HwClient != nil && c.HwClient.ProjectID != "" {
 		c.RegionProjectIDMap[c.Region] = c.HwClient.ProjectID
 	}
 	log.Printf("[DEBUG] init region and project map: %#v", c.RegionProjectIDMap)
 
 	// set DomainID for IAM resource
+	var discoveryErr error
 	if c.DomainID == "" {
 		if domainID, err := c.getDomainID(); err == nil {
 			c.DomainID = domainID
@@ -127,6 +244,7 @@ func (c *Config) LoadAndValidate() error {
 			}
 		} else {
 			log.Printf("[WARN] get domain id failed: %s", err)
+			discoveryErr = err
 		}
 	}
 
@@ -135,21 +253,64 @@ func (c *Config) LoadAndValidate() error {
 			c.UserID = userID
 		} else {
 			log.Printf("[WARN] get user id failed: %s", err)
+			discoveryErr = err
 		}
 	}
 
+	switch {
+	case discoveryErr == nil:
+		c.saveDiscoveryCache()
+	case isAuthFailure(discoveryErr):
+		// the cached IDs may belong to the rejected identity
+		c.DiscoveryCache.Invalidate()
+	default:
+		// the partial IDs are not cached, so they will be discovered again in the next run
+		log.Printf("[DEBUG] the discovery cache is not updated as the discovery of IDs failed")
+	}
 	return nil
 }
 
-func retryBackoffFunc(ctx context.Context, respErr *golangsdk.ErrUnexpectedResponseCode, e error, retries uint) error {
-	minutes := int(math.Pow(2, float64(retries)))
-	if minutes > 30 { // won't wait more than 30 minutes
-		minutes = 30
-	}
+// isAuthFailure checks whether the request is rejected by the API because of the credentials or the IDs used by them,
+// the other errors (e.g. the network errors and the server errors) do not mean that the cached IDs are outdated.
+func isAuthFailure(err error) bool {
+	var err401 golangsdk.ErrDefault401
+	var err403 golangsdk.ErrDefault403
+	return errors.As(err, &err401) || errors.As(err, &err403)
+}
+
+// isProjectRejected checks whether the project ID used in the authentication is rejected by the API.
+func isProjectRejected(err error) bool {
+	var err400 golangsdk.ErrDefault400
+	var err404 golangsdk.ErrDefault404
+	return errors.As(err, &err400) || errors.As(err, &err404)
+}
+
+// retryBackoffWithLimiters returns a RetryFunc which prefers the duration specified by the Retry-After header
+// received from the service, and falls back to the exponential backoff.
+func retryBackoffWithLimiters(limiters *RateLimiters) golangsdk.RetryFunc {
+	return func(ctx context.Context, respErr *golangsdk.ErrUnexpectedResponseCode, e error, retries uint) error {
+		var sleep time.Duration
+		if respErr != nil {
+			sleep = limiters.GetRetryAfter(respErr.URL)
+		}
+
+		if sleep > 0 {
+			log.Printf("[WARN] Received StatusTooManyRequests response code, try to sleep %s as Retry-After header", sleep)
+		} else {
+			minutes := int(math.Pow(2, float64(retries)))
+			if minutes > 30 { // won't wait more than 30 minutes
+				minutes = 30
+			}
+
+			log.Printf("[WARN] Received StatusTooManyRequests response code, try to sleep %d minutes", minutes)
+			sleep = time.Duration(minutes) * time.Minute
+		}
 
-	log.Printf("[WARN] Received StatusTooManyRequests response code, try to sleep %d minutes", minutes)
-	sleep := time.Duration(minutes) * time.Minute
+		return backoffSleep(ctx, e, sleep)
+	}
+}
 
+func backoffSleep(ctx context.Context, e error, sleep time.Duration) error {
 	if ctx != nil {
 		select {
 		case <-time.After(sleep):
@@ -165,6 +326,11 @@ func retryBackoffFunc(ctx context.Context, respErr *golangsdk.ErrUnexpectedRespo
 }
 
 func getObsEndpoint(c *Config, region string) string {
+	if isRegionalCustomEndpoint(c, "obs", region) {
+		endpoint, _ := c.getCustomEndpoint("obs", region)
+		return endpoint
+	}
+
 	if endpoint, ok := c.Endpoints["obs"]; ok {
 		// replace the region in customizing OBS endpoint
 		subparts := strings.Split(endpoint, ".")
@@ -200,17 +366,8 @@ func (c *Config) ObjectStorageClient(region string) (*obs.ObsClient, error) {
 		return nil, fmt.Errorf("missing credentials for OBS, need access_key and secret_key values for provider")
 	}
 
-	if !c.SecurityKeyExpiresAt.IsZero() {
-		c.SecurityKeyLock.Lock()
-		defer c.SecurityKeyLock.Unlock()
-		timeNow := time.Now().Unix()
-		expairesAtInt := c.SecurityKeyExpiresAt.Unix()
-		if timeNow+keyExpiresDuration > expairesAtInt {
-			err := c.reloadSecurityKey()
-			if err != nil {
-				return nil, err
-			}
-		}
+	if err := c.refreshCredentials(); err != nil {
+		return nil, err
 	}
 
 	clientConfigure := obs.WithHttpClient(&c.DomainClient.HTTPClient)
@@ -224,6 +381,51 @@ func (c *Config) ObjectStorageClient(region string) (*obs.ObsClient, error) {
 	return obs.New(c.AccessKey, c.SecretKey, obsEndpoint, clientConfigure, userAgentConfigure, envProxyConfigure)
 }
 
+// refreshCredentials reloads the temporary credentials if they are nearing expiry.
+func (c *Config) refreshCredentials() error {
+	if c.SecurityKeyExpiresAt.IsZero() {
+		return nil
+	}
+
+	c.SecurityKeyLock.Lock()
+	defer c.SecurityKeyLock.Unlock()
+	timeNow := time.Now().Unix()
+	expairesAtInt := c.SecurityKeyExpiresAt.Unix()
+	if timeNow+keyExpiresDuration > expairesAtInt {
+		return c.reloadSecurityKey()
+	}
+	return nil
+}
+
+// withCredentialsReauth makes the service client reload the temporary credentials when the API rejects them.
+// The credentials are only refreshed when a client is created, so the clients which live longer than the credentials,
+// e.g. the ones polling a long-running job, re-sign the rejected request with the reloaded credentials.
+func (c *Config) withCredentialsReauth(sc *golangsdk.ServiceClient) *golangsdk.ServiceClient {
+	if sc == nil || c.SecurityKeyExpiresAt.IsZero() || sc.AKSKAuthOptions.AccessKey == "" {
+		return sc
+	}
+
+	// the provider client may be shared by the service clients, so the credentials are updated in a copy of it
+	client := *sc.ProviderClient
+	client.ReauthFunc = func() error {
+		c.SecurityKeyLock.Lock()
+		defer c.SecurityKeyLock.Unlock()
+
+		// the credentials may have been reloaded by another client since the request was signed
+		if client.AKSKAuthOptions.AccessKey == c.AccessKey {
+			if err := c.reloadSecurityKey(); err != nil {
+				return err
+			}
+		}
+		client.AKSKAuthOptions.AccessKey = c.AccessKey
+		client.AKSKAuthOptions.SecretKey = c.SecretKey
+		client.AKSKAuthOptions.SecurityToken = c.SecurityToken
+		return nil
+	}
+	sc.ProviderClient = &client
+	return sc
+}
+
 func buildObsUserAgent() string {
 	var agent string = providerUserAgent
 	if customUserAgent := os.Getenv("HW_TF_CUSTOM_UA"); customUserAgent != "" {
@@ -242,17 +444,8 @@ func (c *Config) NewServiceClient(srv, region string) (*golangsdk.ServiceClient,
 		return nil, fmt.Errorf("service type %s is invalid or not supportted", srv)
 	}
 
-	if !c.SecurityKeyExpiresAt.IsZero() {
-		c.SecurityKeyLock.Lock()
-		defer c.SecurityKeyLock.Unlock()
-		timeNow := time.Now().Unix()
-		expairesAtInt := c.SecurityKeyExpiresAt.Unix()
-		if timeNow+keyExpiresDuration > expairesAtInt {
-			err := c.reloadSecurityKey()
-			if err != nil {
-				return nil, err
-			}
-		}
+	if err := c.refreshCredentials(); err != nil {
+		return nil, err
 	}
 
 	client := c.HwClient
@@ -260,13 +453,52 @@ func (c *Config) NewServiceClient(srv, region string) (*golangsdk.ServiceClient,
 		client = c.DomainClient
 	}
 
-	if endpoint, ok := c.Endpoints[srv]; ok {
-		if region != "" && region != c.Region {
-			return nil, fmt.Errorf("Resource-level region must be the same as Provider-level region when using customizing endpoints")
+	if endpoint, ok := c.lookupCustomEndpoint(srv, region); ok {
+		// the static endpoint only belongs to the Provider-level region
+		if region != "" && region != c.Region && !isRegionalCustomEndpoint(c, srv, region) {
+			return nil, fmt.Errorf("Resource-level region must be the same as Provider-level region when using " +
+				"customizing endpoints without the {region} placeholder")
+		}
+		sc, err := c.newServiceClientByEndpoint(client, srv, endpoint, region)
+		return c.withCredentialsReauth(sc), err
+	}
+	sc, err := c.newServiceClientByName(client, serviceCatalog, region)
+	return c.withCredentialsReauth(sc), err
+}
+
+// getRegionProjectID returns the project ID of the region, the project ID will be queried and stored if not found.
+func (c *Config) getRegionProjectID(client *golangsdk.ProviderClient, region string) (string, error) {
+	c.RPLock.Lock()
+	projectID, ok := c.RegionProjectIDMap[region]
+	if ok {
+		c.RPLock.Unlock()
+		return projectID, nil
+	}
+
+	// Not find in the map, then try to query and store.
+	err := c.loadUserProjects(client, region)
+	projectID = c.RegionProjectIDMap[region]
+	c.RPLock.Unlock()
+
+	// the cache file is written after releasing the lock, so the other requests are not blocked by the file I/O
+	if err != nil {
+		if isAuthFailure(err) {
+			c.DiscoveryCache.Invalidate()
 		}
-		return c.newServiceClientByEndpoint(client, srv, endpoint)
+		return "", err
 	}
-	return c.newServiceClientByName(client, serviceCatalog, region)
+	c.saveDiscoveryCache()
+	return projectID, nil
+}
+
+// cloneProviderClient returns a copy of the ProviderClient with the ProjectID and region updated
+func cloneProviderClient(client *golangsdk.ProviderClient, projectID, region string) *golangsdk.ProviderClient {
+	clone := new(golangsdk.ProviderClient)
+	*clone = *client
+	clone.ProjectID = projectID
+	clone.AKSKAuthOptions.ProjectId = projectID
+	clone.AKSKAuthOptions.Region = region
+	return clone
 }
 
 func (c *Config) newServiceClientByName(client *golangsdk.ProviderClient, catalog ServiceCatalog, region string) (*golangsdk.ServiceClient, error) {
@@ -280,27 +512,14 @@ func (c *Config) newServiceClientByName(client *golangsdk.ProviderClient, catalo
 		return nil, fmt.Errorf("Resource-level region must be the same as Provider-level region when using non AK/SK authentication if Resource-level region set")
 	}
 
-	c.RPLock.Lock()
-	defer c.RPLock.Unlock()
-	projectID, ok := c.RegionProjectIDMap[region]
-	if !ok {
-		// Not find in the map, then try to query and store.
-		err := c.loadUserProjects(client, region)
-		if err != nil {
-			return nil, err
-		}
-		projectID = c.RegionProjectIDMap[region]
+	projectID, err := c.getRegionProjectID(client, region)
+	if err != nil {
+		return nil, err
 	}
 
 	// update ProjectID and region in ProviderClient
-	clone := new(golangsdk.ProviderClient)
-	*clone = *client
-	clone.ProjectID = projectID
-	clone.AKSKAuthOptions.ProjectId = projectID
-	clone.AKSKAuthOptions.Region = region
-
 	sc := &golangsdk.ServiceClient{
-		ProviderClient: clone,
+		ProviderClient: cloneProviderClient(client, projectID, region),
 	}
 
 	if catalog.Scope == "global" && !c.RegionClient {
@@ -324,16 +543,33 @@ func (c *Config) newServiceClientByName(client *golangsdk.ProviderClient, catalo
 }
 
 // newServiceClientByEndpoint returns a ServiceClient which the endpoint was initialized by customer
-// the format of customer endpoint likes https://{Name}.{Region}.xxxx.com
-func (c *Config) newServiceClientByEndpoint(client *golangsdk.ProviderClient, srv, endpoint string) (*golangsdk.ServiceClient, error) {
+// the format of customer endpoint likes https://{Name}.{Region}.xxxx.com, the {region} and {project_id} placeholders
+// in the endpoint are replaced with the Resource-level region and the project ID of it.
+func (c *Config) newServiceClientByEndpoint(client *golangsdk.ProviderClient, srv, endpoint,
+	region string) (*golangsdk.ServiceClient, error) {
 	catalog, ok := allServiceCatalog[srv]
 	if !ok {
 		return nil, fmt.Errorf("service type %s is invalid or not supportted", srv)
 	}
 
+	if region != "" && region != c.Region {
+		// Custom Resource-level region only supports AK/SK authentication.
+		if c.AccessKey == "" || c.SecretKey == "" {
+			return nil, fmt.Errorf("Resource-level region must be the same as Provider-level region when using non AK/SK authentication if Resource-level region set")
+		}
+
+		projectID, err := c.getRegionProjectID(client, region)
+		if err != nil {
+			return nil, err
+		}
+		client = cloneProviderClient(client, projectID, region)
+	} else {
+		region = c.Region
+	}
+
 	sc := &golangsdk.ServiceClient{
 		ProviderClient: client,
-		Endpoint:       endpoint,
+		Endpoint:       renderCustomEndpoint(endpoint, region, client.ProjectID),
 	}
 
 	sc.ResourceBase = sc.Endpoint
@@ -360,7 +596,7 @@ func (c *Config) getDomainID() (string, error) {
 	// the List request does not support query options
 	allPages, err := domains.List(identityClient, nil).AllPages()
 	if err != nil {
-		return "", fmt.Errorf("List domains failed, err=%s", err)
+		return "", fmt.Errorf("List domains failed, err=%w", err)
 	}
 
 	all, err := domains.ExtractDomains(allPages)
@@ -390,7 +626,7 @@ func (c *Config) getUserIDbyName(name string) (string, error) {
 	}
 	allPages, err := users.List(identityClient, opts).AllPages()
 	if err != nil {
-		return "", fmt.Errorf("query IAM user %s failed, err=%s", name, err)
+		return "", fmt.Errorf("query IAM user %s failed, err=%w", name, err)
 	}
 
 	all, err := users.ExtractUsers(allPages)
@@ -423,7 +659,7 @@ func (c *Config) loadUserProjects(client *golangsdk.ProviderClient, region strin
 	sc.ProviderClient = client
 	allPages, err := projects.List(sc, &opts).AllPages()
 	if err != nil {
-		return fmt.Errorf("List projects failed, err=%s", err)
+		return fmt.Errorf("List projects failed, err=%w", err)
 	}
 
 	all, err := projects.ExtractProjects(allPages)
@@ -444,17 +680,10 @@ func (c *Config) loadUserProjects(client *golangsdk.ProviderClient, region strin
 
 // GetProjectID is used to get the project ID for services
 func (c *Config) GetProjectID(region string) string {
-	c.RPLock.Lock()
-	defer c.RPLock.Unlock()
-
-	projectID, ok := c.RegionProjectIDMap[region]
-	if !ok {
-		// Not find in the map, then try to query and store.
-		if err := c.loadUserProjects(c.DomainClient, region); err != nil {
-			log.Printf("[WARN] can not find the project ID of %s: %s", region, err)
-			return ""
-		}
-		projectID = c.RegionProjectIDMap[region]
+	projectID, err := c.getRegionProjectID(c.DomainClient, region)
+	if err != nil {
+		log.Printf("[WARN] can not find the project ID of %s: %s", region, err)
+		return ""
 	}
 
 	return projectID
@@ -471,6 +700,11 @@ func (c *Config) GetRegion(d *schema.ResourceData) string {
 	return c.Region
 }
 
+// GetProviderTagsConfig returns the provider-level tags configuration.
+func (c *Config) GetProviderTagsConfig() *utils.ProviderTagsConfig {
+	return c.ProviderTags
+}
+
 // GetEnterpriseProjectID returns the enterprise_project_id that was specified in the resource.
 // If it was not set, the provider-level value is checked. The provider-level value can
 // either be set by the `enterprise_project_id` argument or by HW_ENTERPRISE_PROJECT_ID.
@@ -496,6 +730,13 @@ func (c *Config) DataGetEnterpriseProjectID(d *schema.ResourceData) string {
 	return "all_granted_eps"
 }
 
+// PreflightPolicy is a local policy evaluated before apply, the parameters are decoded from the JSON values which
+// are in the same format as the parameters of RMS policy assignments.
+type PreflightPolicy struct {
+	Name       string
+	Parameters map[string]interface{}
+}
+
 // ********** client for Global Service **********
 func (c *Config) IAMV3Client(region string) (*golangsdk.ServiceClient, error) {
 	return c.NewServiceClient("iam", region)
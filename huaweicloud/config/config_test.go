@@ -1,11 +1,19 @@
 package config
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/chnsz/golangsdk"
 	th "github.com/chnsz/golangsdk/testhelper"
@@ -79,4 +87,860 @@ func TestCheckObsEndpoint(t *testing.T) {
 	// the region is not equal to the region in customizing endpoint
 	expected = "https://oss.region-1.myhuaweicloud.com/"
 	th.AssertEquals(t, expected, getObsEndpoint(cfg, "region-1"))
+
+	// with the {region} placeholder in customizing endpoint
+	cfg.Endpoints["obs"] = "https://obs-{region}.private.example.com/"
+	expected = "https://obs-region-1.private.example.com/"
+	th.AssertEquals(t, expected, getObsEndpoint(cfg, "region-1"))
+
+	// with customizing OBS endpoint of the region
+	cfg.RegionEndpoints = map[string]map[string]string{
+		"region-2": {"obs": "https://storage.example.com/"},
+	}
+	expected = "https://storage.example.com/"
+	th.AssertEquals(t, expected, getObsEndpoint(cfg, "region-2"))
+}
+
+func TestCustomEndpointTemplate(t *testing.T) {
+	client := &golangsdk.ProviderClient{ProjectID: "project-0"}
+	cfg := &Config{
+		Region:    "region-0",
+		Cloud:     "myhuaweicloud.com",
+		AccessKey: "access-key",
+		SecretKey: "secret-key",
+		HwClient:  client,
+		RPLock:    new(sync.Mutex),
+		RegionProjectIDMap: map[string]string{
+			"region-0": "project-0",
+			"region-1": "project-1",
+		},
+		Endpoints: map[string]string{
+			"ecs": "https://ecs.{region}.private.example.com/",
+			"evs": "https://evs.private.example.com/",
+			"vpc": "https://gateway.example.com/{region}/{project_id}/vpc/",
+		},
+		RegionEndpoints: map[string]map[string]string{
+			"region-1": {"evs": "https://evs.region-1.example.com/"},
+		},
+	}
+
+	// the placeholders are replaced with the Resource-level region and the project ID of it
+	sc, err := cfg.NewServiceClient("ecs", "region-1")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://ecs.region-1.private.example.com/", sc.Endpoint)
+	th.AssertEquals(t, "https://ecs.region-1.private.example.com/v1/project-1/", sc.ResourceBase)
+	th.AssertEquals(t, "project-1", sc.ProviderClient.ProjectID)
+	th.AssertEquals(t, "project-0", client.ProjectID)
+
+	sc, err = cfg.NewServiceClient("vpc", "region-1")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://gateway.example.com/region-1/project-1/vpc/", sc.Endpoint)
+
+	// the endpoint of the region takes precedence over the static endpoint
+	sc, err = cfg.NewServiceClient("evs", "region-1")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://evs.region-1.example.com/", sc.Endpoint)
+
+	sc, err = cfg.NewServiceClient("evs", "region-0")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://evs.private.example.com/", sc.Endpoint)
+
+	// the static endpoint only belongs to the Provider-level region
+	_, err = cfg.NewServiceClient("evs", "region-2")
+	th.AssertEquals(t, true, err != nil)
+
+	th.AssertEquals(t, "https://ecs.region-1.private.example.com/", GetServiceEndpoint(cfg, "ecs", "region-1"))
+}
+
+func TestRateLimitersServiceName(t *testing.T) {
+	limiters := NewRateLimiters(map[string]int{
+		"ecsv21": 20,
+		"vpc":    50,
+	}, map[string]string{
+		"vpc": "https://vpc-customizing-endpoint.com/",
+	})
+
+	th.AssertEquals(t, 20, limiters.limits["ecs"])
+	th.AssertEquals(t, 50, limiters.limits["vpc"])
+	th.AssertEquals(t, "ecs", limiters.serviceName("ecs.region-0.myhuaweicloud.com"))
+	th.AssertEquals(t, "vpc", limiters.serviceName("vpc-customizing-endpoint.com"))
+}
+
+func TestRateLimitersWait(t *testing.T) {
+	limiters := NewRateLimiters(map[string]int{"ecs": 10}, nil)
+	request, _ := http.NewRequest("GET", "https://ecs.region-0.myhuaweicloud.com/v1/servers", nil)
+
+	start := time.Now()
+	// the first 10 requests are allowed by the burst, then the next 5 requests need to wait 0.5 seconds
+	for i := 0; i < 15; i++ {
+		th.AssertNoErr(t, limiters.Wait(request))
+	}
+	elapsed := time.Since(start)
+	if elapsed < 400*time.Millisecond || elapsed > 2*time.Second {
+		t.Fatalf("unexpected duration of the rate limiter: %s", elapsed)
+	}
+
+	// the requests of other services are not limited
+	otherRequest, _ := http.NewRequest("GET", "https://vpc.region-0.myhuaweicloud.com/v1/vpcs", nil)
+	start = time.Now()
+	for i := 0; i < 100; i++ {
+		th.AssertNoErr(t, limiters.Wait(otherRequest))
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("the requests without rate limit are blocked for %s", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("3")
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, 3*time.Second, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, maxRetryAfter, delay)
+
+	_, ok = parseRetryAfter("invalid")
+	th.AssertEquals(t, false, ok)
+}
+
+func TestRequestRetryServerError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var retries int
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		th.AssertNoErr(t, err)
+		// the body should be replayed when retrying
+		th.AssertEquals(t, `{"name":"test"}`, string(body))
+
+		if retries < 2 {
+			retries++
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := http.Client{
+		Transport: &LogRoundTripper{
+			Rt:          http.DefaultTransport,
+			MaxRetries:  2,
+			RetryPolicy: NewRetryPolicy([]string{"put"}, nil),
+		},
+	}
+
+	request, err := http.NewRequest("PUT", th.Endpoint()+"route", strings.NewReader(`{"name":"test"}`))
+	th.AssertNoErr(t, err)
+	resp, err := client.Do(request)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusOK, resp.StatusCode)
+	th.AssertEquals(t, 2, retries)
+
+	// the POST method is not retried
+	retries = 0
+	request, err = http.NewRequest("POST", th.Endpoint()+"route", strings.NewReader(`{"name":"test"}`))
+	th.AssertNoErr(t, err)
+	resp, err = client.Do(request)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusServiceUnavailable, resp.StatusCode)
+	th.AssertEquals(t, 1, retries)
+}
+
+func TestRetryDelay(t *testing.T) {
+	// the Retry-After header of the server error response is preferred
+	response := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{"3"}},
+	}
+	th.AssertEquals(t, 3*time.Second, retryDelay(response, 1))
+
+	response.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	th.AssertEquals(t, maxRetryAfter, retryDelay(response, 1))
+
+	// the jittered backoff is used without the Retry-After header, or for the connection errors
+	for _, resp := range []*http.Response{{StatusCode: http.StatusBadGateway, Header: http.Header{}}, nil} {
+		if delay := retryDelay(resp, 1); delay < 0 || delay > retryTimeout(1) {
+			t.Fatalf("the delay %s is not in the range of the jittered backoff %s", delay, retryTimeout(1))
+		}
+	}
+}
+
+func TestRequestRetryServerErrorRetryAfter(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var retries int
+	var retriedAt []time.Time
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		retriedAt = append(retriedAt, time.Now())
+		if retries < 1 {
+			retries++
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	client := http.Client{
+		Transport: &LogRoundTripper{
+			Rt:          http.DefaultTransport,
+			MaxRetries:  1,
+			RetryPolicy: NewRetryPolicy(nil, nil),
+		},
+	}
+
+	resp, err := client.Get(th.Endpoint() + "route")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, http.StatusOK, resp.StatusCode)
+	th.AssertEquals(t, 2, len(retriedAt))
+	if elapsed := retriedAt[1].Sub(retriedAt[0]); elapsed < time.Second {
+		t.Fatalf("the request is retried after %s, before the duration of the Retry-After header", elapsed)
+	}
+}
+
+func TestRequestRetryCanceled(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client := http.Client{
+		Transport: &LogRoundTripper{
+			Rt:          http.DefaultTransport,
+			MaxRetries:  10,
+			RetryPolicy: NewRetryPolicy(nil, nil),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, "GET", th.Endpoint()+"route", nil)
+	th.AssertNoErr(t, err)
+
+	start := time.Now()
+	_, err = client.Do(request)
+	if err == nil {
+		t.Fatalf("the request should be canceled")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("the retries are not canceled in time: %s", elapsed)
+	}
+}
+
+func TestRedactSensitiveFields(t *testing.T) {
+	redactor := NewRedactor([]string{"db_user"})
+
+	rawJSON := `{"instance":{"name":"test","admin_pass":"Test@123","db_user":"root","access_mode":"rw",` +
+		`"db_user_name":"admin","users":[{"name":"user1","password":"Test@123"}]},` +
+		`"credential":{"access":"ak","secret":"sk"}}`
+	formatted := redactor.formatJSON([]byte(rawJSON))
+	for _, secret := range []string{"Test@123", "root", `"ak"`, `"sk"`} {
+		if strings.Contains(formatted, secret) {
+			t.Fatalf("the sensitive value %s is not redacted: %s", secret, formatted)
+		}
+	}
+	// the fields are matched with the whole key
+	for _, value := range []string{"user1", `"rw"`, `"admin"`} {
+		th.AssertEquals(t, true, strings.Contains(formatted, value))
+	}
+
+	// the custom fields are only redacted by the redactor which is configured with them
+	th.AssertEquals(t, true, strings.Contains(NewRedactor(nil).formatJSON([]byte(rawJSON)), "root"))
+
+	rawXML := `<CreateBucketConfiguration><Location>region-0</Location><SecretKey>sk</SecretKey>` +
+		`<ns:Password attr="1">Test@123</ns:Password></CreateBucketConfiguration>`
+	expected := `<CreateBucketConfiguration><Location>region-0</Location><SecretKey>***</SecretKey>` +
+		`<ns:Password attr="1">***</ns:Password></CreateBucketConfiguration>`
+	th.AssertEquals(t, expected, redactor.redactXML(rawXML))
+
+	headers := http.Header{}
+	headers.Set("X-Auth-Token", "token")
+	headers.Set("X-Security-Token", "security-token")
+	headers.Set("Db_user", "root")
+	headers.Set("Content-Type", "application/json")
+	th.AssertEquals(t, "Content-Type: application/json\nDb_user: ***\nX-Auth-Token: ***\nX-Security-Token: ***",
+		redactor.FormatHeaders(headers, "\n"))
+
+	rawURL := "https://bucket.obs.region-0.example.com/object?AccessKeyId=ak&Expires=1700000000&" +
+		"Signature=sig%2B&x-obs-security-token=token&db_user=root&limit=10"
+	u, err := url.Parse(rawURL)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://bucket.obs.region-0.example.com/object?AccessKeyId=***&Expires=1700000000&"+
+		"Signature=***&x-obs-security-token=***&db_user=***&limit=10", redactor.RedactURL(u))
+}
+
+func TestTraceRecord(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "request-0")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tracePath := filepath.Join(t.TempDir(), "trace.log")
+	tracer, err := NewTracer(tracePath)
+	th.AssertNoErr(t, err)
+
+	client := http.Client{
+		Transport: &LogRoundTripper{
+			Rt:     http.DefaultTransport,
+			Tracer: tracer,
+		},
+	}
+	resp, err := client.Get(th.Endpoint() + "v1/servers/5a3a4b0e-6f5b-4b5a-9a3e-3e0b4c5d6e7f?limit=10")
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+
+	data, err := os.ReadFile(tracePath)
+	th.AssertNoErr(t, err)
+
+	var record TraceRecord
+	th.AssertNoErr(t, json.Unmarshal(data, &record))
+	th.AssertEquals(t, "GET", record.Method)
+	th.AssertEquals(t, http.StatusOK, record.StatusCode)
+	th.AssertEquals(t, "request-0", record.RequestID)
+	th.AssertEquals(t, 0, record.Retries)
+	th.AssertEquals(t, true, strings.HasSuffix(record.URL, "/v1/servers/{id}"))
+}
+
+func TestTraceRecordHcClient(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var count int
+	th.Mux.HandleFunc("/v3/vpcs/", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tracePath := filepath.Join(t.TempDir(), "trace.log")
+	tracer, err := NewTracer(tracePath)
+	th.AssertNoErr(t, err)
+
+	conf := &Config{
+		MaxRetries:  2,
+		RetryPolicy: NewRetryPolicy(nil, nil),
+		Tracer:      tracer,
+	}
+	client := http.Client{Transport: buildHTTPConfig(conf).HttpTransport}
+	resp, err := client.Get(th.Endpoint() + "v3/vpcs/5a3a4b0e-6f5b-4b5a-9a3e-3e0b4c5d6e7f")
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+
+	data, err := os.ReadFile(tracePath)
+	th.AssertNoErr(t, err)
+
+	var record TraceRecord
+	th.AssertNoErr(t, json.Unmarshal(data, &record))
+	th.AssertEquals(t, http.StatusOK, record.StatusCode)
+	th.AssertEquals(t, 1, record.Retries)
+
+	// the records are dropped after the trace file is closed
+	CloseTracers()
+	tracer.Record(&TraceRecord{Method: "GET"})
+	closed, err := os.ReadFile(tracePath)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, string(data), string(closed))
+	th.AssertNoErr(t, tracer.Close())
+}
+
+func TestCassetteRecordReplay(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var status = "CREATING"
+	th.Mux.HandleFunc("/v1/volumes/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", "token-0")
+		fmt.Fprintf(w, `{"volume": {"id": "volume-0", "status": "%s", "admin_pass": "secret"}}`, status)
+	})
+
+	get := func(client *http.Client, query string) (string, http.Header, error) {
+		resp, err := client.Get(th.Endpoint() + "v1/volumes/volume-0" + query)
+		if err != nil {
+			return "", nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), resp.Header, err
+	}
+
+	cassettePath := filepath.Join(t.TempDir(), "cassettes", "TestCassette.json")
+	recorder, err := NewCassette(cassettePath, CassetteModeRecord)
+	th.AssertNoErr(t, err)
+	client := &http.Client{Transport: recorder.RoundTripper(http.DefaultTransport, nil)}
+	_, _, err = get(client, "?limit=1")
+	th.AssertNoErr(t, err)
+	status = "AVAILABLE"
+	_, _, err = get(client, "?limit=1")
+	th.AssertNoErr(t, err)
+	th.AssertNoErr(t, recorder.Save())
+
+	// the server is not required in replay mode
+	status = "DELETED"
+	player, err := NewCassette(cassettePath, CassetteModeReplay)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, recorder.Seed, player.Seed)
+
+	client = &http.Client{Transport: player.HTTPTransport(http.DefaultTransport, nil)}
+	body, headers, err := get(client, "?limit=1")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, `{"volume":{"admin_pass":"***","id":"volume-0","status":"CREATING"}}`, body)
+	th.AssertEquals(t, "***", headers.Get("X-Subject-Token"))
+
+	// the query is ignored if no interaction matches it
+	body, _, err = get(client, "?limit=2")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, strings.Contains(body, "AVAILABLE"))
+
+	// the last matched interaction is reused
+	body, _, err = get(client, "?limit=1")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, strings.Contains(body, "AVAILABLE"))
+
+	_, err = client.Get(th.Endpoint() + "v1/servers")
+	th.AssertEquals(t, true, err != nil)
+}
+
+func TestCassetteHostAliases(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/volumes", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"volumes": []}`)
+	})
+
+	endpoint, err := url.Parse(th.Endpoint())
+	th.AssertNoErr(t, err)
+	cassettePath := filepath.Join(t.TempDir(), "TestCassette.json")
+	recorder, err := NewCassette(cassettePath, CassetteModeRecord)
+	th.AssertNoErr(t, err)
+	recorder.HostAliases = map[string]string{endpoint.Host: "evs.fake.test"}
+
+	client := &http.Client{Transport: recorder.RoundTripper(http.DefaultTransport, nil)}
+	resp, err := client.Get(th.Endpoint() + "v1/volumes")
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+	th.AssertNoErr(t, recorder.Save())
+
+	// the interactions are replayed by the alias host
+	player, err := NewCassette(cassettePath, CassetteModeReplay)
+	th.AssertNoErr(t, err)
+	client = &http.Client{Transport: player.RoundTripper(http.DefaultTransport, nil)}
+	resp, err = client.Get("http://evs.fake.test/v1/volumes")
+	th.AssertNoErr(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, `{"volumes":[]}`, string(body))
+}
+
+func TestAssumeRolesChain(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3/auth/domains", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.Header.Get("Authorization"), "ak-2") {
+			_, _ = fmt.Fprint(w, `{"domains": [{"id": "workload-domain-id", "name": "workload", "enabled": true}]}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"domains": [{"id": "hub-domain-id", "name": "hub", "enabled": true}]}`)
+	})
+
+	var requests []map[string]interface{}
+	th.Mux.HandleFunc("/v3.0/OS-CREDENTIAL/securitytokens", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, body)
+
+		// the next agency must be assumed by the temporary credentials of the previous one
+		hop := len(requests)
+		if hop > 1 {
+			th.AssertEquals(t, true, strings.Contains(r.Header.Get("Authorization"), fmt.Sprintf("ak-%d", hop-1)))
+		}
+
+		expiresAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"credential": {"access": "ak-%[1]d", "secret": "sk-%[1]d", "securitytoken": "token-%[1]d",
+"expires_at": "%[2]s"}}`, hop, expiresAt)
+	})
+
+	cfg := &Config{
+		AccessKey:        "source-ak",
+		SecretKey:        "source-sk",
+		DomainID:         "source-domain-id",
+		Region:           "cn-north-4",
+		IdentityEndpoint: th.Endpoint(),
+		Endpoints:        map[string]string{"iam": th.Endpoint()},
+		AssumeRoles: []AssumeRole{
+			{AgencyName: "hub", DomainName: "hub"},
+			{AgencyName: "workload", DomainName: "workload", Duration: 3600, SessionUserName: "terraform"},
+		},
+	}
+	th.AssertNoErr(t, assumeRoles(cfg))
+
+	th.AssertEquals(t, 2, len(requests))
+	th.AssertEquals(t, "ak-2", cfg.AccessKey)
+	th.AssertEquals(t, "sk-2", cfg.SecretKey)
+	th.AssertEquals(t, "token-2", cfg.SecurityToken)
+	// the domain of the last agency is used by the temporary credentials
+	th.AssertEquals(t, "workload-domain-id", cfg.DomainID)
+	th.AssertEquals(t, "workload", cfg.DomainName)
+	th.AssertEquals(t, false, cfg.SecurityKeyExpiresAt.IsZero())
+
+	lastRole := requests[1]["auth"].(map[string]interface{})["identity"].(map[string]interface{})["assume_role"]
+	th.AssertDeepEquals(t, map[string]interface{}{
+		"agency_name":      "workload",
+		"domain_name":      "workload",
+		"duration_seconds": float64(3600),
+		"session_user":     map[string]interface{}{"name": "terraform"},
+	}, lastRole)
+}
+
+func TestCredentialProviderChain(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	sharedConfig := `{"current": "default", "profiles": [
+{"name": "default", "mode": "AKSK", "accessKeyId": "", "secretAccessKey": ""},
+{"name": "temporary", "mode": "AKSK", "accessKeyId": "profile-ak", "secretAccessKey": "profile-sk",
+"securityToken": "profile-token"}]}`
+	th.AssertNoErr(t, os.WriteFile(configFile, []byte(sharedConfig), 0600))
+
+	chain := ChainCredentialProvider{
+		Providers: []CredentialProvider{
+			&StaticCredentialProvider{},
+			&SharedConfigCredentialProvider{FilePath: configFile},
+			&SharedConfigCredentialProvider{FilePath: configFile, Profile: "temporary"},
+		},
+	}
+	credentials, err := chain.Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "profile-ak", credentials.AccessKey)
+	th.AssertEquals(t, "profile-sk", credentials.SecretKey)
+	th.AssertEquals(t, "profile-token", credentials.SecurityToken)
+	// the temporary credentials of shared config file are reloaded periodically
+	th.AssertEquals(t, false, credentials.ExpiresAt.IsZero())
+
+	// the credentials are reloaded from the same provider
+	sharedConfig = strings.ReplaceAll(sharedConfig, "profile-ak", "rotated-ak")
+	th.AssertNoErr(t, os.WriteFile(configFile, []byte(sharedConfig), 0600))
+	credentials, err = chain.Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "rotated-ak", credentials.AccessKey)
+
+	chain = ChainCredentialProvider{
+		Providers: []CredentialProvider{&StaticCredentialProvider{}},
+	}
+	_, err = chain.Retrieve()
+	th.AssertEquals(t, true, err != nil)
+}
+
+func TestCredentialsReauth(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var requests int
+	th.Mux.HandleFunc("/v1/project-id/cloudservers/jobs/job-id", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// the expired credentials are rejected by the API
+		if !strings.Contains(r.Header.Get("Authorization"), "Access=rotated-ak") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		th.AssertEquals(t, "rotated-token", r.Header.Get("X-Security-Token"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"status": "SUCCESS"}`)
+	})
+
+	provider := &StaticCredentialProvider{AccessKey: "expiring-ak", SecretKey: "expiring-sk",
+		SecurityToken: "expiring-token"}
+	cfg := &Config{
+		AccessKey:            "expiring-ak",
+		SecretKey:            "expiring-sk",
+		SecurityToken:        "expiring-token",
+		SecurityKeyExpiresAt: time.Now().Add(time.Hour),
+		SecurityKeyLock:      new(sync.Mutex),
+		CredentialProvider:   provider,
+		DomainID:             "domain-id",
+		TenantID:             "project-id",
+		Region:               "cn-north-4",
+		IdentityEndpoint:     th.Endpoint() + "v3/",
+		Endpoints:            map[string]string{"ecs": th.Endpoint()},
+		RPLock:               new(sync.Mutex),
+		RegionProjectIDMap:   map[string]string{"cn-north-4": "project-id"},
+	}
+	th.AssertNoErr(t, buildClientByAKSK(cfg))
+
+	// the client is created before the credentials expire and is used after that
+	client, err := cfg.NewServiceClient("ecs", "cn-north-4")
+	th.AssertNoErr(t, err)
+	provider.AccessKey, provider.SecretKey, provider.SecurityToken = "rotated-ak", "rotated-sk", "rotated-token"
+
+	var job map[string]interface{}
+	_, err = client.Get(client.ServiceURL("cloudservers", "jobs", "job-id"), &job, nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "SUCCESS", job["status"])
+	th.AssertEquals(t, 2, requests)
+	th.AssertEquals(t, "rotated-ak", cfg.AccessKey)
+
+	// the other clients use the reloaded credentials without reloading them again
+	client, err = cfg.NewServiceClient("ecs", "cn-north-4")
+	th.AssertNoErr(t, err)
+	_, err = client.Get(client.ServiceURL("cloudservers", "jobs", "job-id"), &job, nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, requests)
+}
+
+func TestProcessCredentialProvider(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("the shell is not available")
+	}
+
+	provider := ProcessCredentialProvider{
+		Command: `echo '{"access": "process-ak", "secret": "process-sk", "securitytoken": "process-token",
+"expires_at": "2030-01-01T00:00:00Z"}'`,
+	}
+	credentials, err := provider.Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "process-ak", credentials.AccessKey)
+	th.AssertEquals(t, "process-sk", credentials.SecretKey)
+	th.AssertEquals(t, "process-token", credentials.SecurityToken)
+	th.AssertEquals(t, "2030-01-01T00:00:00Z", credentials.ExpiresAt.Format(time.RFC3339))
+
+	provider.Command = "echo 'not a json'"
+	_, err = provider.Retrieve()
+	th.AssertEquals(t, true, err != nil)
+
+	provider.Command = "exit 1"
+	_, err = provider.Retrieve()
+	th.AssertEquals(t, true, err != nil)
+}
+
+func TestSSOCredentialProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/federation/credentials", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Security-Token", "sso-access-token")
+		th.TestFormValues(t, r, map[string]string{
+			"account_id": "account-id",
+			"agency_urn": "iam::account-id:agency:admin",
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"role_credentials": {"access_key_id": "sso-ak", "secret_access_key": "sso-sk",
+"session_token": "sso-token", "expiration": %d}}`, time.Now().Add(time.Hour).UnixMilli())
+	})
+
+	cacheFile := filepath.Join(t.TempDir(), "token.json")
+	provider := SSOCredentialProvider{
+		StartUrl:  "https://example.com/start",
+		Region:    "cn-north-4",
+		AccountId: "account-id",
+		AgencyUrn: "iam::account-id:agency:admin",
+		PortalUrl: th.Endpoint(),
+		CacheFile: cacheFile,
+	}
+
+	// the cached token is not found
+	_, err := provider.Retrieve()
+	th.AssertEquals(t, true, err != nil)
+
+	expiredToken := fmt.Sprintf(`{"accessToken": "sso-access-token", "expiresAt": "%s"}`,
+		time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	th.AssertNoErr(t, os.WriteFile(cacheFile, []byte(expiredToken), 0600))
+	_, err = provider.Retrieve()
+	th.AssertEquals(t, true, err != nil)
+
+	validToken := fmt.Sprintf(`{"accessToken": "sso-access-token", "expiresAt": "%s"}`,
+		time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	th.AssertNoErr(t, os.WriteFile(cacheFile, []byte(validToken), 0600))
+	credentials, err := provider.Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "sso-ak", credentials.AccessKey)
+	th.AssertEquals(t, "sso-sk", credentials.SecretKey)
+	th.AssertEquals(t, "sso-token", credentials.SecurityToken)
+	th.AssertEquals(t, false, credentials.ExpiresAt.IsZero())
+}
+
+func TestSharedConfigCredentialProcessMode(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("the shell is not available")
+	}
+
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	sharedConfig := `{"current": "process", "profiles": [{"name": "process", "mode": "credentialProcess",
+"credentialProcess": "echo '{\"access\": \"process-ak\", \"secret\": \"process-sk\"}'"},
+{"name": "unknown", "mode": "unknown"}]}`
+	th.AssertNoErr(t, os.WriteFile(configFile, []byte(sharedConfig), 0600))
+
+	provider := SharedConfigCredentialProvider{FilePath: configFile}
+	credentials, err := provider.Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "process-ak", credentials.AccessKey)
+	th.AssertEquals(t, "process-sk", credentials.SecretKey)
+
+	provider.Profile = "unknown"
+	_, err = provider.Retrieve()
+	th.AssertEquals(t, true, err != nil)
+}
+
+func TestWebIdentityCredentialProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3.0/OS-AUTH/id-token/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Idp-Id", "github_oidc")
+		th.TestJSONRequest(t, r, `{"auth": {"id_token": {"id": "oidc-jwt"}, "scope": {"domain": {"name": "my-domain"}}}}`)
+
+		w.Header().Set("X-Subject-Token", "federated-token")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"token": {"expires_at": "2030-01-01T00:00:00.000000Z"}}`)
+	})
+	th.Mux.HandleFunc("/v3.0/OS-CREDENTIAL/securitytokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", "federated-token")
+		th.TestJSONRequest(t, r, `{"auth": {"identity": {"methods": ["token"],
+"token": {"id": "federated-token", "duration_seconds": 900}}}}`)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"credential": {"access": "oidc-ak", "secret": "oidc-sk", "securitytoken": "oidc-token",
+"expires_at": "2030-01-01T00:00:00.000000Z"}}`)
+	})
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	th.AssertNoErr(t, os.WriteFile(tokenFile, []byte("oidc-jwt\n"), 0600))
+
+	provider := WebIdentityCredentialProvider{
+		WebIdentity: WebIdentity{
+			IdpId:     "github_oidc",
+			TokenFile: tokenFile,
+			Duration:  900,
+		},
+		config: &Config{
+			DomainName: "my-domain",
+			Region:     "cn-north-4",
+			Endpoints:  map[string]string{"iam": th.Endpoint()},
+		},
+	}
+	credentials, err := provider.Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "oidc-ak", credentials.AccessKey)
+	th.AssertEquals(t, "oidc-sk", credentials.SecretKey)
+	th.AssertEquals(t, "oidc-token", credentials.SecurityToken)
+	th.AssertEquals(t, 2030, credentials.ExpiresAt.Year())
+
+	// the chain stops at the failed exchange instead of falling through to the shared config file
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	sharedConfig := `{"current": "default", "profiles": [
+{"name": "default", "mode": "AKSK", "accessKeyId": "profile-ak", "secretAccessKey": "profile-sk"}]}`
+	th.AssertNoErr(t, os.WriteFile(configFile, []byte(sharedConfig), 0600))
+
+	provider.TokenFile = filepath.Join(t.TempDir(), "missing")
+	chain := ChainCredentialProvider{
+		Providers: []CredentialProvider{
+			&provider,
+			&SharedConfigCredentialProvider{FilePath: configFile},
+		},
+	}
+	_, err = chain.Retrieve()
+	th.AssertEquals(t, true, err != nil)
+	th.AssertEquals(t, true, strings.Contains(err.Error(), "web identity"))
+}
+
+func TestDiscoveryCache(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "cache", "discovery.json")
+	cfg := &Config{
+		AccessKey:          "my-ak",
+		SecretKey:          "my-sk",
+		Region:             "cn-north-4",
+		TenantName:         "cn-north-4",
+		IdentityEndpoint:   "https://iam.cn-north-4.myhuaweicloud.com/v3",
+		DiscoveryCacheFile: cacheFile,
+		DiscoveryCacheTTL:  time.Hour,
+		DomainID:           "domain-id",
+		RPLock:             new(sync.Mutex),
+		RegionProjectIDMap: map[string]string{
+			"cn-north-4": "project-id",
+		},
+	}
+
+	cache, err := newDiscoveryCache(cfg)
+	th.AssertNoErr(t, err)
+	cfg.DiscoveryCache = cache
+	_, ok := cache.Load()
+	th.AssertEquals(t, false, ok)
+
+	cfg.saveDiscoveryCache()
+	data, err := os.ReadFile(cacheFile)
+	th.AssertNoErr(t, err)
+	// the credentials are never persisted
+	th.AssertEquals(t, false, strings.Contains(string(data), "my-ak"))
+
+	entry, ok := cache.Load()
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "domain-id", entry.DomainID)
+	th.AssertEquals(t, "project-id", entry.Projects["cn-north-4"])
+
+	// the entry which conflicts with the authenticated identity is stale
+	th.AssertEquals(t, false, entry.isStale(cfg))
+	cfg.HwClient = &golangsdk.ProviderClient{ProjectID: "other-project-id"}
+	th.AssertEquals(t, true, entry.isStale(cfg))
+	cfg.HwClient = nil
+	cfg.DomainID = "other-domain-id"
+	th.AssertEquals(t, true, entry.isStale(cfg))
+
+	// the cached project ID is used in authentication
+	cfg.DomainID = ""
+	th.AssertEquals(t, true, cfg.applyDiscoveryCache(entry))
+	th.AssertEquals(t, "project-id", cfg.TenantID)
+
+	// the entries of other identities are isolated
+	otherCfg := *cfg
+	otherCfg.AccessKey = "other-ak"
+	otherCache, err := newDiscoveryCache(&otherCfg)
+	th.AssertNoErr(t, err)
+	_, ok = otherCache.Load()
+	th.AssertEquals(t, false, ok)
+
+	cache.Invalidate()
+	_, ok = cache.Load()
+	th.AssertEquals(t, false, ok)
+
+	// the entry is kept when the discovery fails for other reasons than the rejected identity
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	statusCode := http.StatusInternalServerError
+	th.Mux.HandleFunc("/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+	})
+
+	cfg.saveDiscoveryCache()
+	cfg.IdentityEndpoint = strings.TrimSuffix(th.Endpoint(), "/")
+	_, err = cfg.getRegionProjectID(&golangsdk.ProviderClient{}, "cn-south-1")
+	th.AssertEquals(t, true, err != nil)
+	_, ok = cache.Load()
+	th.AssertEquals(t, true, ok)
+
+	// the whole entry is invalidated when the identity is rejected
+	for _, statusCode = range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		cfg.saveDiscoveryCache()
+		_, err = cfg.getRegionProjectID(&golangsdk.ProviderClient{}, "cn-south-1")
+		th.AssertEquals(t, true, err != nil)
+		_, ok = cache.Load()
+		th.AssertEquals(t, false, ok)
+	}
 }
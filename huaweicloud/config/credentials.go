@@ -0,0 +1,301 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// the timeout of executing the external credential process
+	credentialProcessTimeout = 1 * time.Minute
+	// the temporary credentials in shared config file have no expiration time, so they are reloaded periodically
+	sharedConfigRefreshInterval = 15 * time.Minute
+)
+
+// Credentials is the AK/SK credentials used to sign the requests, the temporary credentials have a security token
+// and an expiration time.
+type Credentials struct {
+	AccessKey     string
+	SecretKey     string
+	SecurityToken string
+	// ExpiresAt is the expiration time of the temporary credentials, zero means the credentials never expire
+	ExpiresAt time.Time
+}
+
+// CredentialProvider is used to retrieve the credentials, the credentials are retrieved again by the provider when
+// they are nearing expiry. Both the golangsdk ProviderClient and the huaweicloud-sdk-go-v3 clients are rebuilt with
+// the reloaded credentials.
+type CredentialProvider interface {
+	// Retrieve returns the credentials, or an error if the credentials are not available in the provider
+	Retrieve() (*Credentials, error)
+	// String returns the name of provider which is used in the logs
+	String() string
+}
+
+// newDefaultCredentialProvider builds the default provider chain in the following order:
+// static credentials (including the environment variables) -> web identity -> shared config file -> ECS metadata API
+// -> external credential process.
+func newDefaultCredentialProvider(c *Config) CredentialProvider {
+	providers := make([]CredentialProvider, 0, 5)
+	if c.AccessKey != "" && c.SecretKey != "" {
+		providers = append(providers, &StaticCredentialProvider{
+			AccessKey:     c.AccessKey,
+			SecretKey:     c.SecretKey,
+			SecurityToken: c.SecurityToken,
+		})
+	}
+	if c.WebIdentity != nil {
+		providers = append(providers, &WebIdentityCredentialProvider{
+			WebIdentity: *c.WebIdentity,
+			config:      c,
+		})
+	}
+	if c.SharedConfigFile != "" {
+		providers = append(providers, &SharedConfigCredentialProvider{
+			FilePath: c.SharedConfigFile,
+			Profile:  c.Profile,
+		})
+	}
+	providers = append(providers, &MetadataCredentialProvider{})
+	if c.CredentialProcess != "" {
+		providers = append(providers, &ProcessCredentialProvider{
+			Command: c.CredentialProcess,
+		})
+	}
+
+	return &ChainCredentialProvider{Providers: providers}
+}
+
+// configuredCredentialError is returned by the providers which are explicitly configured, e.g. the web identity, the
+// chain stops at such errors instead of falling through to the next provider.
+type configuredCredentialError struct {
+	err error
+}
+
+func (e *configuredCredentialError) Error() string {
+	return e.err.Error()
+}
+
+func (e *configuredCredentialError) Unwrap() error {
+	return e.err
+}
+
+// ChainCredentialProvider retrieves the credentials from the providers in order, the first available provider is
+// remembered and used to reload the credentials.
+type ChainCredentialProvider struct {
+	Providers []CredentialProvider
+
+	mu      sync.Mutex
+	current CredentialProvider
+}
+
+// Retrieve returns the credentials of the first available provider.
+func (p *ChainCredentialProvider) Retrieve() (*Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current != nil {
+		return p.current.Retrieve()
+	}
+
+	errs := make([]string, 0, len(p.Providers))
+	for _, provider := range p.Providers {
+		credentials, err := provider.Retrieve()
+		var configuredErr *configuredCredentialError
+		if errors.As(err, &configuredErr) {
+			return nil, fmt.Errorf("failed to retrieve the credentials from %s: %s", provider, err)
+		}
+		if err != nil {
+			log.Printf("[DEBUG] failed to retrieve the credentials from %s: %s", provider, err)
+			errs = append(errs, fmt.Sprintf("%s: %s", provider, err))
+			continue
+		}
+
+		log.Printf("[DEBUG] the credentials are retrieved from %s", provider)
+		p.current = provider
+		return credentials, nil
+	}
+	return nil, fmt.Errorf("no valid credentials found in the provider chain: %s", strings.Join(errs, "; "))
+}
+
+func (p *ChainCredentialProvider) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current != nil {
+		return p.current.String()
+	}
+	return "credential provider chain"
+}
+
+// StaticCredentialProvider returns the credentials specified in the provider block or environment variables.
+type StaticCredentialProvider struct {
+	AccessKey     string
+	SecretKey     string
+	SecurityToken string
+}
+
+// Retrieve returns the static credentials.
+func (p *StaticCredentialProvider) Retrieve() (*Credentials, error) {
+	if p.AccessKey == "" || p.SecretKey == "" {
+		return nil, fmt.Errorf("access_key or secret_key is missing")
+	}
+
+	credentials := Credentials{
+		AccessKey:     p.AccessKey,
+		SecretKey:     p.SecretKey,
+		SecurityToken: p.SecurityToken,
+	}
+	return &credentials, nil
+}
+
+func (*StaticCredentialProvider) String() string {
+	return "static credentials"
+}
+
+// SharedConfigCredentialProvider retrieves the credentials from the profile of shared config file, the file is read
+// again on each retrieval so that the credentials rotated by other tools can be picked up.
+// The following modes of profile are supported:
+//   - AKSK: the static or temporary credentials in the profile
+//   - credentialProcess: the credentials are printed by the external command of the profile
+//   - SSO: the credentials are exchanged by the cached token of IAM Identity Center
+type SharedConfigCredentialProvider struct {
+	FilePath string
+	Profile  string
+}
+
+// Retrieve returns the credentials of the profile.
+func (p *SharedConfigCredentialProvider) Retrieve() (*Credentials, error) {
+	profile, err := loadSharedProfile(p.FilePath, p.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	switch profile.Mode {
+	case profileModeCredentialProcess:
+		if profile.CredentialProcess == "" {
+			return nil, fmt.Errorf("credentialProcess is missing in profile %s", profile.Name)
+		}
+		return (&ProcessCredentialProvider{Command: profile.CredentialProcess}).Retrieve()
+	case profileModeSSO:
+		ssoProvider := SSOCredentialProvider{
+			StartUrl:  profile.SsoStartUrl,
+			Region:    profile.SsoRegion,
+			AccountId: profile.SsoAccountId,
+			AgencyUrn: profile.SsoAgencyUrn,
+			PortalUrl: profile.SsoPortalUrl,
+		}
+		return ssoProvider.Retrieve()
+	case "", profileModeAKSK:
+		return retrieveProfileAKSK(profile)
+	default:
+		return nil, fmt.Errorf("the mode %s of profile %s is not supported", profile.Mode, profile.Name)
+	}
+}
+
+func retrieveProfileAKSK(profile *Profile) (*Credentials, error) {
+	if profile.AccessKeyId == "" || profile.SecretAccessKey == "" {
+		return nil, fmt.Errorf("accessKeyId or secretAccessKey is missing in profile %s", profile.Name)
+	}
+
+	credentials := Credentials{
+		AccessKey:     profile.AccessKeyId,
+		SecretKey:     profile.SecretAccessKey,
+		SecurityToken: profile.SecurityToken,
+	}
+	if profile.SecurityToken != "" {
+		credentials.ExpiresAt = time.Now().Add(sharedConfigRefreshInterval)
+	}
+	return &credentials, nil
+}
+
+func (p *SharedConfigCredentialProvider) String() string {
+	return fmt.Sprintf("shared config file %s", p.FilePath)
+}
+
+// MetadataCredentialProvider retrieves the temporary credentials of the ECS agency from the metadata API.
+type MetadataCredentialProvider struct{}
+
+// Retrieve returns the temporary credentials from the metadata API.
+func (*MetadataCredentialProvider) Retrieve() (*Credentials, error) {
+	return getCredentialsByMeta()
+}
+
+func (*MetadataCredentialProvider) String() string {
+	return "ECS metadata API"
+}
+
+// ProcessCredentialProvider retrieves the credentials by executing an external command, the command must print the
+// credentials in JSON format to stdout, e.g.
+//
+//	{"access": "xxx", "secret": "xxx", "securitytoken": "xxx", "expires_at": "2023-01-01T00:00:00.000000Z"}
+type ProcessCredentialProvider struct {
+	Command string
+}
+
+type processCredentials struct {
+	Access        string `json:"access"`
+	Secret        string `json:"secret"`
+	SecurityToken string `json:"securitytoken"`
+	ExpiresAt     string `json:"expires_at"`
+}
+
+// Retrieve executes the command and returns the credentials parsed from the output.
+func (p *ProcessCredentialProvider) Retrieve() (*Credentials, error) {
+	if p.Command == "" {
+		return nil, fmt.Errorf("the command of credential process is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialProcessTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", p.Command) //nolint:gosec // the command is configured by the user
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.Command) //nolint:gosec // the command is configured by the user
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error executing the credential process: %s, stderr: %s", err,
+			strings.TrimSpace(stderr.String()))
+	}
+
+	var output processCredentials
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("error parsing the output of credential process: %s", err)
+	}
+	if output.Access == "" || output.Secret == "" {
+		return nil, fmt.Errorf("access or secret is missing in the output of credential process")
+	}
+
+	credentials := Credentials{
+		AccessKey:     output.Access,
+		SecretKey:     output.Secret,
+		SecurityToken: output.SecurityToken,
+	}
+	if output.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, output.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the expires_at of credential process: %s", err)
+		}
+		credentials.ExpiresAt = expiresAt
+	}
+	return &credentials, nil
+}
+
+func (*ProcessCredentialProvider) String() string {
+	return "credential process"
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// DefaultDiscoveryCacheTTL is the default time to live of the cached IDs.
+const DefaultDiscoveryCacheTTL = 24 * time.Hour
+
+// discoveryCacheEntry is the cached IDs which are discovered by an identity.
+type discoveryCacheEntry struct {
+	DomainID  string            `json:"domain_id,omitempty"`
+	UserID    string            `json:"user_id,omitempty"`
+	Projects  map[string]string `json:"projects,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// DiscoveryCache persists the region-projectId pairs, domain ID and user ID between runs to reduce the IAM calls
+// during the provider initialization, the entries are keyed by the fingerprint of the identity.
+type DiscoveryCache struct {
+	path string
+	ttl  time.Duration
+	key  string
+}
+
+// newDiscoveryCache creates the cache of the identity of config, nil will be returned if the path is empty.
+func newDiscoveryCache(c *Config) (*DiscoveryCache, error) {
+	if c.DiscoveryCacheFile == "" {
+		return nil, nil
+	}
+
+	cachePath, err := homedir.Expand(c.DiscoveryCacheFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := c.DiscoveryCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultDiscoveryCacheTTL
+	}
+	return &DiscoveryCache{
+		path: cachePath,
+		ttl:  ttl,
+		key:  credentialsFingerprint(c),
+	}, nil
+}
+
+// credentialsFingerprint builds the cache key by the inputs of the identity, the secrets are hashed and never
+// persisted. The temporary credentials are changed in each run, so the sources of them are used instead.
+func credentialsFingerprint(c *Config) string {
+	parts := []string{
+		c.IdentityEndpoint, c.Cloud, c.AccessKey, c.Token, c.UserID, c.Username, c.DomainID, c.DomainName,
+		c.AgencyName, c.AgencyDomainName, c.DelegatedProject, c.SharedConfigFile, c.Profile, c.CredentialProcess,
+	}
+	// the temporary credentials change in each run, so only the non-temporary access key is used
+	if c.SecurityToken != "" {
+		parts[2] = ""
+	}
+	for _, role := range c.AssumeRoles {
+		parts = append(parts, role.DomainName, role.AgencyName)
+	}
+	if c.WebIdentity != nil {
+		parts = append(parts, c.WebIdentity.IdpId)
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(hash[:])
+}
+
+func (dc *DiscoveryCache) readAll() map[string]discoveryCacheEntry {
+	entries := make(map[string]discoveryCacheEntry)
+	data, err := os.ReadFile(dc.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[WARN] failed to read the discovery cache file %s: %s", dc.path, err)
+		}
+		return entries
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		log.Printf("[WARN] failed to parse the discovery cache file %s, the cache is ignored: %s", dc.path, err)
+		return make(map[string]discoveryCacheEntry)
+	}
+	return entries
+}
+
+// writeAll writes the entries to a temporary file and renames it, so that the concurrent providers will not read a
+// partial file.
+func (dc *DiscoveryCache) writeAll(entries map[string]discoveryCacheEntry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dc.path), 0700); err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(dc.path), filepath.Base(dc.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), dc.path)
+}
+
+// Load returns the unexpired entry of the identity.
+func (dc *DiscoveryCache) Load() (*discoveryCacheEntry, bool) {
+	if dc == nil {
+		return nil, false
+	}
+
+	entry, ok := dc.readAll()[dc.key]
+	if !ok || time.Now().After(entry.ExpiresAt) {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Save stores the discovered IDs of the identity, the expiration time of entry is reset.
+func (dc *DiscoveryCache) Save(domainID, userID string, projects map[string]string) {
+	if dc == nil {
+		return
+	}
+
+	entries := dc.readAll()
+	now := time.Now()
+	for k, v := range entries {
+		if now.After(v.ExpiresAt) {
+			delete(entries, k)
+		}
+	}
+	entries[dc.key] = discoveryCacheEntry{
+		DomainID:  domainID,
+		UserID:    userID,
+		Projects:  projects,
+		ExpiresAt: now.Add(dc.ttl),
+	}
+
+	if err := dc.writeAll(entries); err != nil {
+		log.Printf("[WARN] failed to write the discovery cache file %s: %s", dc.path, err)
+	}
+}
+
+// Invalidate removes the entry of the identity, which is called when the authentication fails with the cached IDs.
+func (dc *DiscoveryCache) Invalidate() {
+	if dc == nil {
+		return
+	}
+
+	entries := dc.readAll()
+	if _, ok := entries[dc.key]; !ok {
+		return
+	}
+
+	delete(entries, dc.key)
+	if err := dc.writeAll(entries); err != nil {
+		log.Printf("[WARN] failed to write the discovery cache file %s: %s", dc.path, err)
+	}
+}
+
+// applyDiscoveryCache uses the cached project ID of the default project to skip the query during authentication.
+// The roles (including the agency of shared profile) are assumed after the authentication, so the cached IDs which
+// belong to the assumed identity will not be used in this stage.
+func (c *Config) applyDiscoveryCache(entry *discoveryCacheEntry) bool {
+	if entry == nil || c.TenantID != "" || len(c.AssumeRoles) > 0 || c.SharedConfigFile != "" {
+		return false
+	}
+
+	if projectID, ok := entry.Projects[c.TenantName]; ok && projectID != "" {
+		log.Printf("[DEBUG] use the cached project ID %s of %s", projectID, c.TenantName)
+		c.TenantID = projectID
+		return true
+	}
+	return false
+}
+
+// isStale checks whether the cached IDs conflict with the IDs of the authenticated identity.
+func (e *discoveryCacheEntry) isStale(c *Config) bool {
+	if e.DomainID != "" && c.DomainID != "" && e.DomainID != c.DomainID {
+		return true
+	}
+	if c.HwClient == nil || c.HwClient.ProjectID == "" {
+		return false
+	}
+	projectID, ok := e.Projects[c.Region]
+	return ok && projectID != c.HwClient.ProjectID
+}
+
+// saveDiscoveryCache persists the discovered IDs of the identity, it must not be called with RPLock held.
+func (c *Config) saveDiscoveryCache() {
+	if c.DiscoveryCache == nil {
+		return
+	}
+
+	c.RPLock.Lock()
+	projects := make(map[string]string, len(c.RegionProjectIDMap))
+	for k, v := range c.RegionProjectIDMap {
+		projects[k] = v
+	}
+	c.RPLock.Unlock()
+
+	c.DiscoveryCache.Save(c.DomainID, c.UserID, projects)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ServiceCatalog defines a struct which was used to generate a service client for huaweicloud.
@@ -896,9 +897,15 @@ var allServiceCatalog = map[string]ServiceCatalog{
 	},
 }
 
+const (
+	// the placeholders in the customizing endpoints
+	endpointRegionPlaceholder    = "{region}"
+	endpointProjectIDPlaceholder = "{project_id}"
+)
+
 // GetServiceEndpoint try to get the endpoint from customizing map
 func GetServiceEndpoint(c *Config, srv, region string) string {
-	if endpoint, ok := c.Endpoints[srv]; ok {
+	if endpoint, ok := c.getCustomEndpoint(srv, region); ok {
 		return endpoint
 	}
 
@@ -917,6 +924,61 @@ func GetServiceEndpoint(c *Config, srv, region string) string {
 	return ep
 }
 
+// lookupCustomEndpoint returns the customizing endpoint of the service without the placeholders replaced, the
+// endpoint of the region in RegionEndpoints takes precedence over the one in Endpoints.
+func (c *Config) lookupCustomEndpoint(srv, region string) (string, bool) {
+	if region == "" {
+		region = c.Region
+	}
+	if endpoint, ok := c.RegionEndpoints[region][srv]; ok {
+		return endpoint, true
+	}
+
+	endpoint, ok := c.Endpoints[srv]
+	return endpoint, ok
+}
+
+// isRegionalCustomEndpoint checks whether the customizing endpoint of the service can be used in the region, that is
+// the endpoint is specified for the region or it has the {region} placeholder.
+func isRegionalCustomEndpoint(c *Config, srv, region string) bool {
+	if _, ok := c.RegionEndpoints[region][srv]; ok {
+		return true
+	}
+
+	endpoint, ok := c.Endpoints[srv]
+	return ok && strings.Contains(endpoint, endpointRegionPlaceholder)
+}
+
+// getCustomEndpoint returns the customizing endpoint of the service with the placeholders replaced, the project ID
+// of the region is queried only if the {project_id} placeholder is used.
+func (c *Config) getCustomEndpoint(srv, region string) (string, bool) {
+	endpoint, ok := c.lookupCustomEndpoint(srv, region)
+	if !ok {
+		return "", false
+	}
+
+	if region == "" {
+		region = c.Region
+	}
+	var projectID string
+	if strings.Contains(endpoint, endpointProjectIDPlaceholder) && c.DomainClient != nil {
+		projectID = c.GetProjectID(region)
+	}
+	return renderCustomEndpoint(endpoint, region, projectID), true
+}
+
+// renderCustomEndpoint replaces the {region} and {project_id} placeholders in the customizing endpoint,
+// the placeholder will be kept if the value is empty.
+func renderCustomEndpoint(endpoint, region, projectID string) string {
+	if region != "" {
+		endpoint = strings.ReplaceAll(endpoint, endpointRegionPlaceholder, region)
+	}
+	if projectID != "" {
+		endpoint = strings.ReplaceAll(endpoint, endpointProjectIDPlaceholder, projectID)
+	}
+	return endpoint
+}
+
 // GetServiceCatalog returns the catalog object of a service
 func GetServiceCatalog(service string) *ServiceCatalog {
 	if catalog, ok := allServiceCatalog[service]; ok {
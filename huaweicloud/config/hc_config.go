@@ -1,14 +1,10 @@
 package config
 
 import (
-	"bytes"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
-	"net/url"
 	"os"
-	"strings"
 
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/auth/basic"
@@ -54,17 +50,9 @@ func buildAuthCredentials(c *Config, region string) (*basic.Credentials, error)
 		IamEndpoint:   c.IdentityEndpoint,
 	}
 
-	c.RPLock.Lock()
-	defer c.RPLock.Unlock()
-	projectID, ok := c.RegionProjectIDMap[region]
-	if !ok {
-		// Not find in the map, then try to query and store.
-		client := c.HwClient
-		err := c.loadUserProjects(client, region)
-		if err != nil {
-			return nil, err
-		}
-		projectID = c.RegionProjectIDMap[region]
+	projectID, err := c.getRegionProjectID(c.HwClient, region)
+	if err != nil {
+		return nil, err
 	}
 
 	credentials.ProjectId = projectID
@@ -90,39 +78,38 @@ func buildGlobalAuthCredentials(c *Config, region string) (*global.Credentials,
 func buildHTTPConfig(c *Config) *hcconfig.HttpConfig {
 	httpConfig := hcconfig.DefaultHttpConfig()
 
-	if c.MaxRetries > 0 {
-		httpConfig = httpConfig.WithRetries(c.MaxRetries)
-	}
-
-	if c.Insecure {
-		httpConfig = httpConfig.WithIgnoreSSLVerification(true)
-	}
-
 	httpHandler := httphandler.NewHttpHandler().
-		AddRequestHandler(logRequestHandler).
-		AddResponseHandler(logResponseHandler)
-	httpConfig = httpConfig.WithHttpHandler(httpHandler)
-
-	if proxyURL := getProxyFromEnv(); proxyURL != "" {
-		if parsed, err := url.Parse(proxyURL); err == nil {
-			logp.Printf("[DEBUG] using https proxy: %s://%s", parsed.Scheme, parsed.Host)
-
-			httpProxy := hcconfig.Proxy{
-				Schema:   parsed.Scheme,
-				Host:     parsed.Host,
-				Username: parsed.User.Username(),
-			}
-			if pwd, ok := parsed.User.Password(); ok {
-				httpProxy.Password = pwd
+		AddRequestHandler(func(request http.Request) {
+			// the request handler is invoked synchronously before sending the request
+			if err := c.RateLimiters.Wait(&request); err != nil {
+				log.Printf("[WARN] failed to wait for the rate limiter: %s", err)
 			}
+		})
+	httpConfig = httpConfig.WithHttpHandler(httpHandler)
 
-			httpConfig = httpConfig.WithProxy(&httpProxy)
-		} else {
-			logp.Printf("[WARN] parsing https proxy failed: %s", err)
-		}
+	// huaweicloud-sdk-go-v3 package neither retries the requests nor accepts a custom RoundTripper, so the requests
+	// are sent by the LogRoundTripper which is registered as the protocol handler of the transport, then the logs,
+	// retries and trace records are the same as the golangsdk clients.
+	tlsConfig, err := generateTLSConfig(c)
+	if err != nil {
+		logp.Printf("[WARN] failed to generate the TLS config: %s", err)
 	}
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}
+	logRt := &LogRoundTripper{
+		Rt:          wrapCassette(c, transport),
+		MaxRetries:  c.MaxRetries,
+		RetryPolicy: c.RetryPolicy,
+		Tracer:      c.Tracer,
+		Redactor:    c.Redactor,
+	}
+	hcTransport := &http.Transport{}
+	hcTransport.RegisterProtocol("https", logRt)
+	hcTransport.RegisterProtocol("http", logRt)
 
-	return httpConfig
+	return httpConfig.WithHttpTransport(hcTransport)
 }
 
 // HcVpcV3Client is the VPC service client using huaweicloud-sdk-go-v3 package
@@ -299,6 +286,10 @@ func (c *Config) HcCceV3Client(region string) (*ccev3.CceClient, error) {
 
 // NewHcClient is the common client using huaweicloud-sdk-go-v3 package
 func NewHcClient(c *Config, region, product string, globalFlag bool) (*core.HcHttpClient, error) {
+	if err := c.refreshCredentials(); err != nil {
+		return nil, err
+	}
+
 	endpoint := GetServiceEndpoint(c, product, region)
 	if endpoint == "" {
 		return nil, fmt.Errorf("failed to get the endpoint of %q service in region %s", product, region)
@@ -330,101 +321,3 @@ func NewHcClient(c *Config, region, product string, globalFlag bool) (*core.HcHt
 
 	return builder.Build().PreInvoke(headers), nil
 }
-
-func getProxyFromEnv() string {
-	var url string
-
-	envNames := []string{"HTTPS_PROXY", "https_proxy"}
-	for _, n := range envNames {
-		if val := os.Getenv(n); val != "" {
-			url = val
-			break
-		}
-	}
-
-	return url
-}
-
-func logRequestHandler(request http.Request) {
-	log.Printf("[DEBUG] API Request URL: %s %s", request.Method, request.URL)
-	log.Printf("[DEBUG] API Request Headers:\n%s", FormatHeaders(request.Header, "\n"))
-	if request.Body != nil {
-		if err := logRequest(request.Body, request.Header.Get("Content-Type")); err != nil {
-			log.Printf("[WARN] failed to get request body: %s", err)
-		}
-	}
-}
-
-func logResponseHandler(response http.Response) {
-	log.Printf("[DEBUG] API Response Code: %d", response.StatusCode)
-	log.Printf("[DEBUG] API Response Headers:\n%s", FormatHeaders(response.Header, "\n"))
-
-	if err := logResponse(response.Body, response.Header.Get("Content-Type")); err != nil {
-		log.Printf("[WARN] failed to get response body: %s", err)
-	}
-}
-
-func logRequest(original io.ReadCloser, contentType string) error {
-	defer original.Close()
-
-	var bs bytes.Buffer
-	_, err := io.Copy(&bs, original)
-	if err != nil {
-		return err
-	}
-
-	body := bs.Bytes()
-	index := findJSONIndex(body)
-	if index == -1 {
-		return nil
-	}
-
-	// Handle request contentType
-	if strings.HasPrefix(contentType, "application/json") {
-		debugInfo := formatJSON(body[index:], true)
-		log.Printf("[DEBUG] API Request Body: %s", debugInfo)
-	} else {
-		log.Printf("[DEBUG] Not logging because the request body isn't JSON")
-	}
-
-	return nil
-}
-
-// logResponse will log the HTTP Response details.
-// If the body is JSON, it will attempt to be pretty-formatted.
-func logResponse(original io.ReadCloser, contentType string) error {
-	defer original.Close()
-
-	var bs bytes.Buffer
-	_, err := io.Copy(&bs, original)
-	if err != nil {
-		return err
-	}
-
-	body := bs.Bytes()
-	index := findJSONIndex(body)
-	if index == -1 {
-		return nil
-	}
-
-	if strings.HasPrefix(contentType, "application/json") {
-		debugInfo := formatJSON(body[index:], true)
-		log.Printf("[DEBUG] API Response Body: %s", debugInfo)
-	} else {
-		log.Printf("[DEBUG] Not logging because the response body isn't JSON")
-	}
-
-	return nil
-}
-
-func findJSONIndex(raw []byte) int {
-	var index = -1
-	for i, v := range raw {
-		if v == '{' {
-			index = i
-			break
-		}
-	}
-
-	return index
-}
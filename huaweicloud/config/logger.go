@@ -2,13 +2,14 @@ package config
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"math"
+	"math/rand"
 	"net/http"
-	"sort"
 	"strings"
 	"time"
 
@@ -25,6 +26,57 @@ var maxTimeout = 10 * time.Minute
 type LogRoundTripper struct {
 	Rt         http.RoundTripper
 	MaxRetries int
+	// RetryPolicy is used to retry the server error responses, the connection errors are always retried
+	RetryPolicy *RetryPolicy
+	// Tracer is used to record the API calls in the trace file
+	Tracer *Tracer
+	// Redactor is used to redact the sensitive fields in the debug logs, the built-in fields are redacted if it's nil
+	Redactor *Redactor
+}
+
+// RetryPolicy defines which server error responses should be retried, it should only be applied to the idempotent
+// HTTP methods.
+type RetryPolicy struct {
+	Methods     []string
+	StatusCodes []int
+}
+
+var (
+	defaultRetryMethods     = []string{"GET", "HEAD", "OPTIONS", "PUT", "DELETE"}
+	defaultRetryStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+)
+
+// NewRetryPolicy creates a RetryPolicy, the default methods and status codes are used if they are empty.
+func NewRetryPolicy(methods []string, statusCodes []int) *RetryPolicy {
+	policy := RetryPolicy{
+		Methods:     defaultRetryMethods,
+		StatusCodes: defaultRetryStatusCodes,
+	}
+
+	if len(methods) > 0 {
+		policy.Methods = make([]string, len(methods))
+		for i, m := range methods {
+			policy.Methods[i] = strings.ToUpper(m)
+		}
+	}
+	if len(statusCodes) > 0 {
+		policy.StatusCodes = statusCodes
+	}
+	return &policy
+}
+
+// IsRetryable checks whether the response of the request should be retried.
+func (p *RetryPolicy) IsRetryable(method string, statusCode int) bool {
+	if p == nil || !utils.StrSliceContains(p.Methods, method) {
+		return false
+	}
+
+	for _, code := range p.StatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
 }
 
 func retryTimeout(count int) time.Duration {
@@ -36,8 +88,52 @@ func retryTimeout(count int) time.Duration {
 	return timeout
 }
 
+// retryJitterTimeout returns a random duration between 0 and retryTimeout, which is known as "full jitter",
+// to prevent the concurrent requests from retrying at the same time.
+func retryJitterTimeout(count int) time.Duration {
+	timeout := retryTimeout(count)
+	//nolint:gosec // the random number is only used to calculate the backoff duration
+	return time.Duration(rand.Int63n(int64(timeout) + 1))
+}
+
+// retryDelay returns the duration to wait before retrying. The Retry-After header of the server error response is
+// preferred, which is parsed in the same way as the throttled responses (see RateLimiters.RetryAfter), and the
+// jittered backoff is used if it is missing.
+func retryDelay(response *http.Response, count int) time.Duration {
+	if response != nil {
+		if delay, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			return delay
+		}
+	}
+	return retryJitterTimeout(count)
+}
+
+// waitForRetry waits for the delay or returns the error if the context of request is done.
+func waitForRetry(ctx context.Context, delay time.Duration) error {
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rewindRequestBody resets the request body before retrying, so the retried request will send the same body.
+func rewindRequestBody(request *http.Request) error {
+	if request.Body == nil || request.GetBody == nil {
+		return nil
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return fmt.Errorf("failed to rewind the request body: %s", err)
+	}
+	request.Body = body
+	return nil
+}
+
 // RoundTrip performs a round-trip HTTP request and logs relevant information about it.
-func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (response *http.Response, err error) {
 	defer func() {
 		if request.Body != nil {
 			request.Body.Close()
@@ -47,19 +143,31 @@ func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, er
 	// for future reference, this is how to access the Transport struct:
 	//tlsconfig := lrt.Rt.(*http.Transport).TLSClientConfig
 
-	var err error
+	retry := 1
+	if lrt.Tracer != nil {
+		start := time.Now()
+		defer func() {
+			lrt.traceRequest(request, response, err, retry-1, time.Since(start))
+		}()
+	}
 
-	log.Printf("[DEBUG] API Request URL: %s %s", request.Method, request.URL)
-	log.Printf("[DEBUG] API Request Headers:\n%s", FormatHeaders(request.Header, "\n"))
+	log.Printf("[DEBUG] API Request URL: %s %s", request.Method, lrt.Redactor.RedactURL(request.URL))
+	log.Printf("[DEBUG] API Request Headers:\n%s", lrt.Redactor.FormatHeaders(request.Header, "\n"))
 
 	if request.Body != nil {
-		request.Body, err = lrt.logRequest(request.Body, request.Header.Get("Content-Type"))
+		body, err := lrt.logRequest(request.Body, request.Header.Get("Content-Type"))
 		if err != nil {
 			return nil, err
 		}
+
+		// the body has been buffered, so it can be replayed when retrying
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		request.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
 	}
 
-	response, err := lrt.Rt.RoundTrip(request)
+	response, err = lrt.Rt.RoundTrip(request)
 	if response == nil {
 		errMessage := err.Error()
 		if strings.Contains(errMessage, "no such host") {
@@ -67,35 +175,68 @@ func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, er
 		}
 	}
 
-	// Retrying connection
-	retry := 1
-	for response == nil {
-
+	// Retrying connection errors and the retryable server errors
+	for response == nil || lrt.RetryPolicy.IsRetryable(request.Method, response.StatusCode) {
 		if retry > lrt.MaxRetries {
+			if response != nil {
+				log.Printf("[DEBUG] server error %d, retries exhausted", response.StatusCode)
+				break
+			}
+
 			log.Printf("[DEBUG] connection error, retries exhausted. Aborting")
 			err = fmt.Errorf("connection error, retries exhausted. Aborting. Last error was: %s", err)
 			return nil, err
 		}
 
-		log.Printf("[DEBUG] connection error, retry number %d: %s", retry, err)
+		delay := retryDelay(response, retry)
+		if response != nil {
+			log.Printf("[DEBUG] server error %d, retry number %d after %s", response.StatusCode, retry, delay)
+			// drain and close the body to reuse the connection
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		} else {
+			log.Printf("[DEBUG] connection error, retry number %d: %s", retry, err)
+		}
+
+		if err := waitForRetry(request.Context(), delay); err != nil {
+			return nil, err
+		}
+		if err := rewindRequestBody(request); err != nil {
+			return nil, err
+		}
 
-		//lintignore:R018
-		time.Sleep(retryTimeout(retry))
 		response, err = lrt.Rt.RoundTrip(request)
 		retry++
 	}
 
 	log.Printf("[DEBUG] API Response Code: %d", response.StatusCode)
-	log.Printf("[DEBUG] API Response Headers:\n%s", FormatHeaders(response.Header, "\n"))
+	log.Printf("[DEBUG] API Response Headers:\n%s", lrt.Redactor.FormatHeaders(response.Header, "\n"))
 
 	response.Body, err = lrt.logResponse(response.Body, response.Header.Get("Content-Type"))
 
 	return response, err
 }
 
-// logRequest will log the HTTP Request details.
+// traceRequest appends the record of the API call to the trace file.
+func (lrt *LogRoundTripper) traceRequest(request *http.Request, response *http.Response, err error, retries int,
+	latency time.Duration) {
+	record := newTraceRecord(request.Method, request.URL)
+	record.Retries = retries
+	record.LatencyMs = latency.Milliseconds()
+	if response != nil {
+		record.StatusCode = response.StatusCode
+		record.RequestID = response.Header.Get("X-Request-Id")
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	lrt.Tracer.Record(record)
+}
+
+// logRequest will log the HTTP Request details and return the body.
 // If the body is JSON, it will attempt to be pretty-formatted.
-func (lrt *LogRoundTripper) logRequest(original io.ReadCloser, contentType string) (io.ReadCloser, error) {
+func (lrt *LogRoundTripper) logRequest(original io.ReadCloser, contentType string) ([]byte, error) {
 	defer original.Close()
 
 	var bs bytes.Buffer
@@ -109,15 +250,15 @@ func (lrt *LogRoundTripper) logRequest(original io.ReadCloser, contentType strin
 	// Handle request contentType
 	switch {
 	case isJSONFormat:
-		debugInfo := formatJSON(bs.Bytes(), true)
+		debugInfo := lrt.Redactor.formatJSON(bs.Bytes())
 		log.Printf("[DEBUG] API Request Body: %s", debugInfo)
 	case isXMLFormat:
-		log.Printf("[DEBUG] API Request Body: %s", bs.String())
+		log.Printf("[DEBUG] API Request Body: %s", lrt.Redactor.redactXML(bs.String()))
 	default:
 		log.Printf("[DEBUG] Not logging because the request body isn't JSON or XML format")
 	}
 
-	return io.NopCloser(strings.NewReader(bs.String())), nil
+	return bs.Bytes(), nil
 }
 
 // logResponse will log the HTTP Response details.
@@ -135,10 +276,10 @@ func (lrt *LogRoundTripper) logResponse(original io.ReadCloser, contentType stri
 	isXMLFormat := strings.HasPrefix(contentType, "application/xml")
 	switch {
 	case isJSONFormat:
-		debugInfo := formatJSON(bs.Bytes(), true)
+		debugInfo := lrt.Redactor.formatJSON(bs.Bytes())
 		log.Printf("[DEBUG] API Response Body: %s", debugInfo)
 	case isXMLFormat:
-		log.Printf("[DEBUG] API Response Body: %s", bs.String())
+		log.Printf("[DEBUG] API Response Body: %s", lrt.Redactor.redactXML(bs.String()))
 	default:
 		log.Printf("[DEBUG] Not logging because the response body isn't JSON or XML format")
 	}
@@ -148,7 +289,7 @@ func (lrt *LogRoundTripper) logResponse(original io.ReadCloser, contentType stri
 
 // formatJSON will try to pretty-format a JSON body.
 // It will also mask known fields which contain sensitive information.
-func formatJSON(raw []byte, maskBody bool) string {
+func (r *Redactor) formatJSON(raw []byte) string {
 	var data map[string]interface{}
 
 	if len(raw) == 0 {
@@ -162,9 +303,7 @@ func formatJSON(raw []byte, maskBody bool) string {
 	}
 
 	// Mask known password fields
-	if maskBody {
-		maskSecurityFields(data)
-	}
+	r.maskSecurityFields(data)
 
 	// Ignore the catalog
 	if _, ok := data["catalog"]; ok {
@@ -184,72 +323,3 @@ func formatJSON(raw []byte, maskBody bool) string {
 
 	return string(pretty)
 }
-
-// RedactHeaders processes a headers object, returning a redacted list.
-func RedactHeaders(headers http.Header) (processedHeaders []string) {
-	// sensitiveWords is a list of headers that need to be redacted.
-	var sensitiveWords = []string{"token", "authorization"}
-
-	for name, header := range headers {
-		for _, v := range header {
-			if utils.IsStrContainsSliceElement(name, sensitiveWords, true, false) {
-				processedHeaders = append(processedHeaders, fmt.Sprintf("%v: %v", name, "***"))
-			} else {
-				processedHeaders = append(processedHeaders, fmt.Sprintf("%v: %v", name, v))
-			}
-		}
-	}
-	return
-}
-
-// FormatHeaders processes a headers object plus a deliminator, returning a string
-func FormatHeaders(headers http.Header, seperator string) string {
-	redactedHeaders := RedactHeaders(headers)
-	sort.Strings(redactedHeaders)
-
-	return strings.Join(redactedHeaders, seperator)
-}
-
-func maskSecurityFields(data map[string]interface{}) {
-	for k, val := range data {
-		switch val := val.(type) {
-		case string:
-			if isSecurityFields(k) {
-				data[k] = "***"
-			} else if len(val) > MAXFieldLength {
-				data[k] = "** large string **"
-			}
-		case map[string]interface{}:
-			if isSecurityFields(k) {
-				data[k] = map[string]string{"***": "***"}
-			} else {
-				maskSecurityFields(val)
-			}
-		}
-	}
-}
-
-func isSecurityFields(field string) bool {
-	checkField := strings.ToLower(field)
-	// 'password' is apply to the most request JSON body.
-	// 'secret' is apply to the AK/SK response JSON body.
-	// 'pwd' and 'token' is the high frequency sensitive keywords in the request and response bodies.
-	if strings.Contains(checkField, "password") || strings.Contains(checkField, "secret") ||
-		strings.HasSuffix(checkField, "pwd") || strings.HasSuffix(checkField, "token") {
-		return true
-	}
-
-	// 'adminpass' is apply to the ecs/bms instance request JSON body
-	// 'encrypted_user_data' is apply to the function request JSON body of FunctionGraph
-	// 'nonce' is apply to the random string for authorization methods.
-	// 'email', 'phone' and 'sip_number' can uniquely identify a person.
-	// 'signature' are used for encryption.
-	// 'user_passwd' is apply to the dms/kafka user request JSON body
-	// 'auth' is apply to kms keypairs associate or disassociate request JSON body
-	// 'cert_content', 'private_key' and 'trusted_root_ca' are both sensitive parameters of the SSL certificate for APIG
-	// 'sk', 'src_sk' and 'dst_sk' are used in oms_task and oms_task_group
-	// request JSON body
-	securityFields := []string{"adminpass", "encrypted_user_data", "nonce", "email", "phone", "sip_number",
-		"signature", "user_passwd", "auth", "cert_content", "private_key", "trusted_root_ca", "sk", "src_sk", "dst_sk"}
-	return utils.StrSliceContains(securityFields, checkField)
-}
@@ -0,0 +1,238 @@
+package config
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRetryAfter is the maximum duration to wait which was specified by the Retry-After header
+var maxRetryAfter = 30 * time.Minute
+
+// tokenBucket is a client-side rate limiter which allows `rate` requests per second with a burst of `burst`.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// pausedUntil is used to block all requests until the time specified by the Retry-After header
+	pausedUntil time.Time
+}
+
+func newTokenBucket(rate int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(rate),
+		burst:  float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns zero, or returns the duration to wait for the next token.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now)
+	}
+
+	// a bucket without rate is only used to handle the Retry-After header
+	if b.rate <= 0 {
+		return 0
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// Wait blocks until a token is available or the context is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := b.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (b *tokenBucket) pause(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// RateLimiters is a set of client-side rate limiters keyed by the service name of ServiceCatalog.
+type RateLimiters struct {
+	mu sync.Mutex
+	// limits is a map of the service name and the allowed requests per second
+	limits map[string]int
+	// hosts is a map of the host of customizing endpoints and the service name
+	hosts    map[string]string
+	limiters map[string]*tokenBucket
+}
+
+// NewRateLimiters creates the rate limiters with the limits configured in the provider, the key of limits can be
+// either the catalog key or the service name, e.g. ecs, vpc, iam.
+func NewRateLimiters(limits map[string]int, endpoints map[string]string) *RateLimiters {
+	r := RateLimiters{
+		limits:   make(map[string]int),
+		hosts:    make(map[string]string),
+		limiters: make(map[string]*tokenBucket),
+	}
+
+	for key, rate := range limits {
+		name := key
+		if catalog, ok := allServiceCatalog[key]; ok && catalog.Name != "" {
+			name = catalog.Name
+		}
+
+		// use the stricter limit if multiple keys point to the same service
+		if old, ok := r.limits[name]; !ok || rate < old {
+			r.limits[name] = rate
+		}
+
+		if endpoint, ok := endpoints[key]; ok {
+			if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+				r.hosts[u.Host] = name
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] client-side rate limits: %v", r.limits)
+	return &r
+}
+
+// serviceName returns the service name of the request host, the host of customizing endpoints is checked first,
+// then the first part of host which likes {Name}.{Region}.{Cloud} is returned.
+func (r *RateLimiters) serviceName(host string) string {
+	if name, ok := r.hosts[host]; ok {
+		return name
+	}
+	return getServiceNameByHost(host)
+}
+
+func (r *RateLimiters) getLimiter(host string) *tokenBucket {
+	name := r.serviceName(host)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	limiter, ok := r.limiters[name]
+	if !ok {
+		limiter = newTokenBucket(r.limits[name])
+		r.limiters[name] = limiter
+	}
+	return limiter
+}
+
+// Wait blocks until the request is allowed by the rate limiter of its service.
+func (r *RateLimiters) Wait(request *http.Request) error {
+	if r == nil || request.URL == nil {
+		return nil
+	}
+	return r.getLimiter(request.URL.Host).Wait(request.Context())
+}
+
+// RetryAfter records the duration specified by the Retry-After header of a throttled response, all requests of the
+// service will be blocked until the duration expires.
+func (r *RateLimiters) RetryAfter(response *http.Response) {
+	if r == nil || response == nil || response.Request == nil || response.Request.URL == nil {
+		return
+	}
+	if response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable {
+		return
+	}
+
+	if delay, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+		log.Printf("[DEBUG] received Retry-After header from %s, pause the requests for %s",
+			response.Request.URL.Host, delay)
+		r.getLimiter(response.Request.URL.Host).pause(delay)
+	}
+}
+
+// GetRetryAfter returns the remaining duration of the Retry-After header received from the service of rawURL.
+func (r *RateLimiters) GetRetryAfter(rawURL string) time.Duration {
+	if r == nil {
+		return 0
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return 0
+	}
+
+	limiter := r.getLimiter(u.Host)
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return time.Until(limiter.pausedUntil)
+}
+
+// parseRetryAfter parses the value of Retry-After header, which is either delay seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if delay < 0 {
+		return 0, false
+	}
+	if delay > maxRetryAfter {
+		delay = maxRetryAfter
+	}
+	return delay, true
+}
+
+// RateLimitRoundTripper satisfies the http.RoundTripper interface and is used to throttle the requests on the
+// client side before sending them to the API.
+type RateLimitRoundTripper struct {
+	Rt       http.RoundTripper
+	Limiters *RateLimiters
+}
+
+// RoundTrip waits for the rate limiter of the service, then performs the round-trip HTTP request.
+func (rrt *RateLimitRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := rrt.Limiters.Wait(request); err != nil {
+		return nil, err
+	}
+
+	response, err := rrt.Rt.RoundTrip(request)
+	if err == nil {
+		rrt.Limiters.RetryAfter(response)
+	}
+	return response, err
+}
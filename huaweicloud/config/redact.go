@@ -0,0 +1,203 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const redactedValue = "***"
+
+var (
+	// sensitiveHeaders is a list of header keywords that need to be redacted, e.g. X-Auth-Token, X-Security-Token.
+	sensitiveHeaders = []string{"token", "authorization", "cookie", "secret"}
+
+	xmlElementRegexp = regexp.MustCompile(`<([\w:.-]+)(\s[^>]*)?>([^<]*)</([\w:.-]+)>`)
+
+	// defaultRedactor only redacts the built-in sensitive fields
+	defaultRedactor = NewRedactor(nil)
+)
+
+// Redactor redacts the sensitive fields in the debug logs and cassettes of both request and response, including
+// JSON body, XML body, headers and URL query parameters. The built-in fields are always redacted, and the additional
+// fields configured in the provider block are matched with the whole key (case-insensitive).
+// A nil Redactor only redacts the built-in fields.
+type Redactor struct {
+	fields []string
+}
+
+// NewRedactor creates a Redactor with the additional fields which need to be redacted.
+func NewRedactor(fields []string) *Redactor {
+	r := Redactor{
+		fields: make([]string, 0, len(fields)),
+	}
+	for _, f := range fields {
+		if f = strings.ToLower(strings.TrimSpace(f)); f != "" {
+			r.fields = append(r.fields, f)
+		}
+	}
+	return &r
+}
+
+func (r *Redactor) isCustomSensitiveField(field string) bool {
+	if r == nil {
+		return false
+	}
+	return utils.StrSliceContains(r.fields, strings.ToLower(field))
+}
+
+// isSensitiveHeader checks whether the header (or query parameter) contains the sensitive keywords.
+func (r *Redactor) isSensitiveHeader(name string) bool {
+	return utils.IsStrContainsSliceElement(name, sensitiveHeaders, true, false) || r.isCustomSensitiveField(name)
+}
+
+// RedactHeaders processes a headers object, returning a redacted list.
+func (r *Redactor) RedactHeaders(headers http.Header) (processedHeaders []string) {
+	for name, header := range headers {
+		for _, v := range header {
+			if r.isSensitiveHeader(name) {
+				processedHeaders = append(processedHeaders, fmt.Sprintf("%v: %v", name, redactedValue))
+			} else {
+				processedHeaders = append(processedHeaders, fmt.Sprintf("%v: %v", name, v))
+			}
+		}
+	}
+	return
+}
+
+// FormatHeaders processes a headers object plus a deliminator, returning a string
+func (r *Redactor) FormatHeaders(headers http.Header, seperator string) string {
+	redactedHeaders := r.RedactHeaders(headers)
+	sort.Strings(redactedHeaders)
+
+	return strings.Join(redactedHeaders, seperator)
+}
+
+// RedactURL returns the URL string in which the values of sensitive query parameters are masked, e.g. the tokens
+// and signatures of the pre-signed URLs. The order of query parameters is kept.
+func (r *Redactor) RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	params := strings.Split(u.RawQuery, "&")
+	for i, param := range params {
+		key, _, found := strings.Cut(param, "=")
+		if !found {
+			continue
+		}
+		name, err := url.QueryUnescape(key)
+		if err == nil && (r.isSensitiveHeader(name) || r.isSecurityFields(name)) {
+			params[i] = key + "=" + redactedValue
+		}
+	}
+
+	redacted := *u
+	redacted.RawQuery = strings.Join(params, "&")
+	return redacted.String()
+}
+
+// RedactHeaders processes a headers object with the built-in sensitive headers, returning a redacted list.
+func RedactHeaders(headers http.Header) []string {
+	return defaultRedactor.RedactHeaders(headers)
+}
+
+// FormatHeaders processes a headers object plus a deliminator with the built-in sensitive headers, returning a string
+func FormatHeaders(headers http.Header, seperator string) string {
+	return defaultRedactor.FormatHeaders(headers, seperator)
+}
+
+// redactXML masks the value of known XML elements which contain sensitive information.
+func (r *Redactor) redactXML(raw string) string {
+	return xmlElementRegexp.ReplaceAllStringFunc(raw, func(element string) string {
+		matches := xmlElementRegexp.FindStringSubmatch(element)
+		// the start tag and end tag must be the same
+		if len(matches) < 5 || matches[1] != matches[4] || !r.isSecurityFields(localXMLName(matches[1])) {
+			return element
+		}
+		return fmt.Sprintf("<%s%s>%s</%s>", matches[1], matches[2], redactedValue, matches[4])
+	})
+}
+
+// localXMLName returns the element name without namespace prefix.
+func localXMLName(name string) string {
+	if index := strings.LastIndex(name, ":"); index >= 0 {
+		return name[index+1:]
+	}
+	return name
+}
+
+func (r *Redactor) maskSecurityFields(data map[string]interface{}) {
+	for k, val := range data {
+		switch val := val.(type) {
+		case string:
+			if r.isSecurityFields(k) {
+				data[k] = redactedValue
+			} else if len(val) > MAXFieldLength {
+				data[k] = "** large string **"
+			}
+		case map[string]interface{}:
+			if r.isSecurityFields(k) {
+				data[k] = map[string]string{redactedValue: redactedValue}
+			} else {
+				r.maskSecurityFields(val)
+			}
+		case []interface{}:
+			if r.isSecurityFields(k) {
+				data[k] = []string{redactedValue}
+			} else {
+				r.maskSecurityList(val)
+			}
+		}
+	}
+}
+
+func (r *Redactor) maskSecurityList(data []interface{}) {
+	for _, val := range data {
+		switch val := val.(type) {
+		case map[string]interface{}:
+			r.maskSecurityFields(val)
+		case []interface{}:
+			r.maskSecurityList(val)
+		}
+	}
+}
+
+// isSecurityFields checks whether the field is sensitive, the keys in securityFields and the custom fields are only
+// matched with the whole key, e.g. "access" does not match "access_mode".
+func (r *Redactor) isSecurityFields(field string) bool {
+	checkField := strings.ToLower(field)
+	// 'password' is apply to the most request JSON body.
+	// 'secret' is apply to the AK/SK response JSON body.
+	// 'pwd' and 'token' is the high frequency sensitive keywords in the request and response bodies.
+	if strings.Contains(checkField, "password") || strings.Contains(checkField, "secret") ||
+		strings.HasSuffix(checkField, "pwd") || strings.HasSuffix(checkField, "token") {
+		return true
+	}
+
+	// 'adminpass' and 'admin_pass' are apply to the ecs/bms instance request JSON body
+	// 'encrypted_user_data' is apply to the function request JSON body of FunctionGraph
+	// 'nonce' is apply to the random string for authorization methods.
+	// 'email', 'phone' and 'sip_number' can uniquely identify a person.
+	// 'signature' are used for encryption.
+	// 'user_passwd' is apply to the dms/kafka user request JSON body
+	// 'auth' is apply to kms keypairs associate or disassociate request JSON body
+	// 'cert_content', 'private_key' and 'trusted_root_ca' are both sensitive parameters of the SSL certificate for APIG
+	// 'sk', 'src_sk' and 'dst_sk' are used in oms_task and oms_task_group
+	// 'access', 'ak', 'access_key' and 'accesskey' are apply to the AK/SK request and response JSON body
+	// 'accesskeyid' is apply to the query parameters of the OBS pre-signed URL
+	// request JSON body
+	securityFields := []string{"adminpass", "admin_pass", "encrypted_user_data", "nonce", "email", "phone",
+		"sip_number", "signature", "user_passwd", "auth", "cert_content", "private_key", "privatekey",
+		"trusted_root_ca", "sk", "src_sk", "dst_sk", "access", "ak", "access_key", "accesskey",
+		"accesskeyid"}
+	return utils.StrSliceContains(securityFields, checkField) || r.isCustomSensitiveField(checkField)
+}
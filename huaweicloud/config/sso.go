@@ -0,0 +1,157 @@
+package config
+
+import (
+	"crypto/sha1" //nolint:gosec // the hash is only used to name the cache file
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	// the directory of the cached tokens which are created by the CLI after logging in to IAM Identity Center
+	ssoCacheDir = "~/.hcloud/sso/cache"
+	// the timeout of exchanging the temporary credentials from the Identity Center portal
+	ssoRequestTimeout = 30 * time.Second
+)
+
+// SSOCredentialProvider retrieves the temporary credentials of an account by the cached token of IAM Identity Center,
+// the token is cached by the CLI after logging in and it will not be refreshed by the provider.
+type SSOCredentialProvider struct {
+	StartUrl  string
+	Region    string
+	AccountId string
+	AgencyUrn string
+	// PortalUrl is the URL of the Identity Center portal, defaults to
+	// https://portal.identitycenter.{Region}.myhuaweicloud.com
+	PortalUrl string
+	// CacheFile is the path of the cached token, defaults to ~/.hcloud/sso/cache/{sha1 of StartUrl}.json
+	CacheFile string
+}
+
+type ssoCachedToken struct {
+	AccessToken string `json:"accessToken"`
+	ExpiresAt   string `json:"expiresAt"`
+}
+
+type ssoRoleCredentials struct {
+	RoleCredentials struct {
+		AccessKeyId     string `json:"access_key_id"`
+		SecretAccessKey string `json:"secret_access_key"`
+		SessionToken    string `json:"session_token"`
+		// the expiration time in milliseconds
+		Expiration int64 `json:"expiration"`
+	} `json:"role_credentials"`
+}
+
+// Retrieve exchanges the cached token for the temporary credentials of the account.
+func (p *SSOCredentialProvider) Retrieve() (*Credentials, error) {
+	if p.StartUrl == "" || p.Region == "" || p.AccountId == "" || p.AgencyUrn == "" {
+		return nil, fmt.Errorf("ssoStartUrl, ssoRegion, ssoAccountId and ssoAgencyUrn must be specified in SSO mode")
+	}
+
+	token, err := p.loadCachedToken()
+	if err != nil {
+		return nil, err
+	}
+
+	portalUrl := p.PortalUrl
+	if portalUrl == "" {
+		portalUrl = fmt.Sprintf("https://portal.identitycenter.%s.myhuaweicloud.com", p.Region)
+	}
+	query := url.Values{}
+	query.Set("account_id", p.AccountId)
+	query.Set("agency_urn", p.AgencyUrn)
+	requestUrl := fmt.Sprintf("%s/v1/federation/credentials?%s", strings.TrimSuffix(portalUrl, "/"), query.Encode())
+
+	req, err := http.NewRequest("GET", requestUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building Identity Center request: %s", err)
+	}
+	req.Header.Set("X-Security-Token", token)
+
+	httpClient := &http.Client{Timeout: ssoRequestTimeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting Identity Center portal: %s", err)
+	}
+	defer resp.Body.Close()
+
+	rawBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Identity Center response: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error requesting Identity Center portal: status code = %d, %s", resp.StatusCode,
+			string(rawBody))
+	}
+
+	var output ssoRoleCredentials
+	if err := json.Unmarshal(rawBody, &output); err != nil {
+		return nil, fmt.Errorf("error parsing Identity Center response: %s", err)
+	}
+	roleCredentials := output.RoleCredentials
+	if roleCredentials.AccessKeyId == "" || roleCredentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("the credentials are not found in the Identity Center response")
+	}
+
+	credentials := Credentials{
+		AccessKey:     roleCredentials.AccessKeyId,
+		SecretKey:     roleCredentials.SecretAccessKey,
+		SecurityToken: roleCredentials.SessionToken,
+	}
+	if roleCredentials.Expiration > 0 {
+		credentials.ExpiresAt = time.UnixMilli(roleCredentials.Expiration)
+	}
+	return &credentials, nil
+}
+
+func (p *SSOCredentialProvider) String() string {
+	return fmt.Sprintf("IAM Identity Center %s", p.StartUrl)
+}
+
+func (p *SSOCredentialProvider) loadCachedToken() (string, error) {
+	cacheFile := p.CacheFile
+	if cacheFile == "" {
+		hash := sha1.Sum([]byte(p.StartUrl)) //nolint:gosec // the hash is only used to name the cache file
+		cacheFile = filepath.Join(ssoCacheDir, hex.EncodeToString(hash[:])+".json")
+	}
+
+	cachePath, err := homedir.Expand(cacheFile)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return "", fmt.Errorf("error reading the cached SSO token, please login to IAM Identity Center with the CLI: %s",
+			err)
+	}
+
+	var token ssoCachedToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return "", fmt.Errorf("error parsing the cached SSO token %s: %s", cachePath, err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("the access token is not found in the cached SSO token %s", cachePath)
+	}
+
+	if token.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, token.ExpiresAt)
+		if err != nil {
+			return "", fmt.Errorf("error parsing the expiration time of the cached SSO token: %s", err)
+		}
+		if time.Now().After(expiresAt) {
+			return "", fmt.Errorf("the cached SSO token has expired at %s, please login to IAM Identity Center again",
+				expiresAt)
+		}
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+var (
+	uuidSegmentRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}$`)
+	hexSegmentRegexp  = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+	numSegmentRegexp  = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// TraceRecord is the structured record of an API call which is appended to the trace file.
+type TraceRecord struct {
+	Time       string `json:"time"`
+	Service    string `json:"service"`
+	Method     string `json:"method"`
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code,omitempty"`
+	LatencyMs  int64  `json:"latency_ms"`
+	RequestID  string `json:"request_id,omitempty"`
+	Retries    int    `json:"retries"`
+	Error      string `json:"error,omitempty"`
+}
+
+var (
+	// openTracers holds the tracers which are not closed, they will be closed when the provider stops
+	openTracers   = make(map[*Tracer]struct{})
+	openTracersMu sync.Mutex
+)
+
+// Tracer appends one JSON record per API call to the trace file, which is used to audit the cost and latency of
+// the API calls.
+type Tracer struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewTracer creates a Tracer which appends the records to the specified file, nil will be returned if the path is empty.
+func NewTracer(path string) (*Tracer, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	tracePath, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(tracePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening the trace file %s: %s", tracePath, err)
+	}
+
+	log.Printf("[DEBUG] the API calls will be traced in %s", tracePath)
+	tracer := &Tracer{file: file}
+
+	openTracersMu.Lock()
+	openTracers[tracer] = struct{}{}
+	openTracersMu.Unlock()
+	return tracer, nil
+}
+
+// Close closes the trace file, the records after closing are dropped.
+func (t *Tracer) Close() error {
+	if t == nil {
+		return nil
+	}
+
+	openTracersMu.Lock()
+	delete(openTracers, t)
+	openTracersMu.Unlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.file == nil {
+		return nil
+	}
+
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// CloseTracers closes the trace files of all the configured providers, it should be called when the provider stops.
+func CloseTracers() {
+	openTracersMu.Lock()
+	tracers := make([]*Tracer, 0, len(openTracers))
+	for tracer := range openTracers {
+		tracers = append(tracers, tracer)
+	}
+	openTracersMu.Unlock()
+
+	for _, tracer := range tracers {
+		if err := tracer.Close(); err != nil {
+			log.Printf("[WARN] failed to close the trace file: %s", err)
+		}
+	}
+}
+
+// Record appends the record to the trace file, the errors are only logged to avoid breaking the API calls.
+func (t *Tracer) Record(record *TraceRecord) {
+	if t == nil || record == nil {
+		return
+	}
+
+	if record.Time == "" {
+		record.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		log.Printf("[WARN] failed to marshal the trace record: %s", err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.file == nil {
+		return
+	}
+	if _, err := t.file.Write(append(b, '\n')); err != nil {
+		log.Printf("[WARN] failed to write the trace record: %s", err)
+	}
+}
+
+// newTraceRecord builds a record of the request URL, the query string is dropped.
+func newTraceRecord(method string, u *url.URL) *TraceRecord {
+	record := TraceRecord{
+		Method: method,
+	}
+	if u != nil {
+		record.Service = getServiceNameByHost(u.Host)
+		record.URL = buildURLTemplate(u.Host, u.Path)
+	}
+	return &record
+}
+
+// buildURLTemplate replaces the IDs in the URL path with placeholders, so the records of the same API can be
+// aggregated, e.g. ecs.region.myhuaweicloud.com/v1/{id}/cloudservers/{id}
+func buildURLTemplate(host, path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if uuidSegmentRegexp.MatchString(seg) || hexSegmentRegexp.MatchString(seg) || numSegmentRegexp.MatchString(seg) {
+			segments[i] = "{id}"
+		}
+	}
+	return host + strings.Join(segments, "/")
+}
+
+// getServiceNameByHost returns the first part of host which likes {Name}.{Region}.{Cloud}
+func getServiceNameByHost(host string) string {
+	if index := strings.Index(host, "."); index > 0 {
+		return host[:index]
+	}
+	return host
+}
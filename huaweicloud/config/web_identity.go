@@ -0,0 +1,236 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	createTokenWithIdTokenPath = "v3.0/OS-AUTH/id-token/tokens"
+	createSecurityTokenPath    = "v3.0/OS-CREDENTIAL/securitytokens"
+	webIdentityRequestTimeout  = 30 * time.Second
+
+	// the default and valid range of the duration of the temporary credentials exchanged by the ID token
+	defaultWebIdentityDuration = 60 * 60
+	minWebIdentityDuration     = 15 * 60
+	maxWebIdentityDuration     = 24 * 60 * 60
+)
+
+// WebIdentity is the configuration of the OIDC federated authentication, the ID token (JWT) issued by the OIDC
+// identity provider is exchanged for the temporary credentials.
+type WebIdentity struct {
+	// IdpId is the name of the identity provider which is created with the oidc protocol
+	IdpId string
+	// TokenFile is the path of the file which contains the ID token, the file is read again on each retrieval so that
+	// the token rotated by the CI runners can be picked up
+	TokenFile string
+	// Duration is the validity period of the temporary credentials in seconds, defaults to 1 hour
+	Duration int
+}
+
+// WebIdentityCredentialProvider exchanges the ID token for a federated token via IAM, then creates the temporary
+// credentials by the federated token.
+type WebIdentityCredentialProvider struct {
+	WebIdentity
+	config *Config
+}
+
+type webIdentityScope struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Retrieve returns the temporary credentials exchanged by the ID token. The web identity is configured explicitly, so
+// the failures are not hidden by the next providers of the chain.
+func (p *WebIdentityCredentialProvider) Retrieve() (*Credentials, error) {
+	credentials, err := p.retrieve()
+	if err != nil {
+		return nil, &configuredCredentialError{err: err}
+	}
+	return credentials, nil
+}
+
+func (p *WebIdentityCredentialProvider) retrieve() (*Credentials, error) {
+	if p.IdpId == "" || p.TokenFile == "" {
+		return nil, fmt.Errorf("idp_id and token_file must be specified")
+	}
+
+	tokenPath, err := homedir.Expand(p.TokenFile)
+	if err != nil {
+		return nil, err
+	}
+	idToken, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the ID token file: %s", err)
+	}
+
+	httpClient, err := newIdentityHTTPClient(p.config)
+	if err != nil {
+		return nil, err
+	}
+	iamEndpoint := GetServiceEndpoint(p.config, "iam", p.config.Region)
+	if !strings.HasSuffix(iamEndpoint, "/") {
+		iamEndpoint += "/"
+	}
+
+	federatedToken, err := p.createTokenWithIdToken(httpClient, iamEndpoint, strings.TrimSpace(string(idToken)))
+	if err != nil {
+		return nil, err
+	}
+	return p.createSecurityToken(httpClient, iamEndpoint, federatedToken)
+}
+
+func (p *WebIdentityCredentialProvider) String() string {
+	return fmt.Sprintf("web identity of identity provider %s", p.IdpId)
+}
+
+// createTokenWithIdToken returns the federated token which is scoped to the domain or the project of region.
+func (p *WebIdentityCredentialProvider) createTokenWithIdToken(httpClient *http.Client, iamEndpoint,
+	idToken string) (string, error) {
+	scope := make(map[string]interface{})
+	switch {
+	case p.config.DomainID != "":
+		scope["domain"] = webIdentityScope{Id: p.config.DomainID}
+	case p.config.DomainName != "":
+		scope["domain"] = webIdentityScope{Name: p.config.DomainName}
+	case p.config.TenantID != "":
+		scope["project"] = webIdentityScope{Id: p.config.TenantID}
+	default:
+		scope["project"] = webIdentityScope{Name: p.config.Region}
+	}
+
+	body := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"id_token": map[string]interface{}{
+				"id": idToken,
+			},
+			"scope": scope,
+		},
+	}
+	headers := map[string]string{
+		"X-Idp-Id": p.IdpId,
+	}
+
+	resp, _, err := doIdentityRequest(httpClient, iamEndpoint+createTokenWithIdTokenPath, headers, body)
+	if err != nil {
+		return "", fmt.Errorf("error creating the federated token with ID token: %s", err)
+	}
+
+	federatedToken := resp.Header.Get("X-Subject-Token")
+	if federatedToken == "" {
+		return "", fmt.Errorf("error creating the federated token with ID token: X-Subject-Token is not found")
+	}
+	return federatedToken, nil
+}
+
+func (p *WebIdentityCredentialProvider) createSecurityToken(httpClient *http.Client, iamEndpoint,
+	federatedToken string) (*Credentials, error) {
+	duration := defaultWebIdentityDuration
+	if p.Duration != 0 {
+		duration = p.Duration
+	}
+
+	body := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"token"},
+				"token": map[string]interface{}{
+					"id":               federatedToken,
+					"duration_seconds": duration,
+				},
+			},
+		},
+	}
+	headers := map[string]string{
+		"X-Auth-Token": federatedToken,
+	}
+
+	_, rawBody, err := doIdentityRequest(httpClient, iamEndpoint+createSecurityTokenPath, headers, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the temporary credentials with federated token: %s", err)
+	}
+
+	var output struct {
+		Credential processCredentials `json:"credential"`
+	}
+	if err := json.Unmarshal(rawBody, &output); err != nil {
+		return nil, fmt.Errorf("error parsing the temporary credentials: %s", err)
+	}
+	if output.Credential.Access == "" || output.Credential.Secret == "" {
+		return nil, fmt.Errorf("the temporary credentials are not found in the response")
+	}
+
+	credentials := Credentials{
+		AccessKey:     output.Credential.Access,
+		SecretKey:     output.Credential.Secret,
+		SecurityToken: output.Credential.SecurityToken,
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, output.Credential.ExpiresAt); err == nil {
+		credentials.ExpiresAt = expiresAt
+	}
+	return &credentials, nil
+}
+
+// newIdentityHTTPClient creates an HTTP client to send the unsigned requests to IAM, the TLS configurations, debug
+// logs and retries are the same as the service clients.
+func newIdentityHTTPClient(c *Config) (*http.Client, error) {
+	tlsConfig, err := generateTLSConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := http.Client{
+		Timeout: webIdentityRequestTimeout,
+		Transport: &LogRoundTripper{
+			Rt: wrapCassette(c, &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			}),
+			MaxRetries:  c.MaxRetries,
+			RetryPolicy: c.RetryPolicy,
+			Tracer:      c.Tracer,
+			Redactor:    c.Redactor,
+		},
+	}
+	return &httpClient, nil
+}
+
+func doIdentityRequest(httpClient *http.Client, url string, headers map[string]string,
+	body interface{}) (*http.Response, []byte, error) {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json;charset=utf8")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	rawBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, nil, fmt.Errorf("status code = %d, %s", resp.StatusCode, string(rawBody))
+	}
+	return resp, rawBody, nil
+}
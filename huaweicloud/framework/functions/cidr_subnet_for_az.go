@@ -0,0 +1,136 @@
+package functions
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var cidrSubnetAttrTypes = map[string]attr.Type{
+	"cidr":       types.StringType,
+	"gateway_ip": types.StringType,
+}
+
+type cidrSubnet struct {
+	Cidr      string `tfsdk:"cidr"`
+	GatewayIP string `tfsdk:"gateway_ip"`
+}
+
+var _ function.Function = &cidrSubnetForAZFunction{}
+
+type cidrSubnetForAZFunction struct{}
+
+// NewCidrSubnetForAZFunction returns the function which allocates a subnet CIDR of the VPC for an availability zone.
+func NewCidrSubnetForAZFunction() function.Function {
+	return &cidrSubnetForAZFunction{}
+}
+
+func (*cidrSubnetForAZFunction) Metadata(_ context.Context, _ function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "cidr_subnet_for_az"
+}
+
+func (*cidrSubnetForAZFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Allocate a subnet CIDR of the VPC for an availability zone",
+		Description: "Allocates the subnet CIDR of the VPC CIDR by the letter suffix of the availability zone, " +
+			"e.g. the network number of cn-north-4a is 0 and cn-north-4b is 1. It returns an object with the cidr " +
+			"and the gateway_ip (the first usable address), which are required by huaweicloud_vpc_subnet.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "vpc_cidr",
+				Description: "The IPv4 CIDR of the VPC, e.g. 192.168.0.0/16.",
+			},
+			function.Int64Parameter{
+				Name:        "newbits",
+				Description: "The number of additional bits with which to extend the prefix of the VPC CIDR.",
+			},
+			function.StringParameter{
+				Name:        "availability_zone",
+				Description: "The name of the availability zone, e.g. cn-north-4a.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: cidrSubnetAttrTypes,
+		},
+	}
+}
+
+func (*cidrSubnetForAZFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		vpcCidr, az string
+		newbits     int64
+	)
+	resp.Error = req.Arguments.Get(ctx, &vpcCidr, &newbits, &az)
+	if resp.Error != nil {
+		return
+	}
+
+	netnum, err := availabilityZoneIndex(az)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+	subnet, err := cidrSubnetWithGateway(vpcCidr, int(newbits), netnum)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, subnet)
+}
+
+// availabilityZoneIndex returns the index of the availability zone by the letter suffix, e.g. 0 of cn-north-4a.
+func availabilityZoneIndex(az string) (int, error) {
+	name := strings.ToLower(strings.TrimSpace(az))
+	if name == "" {
+		return 0, fmt.Errorf("the availability zone cannot be empty")
+	}
+	suffix := name[len(name)-1]
+	if suffix < 'a' || suffix > 'z' {
+		return 0, fmt.Errorf("the availability zone (%s) does not end with a letter", az)
+	}
+	return int(suffix - 'a'), nil
+}
+
+// cidrSubnetWithGateway calculates the subnet CIDR like the built-in function cidrsubnet, only IPv4 is supported
+// because the subnet CIDR of the VPC is always IPv4.
+func cidrSubnetWithGateway(vpcCidr string, newbits, netnum int) (*cidrSubnet, error) {
+	prefix, err := netip.ParsePrefix(vpcCidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VPC CIDR (%s): %s", vpcCidr, err)
+	}
+	if !prefix.Addr().Is4() {
+		return nil, fmt.Errorf("the VPC CIDR (%s) must be an IPv4 CIDR", vpcCidr)
+	}
+	if newbits < 1 {
+		return nil, fmt.Errorf("the newbits (%d) must be greater than 0", newbits)
+	}
+
+	bits := prefix.Bits() + newbits
+	// at least the network address, the gateway and the broadcast address are in the subnet
+	if bits > 30 {
+		return nil, fmt.Errorf("the subnet prefix length (%d) is greater than 30, please reduce the newbits", bits)
+	}
+	if netnum >= 1<<newbits {
+		return nil, fmt.Errorf("there are only %d subnets with %d newbits, the network number %d is out of range",
+			1<<newbits, newbits, netnum)
+	}
+
+	base := prefix.Masked().Addr().As4()
+	network := binary.BigEndian.Uint32(base[:]) | uint32(netnum)<<(32-bits)
+
+	var subnetAddr, gatewayAddr [4]byte
+	binary.BigEndian.PutUint32(subnetAddr[:], network)
+	binary.BigEndian.PutUint32(gatewayAddr[:], network+1)
+	return &cidrSubnet{
+		Cidr:      netip.PrefixFrom(netip.AddrFrom4(subnetAddr), bits).String(),
+		GatewayIP: netip.AddrFrom4(gatewayAddr).String(),
+	}, nil
+}
@@ -0,0 +1,235 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	definitionResp := &function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, definitionResp)
+	if definitionResp.Diagnostics.HasError() {
+		t.Fatalf("invalid function definition: %v", definitionResp.Diagnostics)
+	}
+
+	result, funcErr := definitionResp.Definition.Return.NewResultData(context.Background())
+	if funcErr != nil {
+		t.Fatalf("error creating the result data: %s", funcErr)
+	}
+	resp := &function.RunResponse{
+		Result: result,
+	}
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData(args),
+	}
+	f.Run(context.Background(), req, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestParseResourceIDFunction(t *testing.T) {
+	cases := []struct {
+		resourceType string
+		id           string
+		expected     map[string]string
+	}{
+		{
+			resourceType: "huaweicloud_rds_mysql_database",
+			id:           "instance-id/db_name",
+			expected:     map[string]string{"instance_id": "instance-id", "name": "db_name"},
+		},
+		{
+			resourceType: "huaweicloud_dli_package",
+			id:           "group/package.jar",
+			expected:     map[string]string{"group_name": "group", "object_name": "package.jar"},
+		},
+		{
+			resourceType: "huaweicloud_sfs_turbo_dir",
+			id:           "share-id/temp/subdir",
+			expected:     map[string]string{"share_id": "share-id", "path": "/temp/subdir"},
+		},
+	}
+
+	for _, tc := range cases {
+		result, err := runFunction(t, NewParseResourceIDFunction(), types.StringValue(tc.resourceType),
+			types.StringValue(tc.id))
+		if err != nil {
+			t.Fatalf("error parsing the ID (%s): %s", tc.id, err)
+		}
+		expected, _ := types.MapValueFrom(context.Background(), types.StringType, tc.expected)
+		if !result.Equal(expected) {
+			t.Errorf("the parsed ID (%s) is not as expected, want %s, but got %s", tc.id, expected, result)
+		}
+	}
+}
+
+func TestParseResourceIDFunction_invalid(t *testing.T) {
+	cases := [][2]string{
+		{"huaweicloud_vpc", "vpc-id"},
+		{"huaweicloud_rds_mysql_account", "instance-id"},
+		{"huaweicloud_rds_mysql_account", "instance-id/"},
+		{"huaweicloud_sfs_turbo_dir_quota", "share-id//"},
+	}
+
+	for _, tc := range cases {
+		_, err := runFunction(t, NewParseResourceIDFunction(), types.StringValue(tc[0]), types.StringValue(tc[1]))
+		if err == nil {
+			t.Errorf("expected an error of parsing the ID (%s) of %s, but got nil", tc[1], tc[0])
+		}
+	}
+}
+
+func TestBuildResourceIDFunction(t *testing.T) {
+	cases := []struct {
+		resourceType string
+		parts        map[string]string
+		expected     string
+		hasError     bool
+	}{
+		{
+			resourceType: "huaweicloud_dli_table",
+			parts:        map[string]string{"database_name": "db", "name": "table"},
+			expected:     "db/table",
+		},
+		{
+			resourceType: "huaweicloud_sfs_turbo_dir_quota",
+			parts:        map[string]string{"share_id": "share-id", "path": "/temp/subdir"},
+			expected:     "share-id/temp/subdir",
+		},
+		{
+			resourceType: "huaweicloud_rds_pg_account",
+			parts:        map[string]string{"instance_id": "instance-id"},
+			hasError:     true,
+		},
+		{
+			resourceType: "huaweicloud_rds_pg_account",
+			parts:        map[string]string{"instance_id": "instance/id", "name": "user"},
+			hasError:     true,
+		},
+		{
+			resourceType: "huaweicloud_rds_pg_account",
+			parts:        map[string]string{"instance_id": "instance-id", "name": "user", "db_name": "db"},
+			hasError:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		parts, _ := types.MapValueFrom(context.Background(), types.StringType, tc.parts)
+		result, err := runFunction(t, NewBuildResourceIDFunction(), types.StringValue(tc.resourceType), parts)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("expected an error of building the ID of %v, but got %s", tc.parts, result)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("error building the ID of %v: %s", tc.parts, err)
+		}
+		if !result.Equal(types.StringValue(tc.expected)) {
+			t.Errorf("the built ID is not as expected, want %s, but got %s", tc.expected, result)
+		}
+	}
+}
+
+func TestObsBucketEndpointFunction(t *testing.T) {
+	cases := []struct {
+		clouds   []attr.Value
+		expected string
+	}{
+		{
+			expected: "bucket.obs.cn-north-4.myhuaweicloud.com",
+		},
+		{
+			clouds:   []attr.Value{types.StringValue("example.com")},
+			expected: "bucket.obs.cn-north-4.example.com",
+		},
+	}
+
+	for _, tc := range cases {
+		result, err := runFunction(t, NewObsBucketEndpointFunction(), types.StringValue("bucket"),
+			types.StringValue("cn-north-4"), types.TupleValueMust(stringTypes(len(tc.clouds)), tc.clouds))
+		if err != nil {
+			t.Fatalf("error computing the bucket endpoint: %s", err)
+		}
+		if !result.Equal(types.StringValue(tc.expected)) {
+			t.Errorf("the bucket endpoint is not as expected, want %s, but got %s", tc.expected, result)
+		}
+	}
+}
+
+// stringTypes returns the element types of the variadic string arguments, which are passed as a tuple.
+func stringTypes(count int) []attr.Type {
+	result := make([]attr.Type, count)
+	for i := range result {
+		result[i] = types.StringType
+	}
+	return result
+}
+
+func TestCidrSubnetForAZFunction(t *testing.T) {
+	cases := []struct {
+		vpcCidr  string
+		newbits  int64
+		az       string
+		expected cidrSubnet
+		hasError bool
+	}{
+		{
+			vpcCidr:  "192.168.0.0/16",
+			newbits:  8,
+			az:       "cn-north-4a",
+			expected: cidrSubnet{Cidr: "192.168.0.0/24", GatewayIP: "192.168.0.1"},
+		},
+		{
+			vpcCidr:  "172.16.0.0/12",
+			newbits:  8,
+			az:       "cn-north-4c",
+			expected: cidrSubnet{Cidr: "172.16.32.0/20", GatewayIP: "172.16.32.1"},
+		},
+		{
+			vpcCidr:  "10.0.0.0/8",
+			newbits:  1,
+			az:       "cn-north-4c",
+			hasError: true,
+		},
+		{
+			vpcCidr:  "192.168.0.0/24",
+			newbits:  8,
+			az:       "cn-north-4a",
+			hasError: true,
+		},
+		{
+			vpcCidr:  "192.168.0.0/16",
+			newbits:  8,
+			az:       "cn-north-4",
+			hasError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		result, err := runFunction(t, NewCidrSubnetForAZFunction(), types.StringValue(tc.vpcCidr),
+			types.Int64Value(tc.newbits), types.StringValue(tc.az))
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("expected an error of allocating the subnet of %s for %s, but got %s", tc.vpcCidr, tc.az,
+					result)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("error allocating the subnet of %s for %s: %s", tc.vpcCidr, tc.az, err)
+		}
+		expected := types.ObjectValueMust(cidrSubnetAttrTypes, map[string]attr.Value{
+			"cidr":       types.StringValue(tc.expected.Cidr),
+			"gateway_ip": types.StringValue(tc.expected.GatewayIP),
+		})
+		if !result.Equal(expected) {
+			t.Errorf("the subnet is not as expected, want %s, but got %s", expected, result)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/obs"
+)
+
+// defaultCloud is the same as the default value of the provider argument cloud, the functions cannot access the
+// provider configuration.
+const defaultCloud = "myhuaweicloud.com"
+
+var _ function.Function = &obsBucketEndpointFunction{}
+
+type obsBucketEndpointFunction struct{}
+
+// NewObsBucketEndpointFunction returns the function which computes the domain name of the OBS bucket.
+func NewObsBucketEndpointFunction() function.Function {
+	return &obsBucketEndpointFunction{}
+}
+
+func (*obsBucketEndpointFunction) Metadata(_ context.Context, _ function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "obs_bucket_endpoint"
+}
+
+func (*obsBucketEndpointFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute the domain name of the OBS bucket",
+		Description: "Returns the domain name of the OBS bucket in the format of {bucket}.obs.{region}.{cloud}, " +
+			"which is the same as the bucket_domain_name of huaweicloud_obs_bucket.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "bucket",
+				Description: "The name of the OBS bucket.",
+			},
+			function.StringParameter{
+				Name:        "region",
+				Description: "The region where the OBS bucket is located.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "cloud",
+			Description: "The endpoint of the cloud provider, defaults to myhuaweicloud.com.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (*obsBucketEndpointFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		bucket, region string
+		clouds         []string
+	)
+	resp.Error = req.Arguments.Get(ctx, &bucket, &region, &clouds)
+	if resp.Error != nil {
+		return
+	}
+
+	if bucket == "" {
+		resp.Error = function.NewArgumentFuncError(0, "the bucket name cannot be empty")
+		return
+	}
+	if region == "" {
+		resp.Error = function.NewArgumentFuncError(1, "the region cannot be empty")
+		return
+	}
+
+	cloud := defaultCloud
+	switch len(clouds) {
+	case 0:
+	case 1:
+		if clouds[0] != "" {
+			cloud = clouds[0]
+		}
+	default:
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("at most one cloud can be specified, "+
+			"but got %d", len(clouds)))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, obs.BucketDomainNameWithCloud(bucket, region, cloud))
+}
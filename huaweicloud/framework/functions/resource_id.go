@@ -0,0 +1,227 @@
+// Package functions implements the provider-defined functions, which are available in Terraform 1.8 and later.
+package functions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const resourceIDSeparator = "/"
+
+// resourceIDFormat describes a composite ID which is joined by the resource IDs or names with slashes.
+type resourceIDFormat struct {
+	// the names of the ID parts, they are the same as the argument names of the resource
+	Parts []string
+	// whether the last part is a path which starts with a slash, e.g. the SFS Turbo directory
+	PathSuffix bool
+}
+
+// resourceIDFormats are the composite IDs which are constructed by the resources, the IDs of the SFS Turbo resources
+// are the import IDs, because the resource IDs of them are the paths or rule IDs only.
+var resourceIDFormats = map[string]resourceIDFormat{
+	"huaweicloud_rds_mysql_account":                {Parts: []string{"instance_id", "name"}},
+	"huaweicloud_rds_mysql_database":               {Parts: []string{"instance_id", "name"}},
+	"huaweicloud_rds_mysql_database_privilege":     {Parts: []string{"instance_id", "db_name"}},
+	"huaweicloud_rds_pg_account":                   {Parts: []string{"instance_id", "name"}},
+	"huaweicloud_rds_pg_database":                  {Parts: []string{"instance_id", "name"}},
+	"huaweicloud_rds_sqlserver_account":            {Parts: []string{"instance_id", "name"}},
+	"huaweicloud_rds_sqlserver_database":           {Parts: []string{"instance_id", "name"}},
+	"huaweicloud_rds_sqlserver_database_privilege": {Parts: []string{"instance_id", "db_name"}},
+	"huaweicloud_dli_table":                        {Parts: []string{"database_name", "name"}},
+	"huaweicloud_dli_permission":                   {Parts: []string{"object", "user_name"}},
+	"huaweicloud_dli_package":                      {Parts: []string{"group_name", "object_name"}},
+	"huaweicloud_sfs_turbo_dir":                    {Parts: []string{"share_id", "path"}, PathSuffix: true},
+	"huaweicloud_sfs_turbo_dir_quota":              {Parts: []string{"share_id", "path"}, PathSuffix: true},
+	"huaweicloud_sfs_turbo_perm_rule":              {Parts: []string{"share_id", "id"}},
+}
+
+func supportedResourceTypes() string {
+	resourceTypes := make([]string, 0, len(resourceIDFormats))
+	for k := range resourceIDFormats {
+		resourceTypes = append(resourceTypes, k)
+	}
+	sort.Strings(resourceTypes)
+	return strings.Join(resourceTypes, ", ")
+}
+
+func getResourceIDFormat(resourceType string) (resourceIDFormat, *function.FuncError) {
+	format, ok := resourceIDFormats[resourceType]
+	if !ok {
+		return format, function.NewArgumentFuncError(0, fmt.Sprintf("unsupported resource type '%s', the supported "+
+			"types are: %s", resourceType, supportedResourceTypes()))
+	}
+	return format, nil
+}
+
+// parseResourceID splits the ID by the format, the last part keeps the rest of the ID.
+func parseResourceID(format resourceIDFormat, id string) (map[string]string, error) {
+	partNames := format.Parts
+	parts := strings.SplitN(id, resourceIDSeparator, len(partNames))
+	if len(parts) != len(partNames) {
+		return nil, fmt.Errorf("invalid format of the ID, want '{%s}', but got '%s'",
+			strings.Join(partNames, "}/{"), id)
+	}
+
+	result := make(map[string]string, len(parts))
+	for i, v := range parts {
+		if format.PathSuffix && i == len(parts)-1 {
+			v = strings.Trim(v, resourceIDSeparator)
+			if v != "" {
+				v = resourceIDSeparator + v
+			}
+		}
+		if v == "" {
+			return nil, fmt.Errorf("invalid format of the ID, the %s is empty in '%s'", partNames[i], id)
+		}
+		result[partNames[i]] = v
+	}
+	return result, nil
+}
+
+// buildResourceID joins the parts by the format, only the last part can contain the separator.
+func buildResourceID(format resourceIDFormat, parts map[string]string) (string, error) {
+	partNames := format.Parts
+	values := make([]string, len(partNames))
+	for i, name := range partNames {
+		v, ok := parts[name]
+		if !ok || v == "" {
+			return "", fmt.Errorf("the %s is missing, want parts: %s", name, strings.Join(partNames, ", "))
+		}
+		if i == len(partNames)-1 {
+			if format.PathSuffix {
+				v = strings.TrimPrefix(v, resourceIDSeparator)
+			}
+		} else if strings.Contains(v, resourceIDSeparator) {
+			return "", fmt.Errorf("the %s (%s) cannot contain '%s'", name, v, resourceIDSeparator)
+		}
+		values[i] = v
+	}
+
+	for name := range parts {
+		if !utils.StrSliceContains(partNames, name) {
+			return "", fmt.Errorf("unexpected part %s, want parts: %s", name, strings.Join(partNames, ", "))
+		}
+	}
+	return strings.Join(values, resourceIDSeparator), nil
+}
+
+var _ function.Function = &parseResourceIDFunction{}
+
+type parseResourceIDFunction struct{}
+
+// NewParseResourceIDFunction returns the function which parses the composite ID of the resource into the parts.
+func NewParseResourceIDFunction() function.Function {
+	return &parseResourceIDFunction{}
+}
+
+func (*parseResourceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "parse_resource_id"
+}
+
+func (*parseResourceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse the composite ID of the resource",
+		Description: "Parses the composite ID of the resource into a map of the ID parts, the keys are the argument " +
+			"names of the resource, e.g. instance_id and name of huaweicloud_rds_mysql_database.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "The resource type, e.g. huaweicloud_rds_mysql_database.",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "The composite ID of the resource.",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (*parseResourceIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceType, id string
+	resp.Error = req.Arguments.Get(ctx, &resourceType, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	format, funcErr := getResourceIDFormat(resourceType)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	parts, err := parseResourceID(format, id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, parts)
+}
+
+var _ function.Function = &buildResourceIDFunction{}
+
+type buildResourceIDFunction struct{}
+
+// NewBuildResourceIDFunction returns the function which builds the composite ID of the resource from the parts.
+func NewBuildResourceIDFunction() function.Function {
+	return &buildResourceIDFunction{}
+}
+
+func (*buildResourceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "build_resource_id"
+}
+
+func (*buildResourceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the composite ID of the resource",
+		Description: "Builds the composite ID of the resource from a map of the ID parts, it is the reverse of " +
+			"parse_resource_id.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "The resource type, e.g. huaweicloud_rds_mysql_database.",
+			},
+			function.MapParameter{
+				Name:        "parts",
+				ElementType: types.StringType,
+				Description: "The ID parts, the keys are the argument names of the resource.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (*buildResourceIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		resourceType string
+		parts        map[string]string
+	)
+	resp.Error = req.Arguments.Get(ctx, &resourceType, &parts)
+	if resp.Error != nil {
+		return
+	}
+
+	format, funcErr := getResourceIDFormat(resourceType)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	id, err := buildResourceID(format, parts)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, id)
+}
@@ -0,0 +1,82 @@
+// Package framework implements the plugin-framework provider which is muxed with the SDK provider, it serves the
+// features which are not supported by the SDK, e.g. the ephemeral resources and the provider-defined functions.
+package framework
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/framework/functions"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dew"
+)
+
+var (
+	_ provider.ProviderWithEphemeralResources = &huaweicloudProvider{}
+	_ provider.ProviderWithFunctions          = &huaweicloudProvider{}
+)
+
+type huaweicloudProvider struct {
+	sdkProvider *schema.Provider
+}
+
+// New returns the plugin-framework provider, the provider configuration is parsed by the SDK provider, so the SDK
+// provider must be configured before this provider in the mux server.
+func New(sdkProvider *schema.Provider) provider.Provider {
+	return &huaweicloudProvider{
+		sdkProvider: sdkProvider,
+	}
+}
+
+func (*huaweicloudProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "huaweicloud"
+}
+
+func (p *huaweicloudProvider) Schema(ctx context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	s, err := providerSchema(ctx, p.sdkProvider)
+	if err != nil {
+		resp.Diagnostics.AddError("Error converting the provider schema", err.Error())
+		return
+	}
+	resp.Schema = s
+}
+
+// Configure shares the client configuration of the SDK provider with the ephemeral resources.
+func (p *huaweicloudProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	cfg, ok := p.sdkProvider.Meta().(*config.Config)
+	if !ok {
+		// the SDK provider is not configured, the ephemeral resources report the error when they are opened
+		return
+	}
+	resp.EphemeralResourceData = cfg
+}
+
+func (*huaweicloudProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (*huaweicloudProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (*huaweicloudProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		dew.EphemeralCsmsSecretVersion,
+		dew.EphemeralKmsDataKey,
+	}
+}
+
+func (*huaweicloudProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewParseResourceIDFunction,
+		functions.NewBuildResourceIDFunction,
+		functions.NewObsBucketEndpointFunction,
+		functions.NewCidrSubnetForAZFunction,
+	}
+}
@@ -0,0 +1,180 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deprecationMessage is used for the deprecated attributes, the protocol only keeps whether the attribute is
+// deprecated, and the SDK provider reports the message itself.
+const deprecationMessage = "Deprecated"
+
+// providerSchema converts the provider schema of the SDK provider to the plugin-framework schema, the muxed providers
+// must have the identical provider schemas.
+func providerSchema(ctx context.Context, sdkProvider *schema.Provider) (fwschema.Schema, error) {
+	resp, err := schema.NewGRPCProviderServer(sdkProvider).GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return fwschema.Schema{}, err
+	}
+	if resp.Provider == nil || resp.Provider.Block == nil {
+		return fwschema.Schema{}, fmt.Errorf("the provider schema of the SDK provider is empty")
+	}
+
+	block := resp.Provider.Block
+	attributes, blocks, err := convertBlock(block)
+	if err != nil {
+		return fwschema.Schema{}, err
+	}
+	result := fwschema.Schema{
+		Attributes:  attributes,
+		Blocks:      blocks,
+		Description: block.Description,
+	}
+	if block.Deprecated {
+		result.DeprecationMessage = deprecationMessage
+	}
+	return result, nil
+}
+
+func convertBlock(block *tfprotov5.SchemaBlock) (map[string]fwschema.Attribute, map[string]fwschema.Block, error) {
+	attributes := make(map[string]fwschema.Attribute, len(block.Attributes))
+	for _, v := range block.Attributes {
+		attribute, err := convertAttribute(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error converting the attribute %s: %s", v.Name, err)
+		}
+		attributes[v.Name] = attribute
+	}
+
+	blocks := make(map[string]fwschema.Block, len(block.BlockTypes))
+	for _, v := range block.BlockTypes {
+		nestedAttributes, nestedBlocks, err := convertBlock(v.Block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error converting the block %s: %s", v.TypeName, err)
+		}
+
+		object := fwschema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+		deprecation := ""
+		if v.Block.Deprecated {
+			deprecation = deprecationMessage
+		}
+		switch v.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			blocks[v.TypeName] = fwschema.ListNestedBlock{
+				NestedObject:       object,
+				Description:        v.Block.Description,
+				DeprecationMessage: deprecation,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[v.TypeName] = fwschema.SetNestedBlock{
+				NestedObject:       object,
+				Description:        v.Block.Description,
+				DeprecationMessage: deprecation,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			blocks[v.TypeName] = fwschema.SingleNestedBlock{
+				Attributes:         nestedAttributes,
+				Blocks:             nestedBlocks,
+				Description:        v.Block.Description,
+				DeprecationMessage: deprecation,
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported nesting mode %s of the block %s", v.Nesting, v.TypeName)
+		}
+	}
+	return attributes, blocks, nil
+}
+
+func convertAttribute(v *tfprotov5.SchemaAttribute) (fwschema.Attribute, error) {
+	deprecation := ""
+	if v.Deprecated {
+		deprecation = deprecationMessage
+	}
+
+	switch {
+	case v.Type.Is(tftypes.String):
+		return fwschema.StringAttribute{
+			Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive,
+			Description: v.Description, DeprecationMessage: deprecation,
+		}, nil
+	case v.Type.Is(tftypes.Bool):
+		return fwschema.BoolAttribute{
+			Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive,
+			Description: v.Description, DeprecationMessage: deprecation,
+		}, nil
+	case v.Type.Is(tftypes.Number):
+		return fwschema.NumberAttribute{
+			Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive,
+			Description: v.Description, DeprecationMessage: deprecation,
+		}, nil
+	}
+
+	attrType, err := convertType(v.Type)
+	if err != nil {
+		return nil, err
+	}
+	switch t := attrType.(type) {
+	case types.ListType:
+		return fwschema.ListAttribute{
+			ElementType: t.ElemType, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive,
+			Description: v.Description, DeprecationMessage: deprecation,
+		}, nil
+	case types.SetType:
+		return fwschema.SetAttribute{
+			ElementType: t.ElemType, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive,
+			Description: v.Description, DeprecationMessage: deprecation,
+		}, nil
+	case types.MapType:
+		return fwschema.MapAttribute{
+			ElementType: t.ElemType, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive,
+			Description: v.Description, DeprecationMessage: deprecation,
+		}, nil
+	case types.ObjectType:
+		return fwschema.ObjectAttribute{
+			AttributeTypes: t.AttrTypes, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive,
+			Description: v.Description, DeprecationMessage: deprecation,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", v.Type)
+}
+
+func convertType(t tftypes.Type) (attr.Type, error) {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	case t.Is(tftypes.List{}):
+		elemType, err := convertType(t.(tftypes.List).ElementType)
+		return types.ListType{ElemType: elemType}, err
+	case t.Is(tftypes.Set{}):
+		elemType, err := convertType(t.(tftypes.Set).ElementType)
+		return types.SetType{ElemType: elemType}, err
+	case t.Is(tftypes.Map{}):
+		elemType, err := convertType(t.(tftypes.Map).ElementType)
+		return types.MapType{ElemType: elemType}, err
+	case t.Is(tftypes.Object{}):
+		attrTypes := make(map[string]attr.Type)
+		for name, v := range t.(tftypes.Object).AttributeTypes {
+			attrType, err := convertType(v)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[name] = attrType
+		}
+		return types.ObjectType{AttrTypes: attrTypes}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
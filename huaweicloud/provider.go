@@ -2,16 +2,24 @@ package huaweicloud
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/framework"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/aad"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/antiddos"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/aom"
@@ -105,6 +113,7 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/vpn"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/waf"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/workspace"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
 const (
@@ -214,9 +223,9 @@ func Provider() *schema.Provider {
 			},
 
 			"assume_role": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["assume_role"],
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"agency_name": {
@@ -231,6 +240,44 @@ func Provider() *schema.Provider {
 							Description: descriptions["assume_role_domain_name"],
 							DefaultFunc: schema.EnvDefaultFunc("HW_ASSUME_ROLE_DOMAIN_NAME", nil),
 						},
+						"duration": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: descriptions["assume_role_duration"],
+						},
+						"session_user_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: descriptions["assume_role_session_user_name"],
+						},
+					},
+				},
+			},
+
+			"assume_role_with_web_identity": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["assume_role_with_web_identity"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"idp_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptions["web_identity_idp_id"],
+							DefaultFunc: schema.EnvDefaultFunc("HW_IDP_ID", nil),
+						},
+						"token_file": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptions["web_identity_token_file"],
+							DefaultFunc: schema.EnvDefaultFunc("HW_ID_TOKEN_FILE", nil),
+						},
+						"duration": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: descriptions["web_identity_duration"],
+						},
 					},
 				},
 			},
@@ -357,6 +404,27 @@ func Provider() *schema.Provider {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 
+			"region_endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["region_endpoints"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptions["region_endpoints_region"],
+						},
+						"endpoints": {
+							Type:        schema.TypeMap,
+							Required:    true,
+							Description: descriptions["region_endpoints_endpoints"],
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
 			"regional": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -377,6 +445,13 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("HW_PROFILE", ""),
 			},
 
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["credential_process"],
+				DefaultFunc: schema.EnvDefaultFunc("HW_CREDENTIAL_PROCESS", ""),
+			},
+
 			"enterprise_project_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -390,6 +465,125 @@ func Provider() *schema.Provider {
 				Description: descriptions["max_retries"],
 				DefaultFunc: schema.EnvDefaultFunc("HW_MAX_RETRIES", 5),
 			},
+
+			"retry_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"methods": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: descriptions["retry_policy_methods"],
+						},
+						"status_codes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: descriptions["retry_policy_status_codes"],
+						},
+					},
+				},
+			},
+
+			"trace_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["trace_file"],
+				DefaultFunc: schema.EnvDefaultFunc("HW_TF_TRACE_FILE", ""),
+			},
+
+			"discovery_cache_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["discovery_cache_file"],
+				DefaultFunc: schema.EnvDefaultFunc("HW_DISCOVERY_CACHE_FILE", ""),
+			},
+
+			"discovery_cache_ttl": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["discovery_cache_ttl"],
+				DefaultFunc: schema.EnvDefaultFunc("HW_DISCOVERY_CACHE_TTL", ""),
+			},
+
+			"sensitive_log_fields": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["sensitive_log_fields"],
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"rate_limits": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: descriptions["rate_limits"],
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"default_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: descriptions["default_tags_tags"],
+						},
+					},
+				},
+			},
+
+			"ignore_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: descriptions["ignore_tags_keys"],
+						},
+						"key_prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: descriptions["ignore_tags_key_prefixes"],
+						},
+					},
+				},
+			},
+
+			"preflight_policies": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["preflight_policies"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptions["preflight_policies_name"],
+						},
+						"parameters": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsJSON,
+							},
+							Description: descriptions["preflight_policies_parameters"],
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -606,6 +800,8 @@ func Provider() *schema.Provider {
 
 			"huaweicloud_rms_policy_definitions":           rms.DataSourcePolicyDefinitions(),
 			"huaweicloud_rms_assignment_package_templates": rms.DataSourceTemplates(),
+			"huaweicloud_rms_advanced_query_results":       rms.DataSourceAdvancedQueryResults(),
+			"huaweicloud_rms_resources":                    rms.DataSourceResources(),
 
 			"huaweicloud_sdrs_domain": sdrs.DataSourceSDRSDomain(),
 
@@ -990,10 +1186,11 @@ func Provider() *schema.Provider {
 			"huaweicloud_evs_snapshot": evs.ResourceEvsSnapshotV2(),
 			"huaweicloud_evs_volume":   evs.ResourceEvsVolume(),
 
-			"huaweicloud_fgs_async_invoke_configuration": fgs.ResourceAsyncInvokeConfiguration(),
-			"huaweicloud_fgs_dependency":                 fgs.ResourceFgsDependency(),
-			"huaweicloud_fgs_function":                   fgs.ResourceFgsFunctionV2(),
-			"huaweicloud_fgs_trigger":                    fgs.ResourceFunctionGraphTrigger(),
+			"huaweicloud_fgs_async_invoke_configuration":  fgs.ResourceAsyncInvokeConfiguration(),
+			"huaweicloud_fgs_dependency":                  fgs.ResourceFgsDependency(),
+			"huaweicloud_fgs_function":                    fgs.ResourceFgsFunctionV2(),
+			"huaweicloud_fgs_function_reserved_instances": fgs.ResourceFunctionReservedInstances(),
+			"huaweicloud_fgs_trigger":                     fgs.ResourceFunctionGraphTrigger(),
 
 			"huaweicloud_ga_accelerator":    ga.ResourceAccelerator(),
 			"huaweicloud_ga_listener":       ga.ResourceListener(),
@@ -1524,6 +1721,13 @@ func Provider() *schema.Provider {
 		},
 	}
 
+	// evaluate the provider-level preflight policies before the resources are changed
+	for _, resourceType := range rms.PreflightPolicyResourceTypes() {
+		if r, ok := provider.ResourcesMap[resourceType]; ok {
+			r.CustomizeDiff = appendCustomizeDiff(r.CustomizeDiff, rms.PreflightPolicyDiff(resourceType))
+		}
+	}
+
 	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
@@ -1538,6 +1742,57 @@ func Provider() *schema.Provider {
 	return provider
 }
 
+func appendCustomizeDiff(origin, diffFunc schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	if origin == nil {
+		return diffFunc
+	}
+	return customdiff.All(origin, diffFunc)
+}
+
+// ConfigureHook is used to modify the client configuration before it is loaded, e.g. recording the HTTP interactions
+// or sending the requests to a fake cloud in the offline tests.
+type ConfigureHook func(*config.Config)
+
+type configureHookKey struct{}
+
+// ProviderWithConfigureHook returns a provider of which the client configuration is modified by the hook.
+func ProviderWithConfigureHook(hook ConfigureHook) *schema.Provider {
+	provider := Provider()
+	configure := provider.ConfigureContextFunc
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return configure(context.WithValue(ctx, configureHookKey{}, hook), d)
+	}
+	return provider
+}
+
+// ProviderWithCassette returns a provider of which the HTTP interactions are recorded to or replayed from the
+// cassette, it is used to run the acceptance tests offline.
+func ProviderWithCassette(cassette *config.Cassette) *schema.Provider {
+	return ProviderWithConfigureHook(func(c *config.Config) {
+		c.Cassette = cassette
+		// the requests are not sent to the cloud in replay mode, so the credentials are not required
+		if cassette.Mode == config.CassetteModeReplay && c.AccessKey == "" && c.Token == "" && c.Password == "" {
+			c.AccessKey = "replay-access-key"
+			c.SecretKey = "replay-secret-key"
+		}
+	})
+}
+
+// NewMuxProviderServer returns the factory of the provider server which muxes the SDK provider and the
+// plugin-framework provider, the latter serves the ephemeral resources.
+func NewMuxProviderServer(ctx context.Context, sdkProvider *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	servers := []func() tfprotov5.ProviderServer{
+		// the SDK provider must be configured first, its client configuration is shared with the framework provider
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(framework.New(sdkProvider)),
+	}
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the mux provider server: %s", err)
+	}
+	return muxServer.ProviderServer, nil
+}
+
 var descriptions map[string]string
 
 func init() {
@@ -1588,9 +1843,30 @@ func init() {
 
 		"assume_role_domain_name": "The name of domain for assume role.",
 
+		"assume_role": "The ordered list of agencies to assume in a chain.",
+
+		"assume_role_duration": "The validity period in seconds of the temporary credentials for assume role.",
+
+		"assume_role_session_user_name": "The name of the session user for assume role.",
+
+		"assume_role_with_web_identity": "The configuration to exchange the OIDC ID token for temporary credentials.",
+
+		"web_identity_idp_id": "The name of the identity provider which is created with the oidc protocol.",
+
+		"web_identity_token_file": "The path of the file which contains the OIDC ID token.",
+
+		"web_identity_duration": "The validity period in seconds of the temporary credentials.",
+
 		"cloud": "The endpoint of cloud provider, defaults to myhuaweicloud.com",
 
-		"endpoints": "The custom endpoints used to override the default endpoint URL.",
+		"endpoints": "The custom endpoints used to override the default endpoint URL, " +
+			"the {region} and {project_id} placeholders are supported.",
+
+		"region_endpoints": "The custom endpoints of the specified region which take precedence over endpoints.",
+
+		"region_endpoints_region": "The region in which the custom endpoints are used.",
+
+		"region_endpoints_endpoints": "The custom endpoints used to override the default endpoint URL in the region.",
 
 		"regional": "Whether the service endpoints are regional",
 
@@ -1598,15 +1874,44 @@ func init() {
 
 		"profile": "The profile name as set in the shared config file.",
 
+		"credential_process": "The external command which prints the temporary credentials in JSON format.",
+
 		"max_retries": "How many times HTTP connection should be retried until giving up.",
 
+		"retry_policy_methods": "The HTTP methods whose server error responses will be retried.",
+
+		"retry_policy_status_codes": "The HTTP status codes of the server error responses which will be retried.",
+
+		"trace_file": "The path of the file which records the API calls in JSON format.",
+
+		"discovery_cache_file": "The path of the file which caches the discovered project IDs and domain ID between runs.",
+
+		"discovery_cache_ttl": "The time to live of the cached IDs, e.g. 12h. Defaults to 24h.",
+
+		"sensitive_log_fields": "The additional fields which need to be redacted in the debug logs.",
+
+		"rate_limits": "The maximum number of requests per second sent to each service on the client side.",
+
 		"enterprise_project_id": "enterprise project id",
+
+		"default_tags_tags": "The default tags which will be merged into the tags of every taggable resource.",
+
+		"ignore_tags_keys": "The tag keys which will be ignored when reading the tags of resources.",
+
+		"ignore_tags_key_prefixes": "The tag key prefixes which will be ignored when reading the tags of resources.",
+
+		"preflight_policies": "The local policies which are evaluated against the planned attributes of the resources.",
+
+		"preflight_policies_name": "The name of the preflight policy.",
+
+		"preflight_policies_parameters": "The parameters of the preflight policy, the values are in JSON format.",
 	}
 }
 
-func configureProvider(_ context.Context, d *schema.ResourceData, terraformVersion string) (interface{},
+func configureProvider(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{},
 	diag.Diagnostics) {
 	var tenantName, tenantID, delegatedProject, identityEndpoint string
+	hook, _ := ctx.Value(configureHookKey{}).(ConfigureHook)
 	region := d.Get("region").(string)
 	cloud := getCloudDomain(d.Get("cloud").(string), region)
 
@@ -1672,30 +1977,22 @@ func configureProvider(_ context.Context, d *schema.ResourceData, terraformVersi
 		Cloud:               cloud,
 		RegionClient:        isRegional,
 		MaxRetries:          d.Get("max_retries").(int),
+		TraceFile:           d.Get("trace_file").(string),
+		DiscoveryCacheFile:  d.Get("discovery_cache_file").(string),
+		SensitiveLogFields:  utils.ExpandToStringList(d.Get("sensitive_log_fields").([]interface{})),
 		EnterpriseProjectID: d.Get("enterprise_project_id").(string),
 		SharedConfigFile:    d.Get("shared_config_file").(string),
 		Profile:             d.Get("profile").(string),
+		CredentialProcess:   d.Get("credential_process").(string),
 		TerraformVersion:    terraformVersion,
 		RegionProjectIDMap:  make(map[string]string),
 		RPLock:              new(sync.Mutex),
 		SecurityKeyLock:     new(sync.Mutex),
 	}
 
-	// get assume role
-	assumeRoleList := d.Get("assume_role").([]interface{})
-	if len(assumeRoleList) == 0 {
-		// without assume_role block in provider
-		delegatedAgencyName := os.Getenv("HW_ASSUME_ROLE_AGENCY_NAME")
-		delegatedDomianName := os.Getenv("HW_ASSUME_ROLE_DOMAIN_NAME")
-		if delegatedAgencyName != "" && delegatedDomianName != "" {
-			config.AssumeRoleAgency = delegatedAgencyName
-			config.AssumeRoleDomain = delegatedDomianName
-		}
-	} else {
-		assumeRole := assumeRoleList[0].(map[string]interface{})
-		config.AssumeRoleAgency = assumeRole["agency_name"].(string)
-		config.AssumeRoleDomain = assumeRole["domain_name"].(string)
-	}
+	// get assume roles
+	config.AssumeRoles = flattenProviderAssumeRoles(d)
+	config.WebIdentity = flattenProviderWebIdentity(d)
 
 	// get custom endpoints
 	endpoints, err := flattenProviderEndpoints(d)
@@ -1704,6 +2001,45 @@ func configureProvider(_ context.Context, d *schema.ResourceData, terraformVersi
 	}
 	config.Endpoints = endpoints
 
+	regionEndpoints, err := flattenProviderRegionEndpoints(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	config.RegionEndpoints = regionEndpoints
+
+	// get retry policy of server error responses
+	config.RetryPolicy = flattenProviderRetryPolicy(d)
+
+	// get client-side rate limits
+	rateLimits := make(map[string]int)
+	for key, val := range d.Get("rate_limits").(map[string]interface{}) {
+		rateLimits[key] = val.(int)
+	}
+	config.RateLimits = rateLimits
+
+	// get the time to live of discovery cache
+	if v := d.Get("discovery_cache_ttl").(string); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl <= 0 {
+			return nil, diag.Errorf("invalid discovery_cache_ttl %q, it should be a positive duration, e.g. 12h", v)
+		}
+		config.DiscoveryCacheTTL = ttl
+	}
+
+	// get default tags and ignore tags
+	config.ProviderTags = flattenProviderTagsConfig(d)
+
+	// get preflight policies
+	preflightPolicies, err := flattenProviderPreflightPolicies(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	config.PreflightPolicies = preflightPolicies
+
+	if hook != nil {
+		hook(&config)
+	}
+
 	if err := config.LoadAndValidate(); err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -1711,8 +2047,103 @@ func configureProvider(_ context.Context, d *schema.ResourceData, terraformVersi
 	return &config, nil
 }
 
+func flattenProviderAssumeRoles(d *schema.ResourceData) []config.AssumeRole {
+	assumeRoleList := d.Get("assume_role").([]interface{})
+	if len(assumeRoleList) == 0 {
+		// without assume_role block in provider
+		delegatedAgencyName := os.Getenv("HW_ASSUME_ROLE_AGENCY_NAME")
+		delegatedDomianName := os.Getenv("HW_ASSUME_ROLE_DOMAIN_NAME")
+		if delegatedAgencyName != "" && delegatedDomianName != "" {
+			return []config.AssumeRole{
+				{
+					AgencyName: delegatedAgencyName,
+					DomainName: delegatedDomianName,
+				},
+			}
+		}
+		return nil
+	}
+
+	assumeRoles := make([]config.AssumeRole, 0, len(assumeRoleList))
+	for _, v := range assumeRoleList {
+		assumeRole, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		assumeRoles = append(assumeRoles, config.AssumeRole{
+			AgencyName:      assumeRole["agency_name"].(string),
+			DomainName:      assumeRole["domain_name"].(string),
+			Duration:        assumeRole["duration"].(int),
+			SessionUserName: assumeRole["session_user_name"].(string),
+		})
+	}
+	return assumeRoles
+}
+
+func flattenProviderWebIdentity(d *schema.ResourceData) *config.WebIdentity {
+	webIdentityList := d.Get("assume_role_with_web_identity").([]interface{})
+	if len(webIdentityList) == 0 {
+		// without assume_role_with_web_identity block in provider
+		idpID := os.Getenv("HW_IDP_ID")
+		tokenFile := os.Getenv("HW_ID_TOKEN_FILE")
+		if idpID != "" && tokenFile != "" {
+			return &config.WebIdentity{
+				IdpId:     idpID,
+				TokenFile: tokenFile,
+			}
+		}
+		return nil
+	}
+
+	webIdentity, ok := webIdentityList[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return &config.WebIdentity{
+		IdpId:     webIdentity["idp_id"].(string),
+		TokenFile: webIdentity["token_file"].(string),
+		Duration:  webIdentity["duration"].(int),
+	}
+}
+
 func flattenProviderEndpoints(d *schema.ResourceData) (map[string]string, error) {
-	endpoints := d.Get("endpoints").(map[string]interface{})
+	epMap, err := normalizeCustomEndpoints(d.Get("endpoints").(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] customer endpoints: %+v", epMap)
+	return epMap, nil
+}
+
+func flattenProviderRegionEndpoints(d *schema.ResourceData) (map[string]map[string]string, error) {
+	regionEndpoints := make(map[string]map[string]string)
+	for _, v := range d.Get("region_endpoints").([]interface{}) {
+		raw, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		region := raw["region"].(string)
+		if _, ok := regionEndpoints[region]; ok {
+			return nil, fmt.Errorf("the custom endpoints of region %s are specified more than once", region)
+		}
+		epMap, err := normalizeCustomEndpoints(raw["endpoints"].(map[string]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom endpoints of region %s: %s", region, err)
+		}
+		regionEndpoints[region] = epMap
+	}
+
+	if len(regionEndpoints) > 0 {
+		log.Printf("[DEBUG] customer endpoints of regions: %+v", regionEndpoints)
+	}
+	return regionEndpoints, nil
+}
+
+// normalizeCustomEndpoints adds the missing scheme and trailing slash to the custom endpoints, and copies the
+// endpoint to the derived catalog keys of the service. The placeholders in the endpoints are kept as they are.
+func normalizeCustomEndpoints(endpoints map[string]interface{}) (map[string]string, error) {
 	epMap := make(map[string]string)
 
 	for key, val := range endpoints {
@@ -1745,10 +2176,75 @@ func flattenProviderEndpoints(d *schema.ResourceData) (map[string]string, error)
 		}
 	}
 
-	log.Printf("[DEBUG] customer endpoints: %+v", epMap)
 	return epMap, nil
 }
 
+func flattenProviderRetryPolicy(d *schema.ResourceData) *config.RetryPolicy {
+	v, ok := d.Get("retry_policy").([]interface{})
+	if !ok || len(v) == 0 {
+		return nil
+	}
+
+	var methods []string
+	var statusCodes []int
+	if policy, ok := v[0].(map[string]interface{}); ok {
+		methods = utils.ExpandToStringList(policy["methods"].(*schema.Set).List())
+		statusCodes = utils.ExpandToIntList(policy["status_codes"].(*schema.Set).List())
+	}
+	return config.NewRetryPolicy(methods, statusCodes)
+}
+
+func flattenProviderTagsConfig(d *schema.ResourceData) *utils.ProviderTagsConfig {
+	tagsConfig := utils.ProviderTagsConfig{
+		DefaultTags: make(map[string]string),
+	}
+
+	if v, ok := d.Get("default_tags").([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		defaultTags := v[0].(map[string]interface{})["tags"].(map[string]interface{})
+		for key, val := range defaultTags {
+			tagsConfig.DefaultTags[key] = val.(string)
+		}
+	}
+
+	if v, ok := d.Get("ignore_tags").([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		ignoreTags := v[0].(map[string]interface{})
+		tagsConfig.IgnoreKeys = utils.ExpandToStringList(ignoreTags["keys"].(*schema.Set).List())
+		tagsConfig.IgnoreKeyPrefixes = utils.ExpandToStringList(ignoreTags["key_prefixes"].(*schema.Set).List())
+	}
+
+	log.Printf("[DEBUG] provider default tags: %v, ignore tags: %v, %v", tagsConfig.DefaultTags,
+		tagsConfig.IgnoreKeys, tagsConfig.IgnoreKeyPrefixes)
+	return &tagsConfig
+}
+
+func flattenProviderPreflightPolicies(d *schema.ResourceData) ([]config.PreflightPolicy, error) {
+	policyList := d.Get("preflight_policies").([]interface{})
+	policies := make([]config.PreflightPolicy, 0, len(policyList))
+	for _, v := range policyList {
+		raw, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		policy := config.PreflightPolicy{
+			Name:       raw["name"].(string),
+			Parameters: make(map[string]interface{}),
+		}
+		for key, val := range raw["parameters"].(map[string]interface{}) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(val.(string)), &value); err != nil {
+				return nil, fmt.Errorf("invalid parameter %s of the preflight policy %s: %s", key, policy.Name, err)
+			}
+			policy.Parameters[key] = value
+		}
+		if err := rms.ValidatePreflightPolicy(policy); err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
 func getCloudDomain(cloud, region string) string {
 	// first, use the specified value
 	if cloud != "" {
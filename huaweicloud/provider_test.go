@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
@@ -99,6 +100,41 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestMuxProviderServer(t *testing.T) {
+	serverFactory, err := NewMuxProviderServer(context.Background(), Provider())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := serverFactory().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, v := range resp.Diagnostics {
+		if v.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error of the provider schema: %s: %s", v.Summary, v.Detail)
+		}
+	}
+	for _, name := range []string{"huaweicloud_csms_secret_version", "huaweicloud_kms_data_key"} {
+		if _, ok := resp.EphemeralResourceSchemas[name]; !ok {
+			t.Errorf("the ephemeral resource %s is not served", name)
+		}
+	}
+	if _, ok := resp.ResourceSchemas["huaweicloud_vpc"]; !ok {
+		t.Errorf("the resources of the SDK provider are not served")
+	}
+
+	functionsResp, err := serverFactory().GetFunctions(context.Background(), &tfprotov5.GetFunctionsRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, name := range []string{"parse_resource_id", "build_resource_id", "obs_bucket_endpoint", "cidr_subnet_for_az"} {
+		if _, ok := functionsResp.Functions[name]; !ok {
+			t.Errorf("the function %s is not served", name)
+		}
+	}
+}
+
 // Steps for configuring HuaweiCloud with SSL validation are here:
 // https://github.com/hashicorp/terraform/pull/6279#issuecomment-219020144
 func TestAccProvider_caCertFile(t *testing.T) {
@@ -613,7 +613,7 @@ func resourceClusterV1Read(d *schema.ResourceData, meta interface{}) error {
 	// set tags
 	if resourceTags, err := tags.Get(client, "clusters", d.Id()).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		d.Set("tags", tagmap)
+		d.Set("tags", utils.FilterResourceTags(d, config.ProviderTags, tagmap))
 	} else {
 		logp.Printf("[WARN] fetching tags of MRS cluster failed: %s", err)
 	}
@@ -629,7 +629,7 @@ func resourceClusterV1Update(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// update tags
-	tagErr := utils.UpdateResourceTags(client, d, "clusters", d.Id())
+	tagErr := utils.UpdateResourceTags(client, d, config.ProviderTags, "clusters", d.Id())
 	if tagErr != nil {
 		return fmtp.Errorf("Error updating tags of MRS cluster:%s, err:%s", d.Id(), tagErr)
 	}
@@ -2,10 +2,13 @@
 package acceptance
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -197,6 +200,8 @@ var (
 
 	// the ID of ECS instance which has installed uniagent
 	HW_COC_INSTANCE_ID = os.Getenv("HW_COC_INSTANCE_ID")
+	// the comma-separated IDs of at least two ECS instances which have installed uniagent
+	HW_COC_INSTANCE_IDS = os.Getenv("HW_COC_INSTANCE_IDS")
 
 	// Deprecated
 	HW_SRC_ACCESS_KEY = os.Getenv("HW_SRC_ACCESS_KEY")
@@ -278,6 +283,10 @@ var TestAccProviderFactories map[string]func() (*schema.Provider, error)
 // TestAccProvider is the "main" provider instance
 var TestAccProvider *schema.Provider
 
+// TestAccProtoV5ProviderFactories contains the provider server which muxes the main provider instance and the
+// plugin-framework provider, it is required by the tests of ephemeral resources.
+var TestAccProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+
 func init() {
 	TestAccProvider = huaweicloud.Provider()
 
@@ -290,6 +299,16 @@ func init() {
 			return TestAccProvider, nil
 		},
 	}
+
+	TestAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+		"huaweicloud": func() (tfprotov5.ProviderServer, error) {
+			serverFactory, err := huaweicloud.NewMuxProviderServer(context.Background(), TestAccProvider)
+			if err != nil {
+				return nil, err
+			}
+			return serverFactory(), nil
+		},
+	}
 }
 
 func preCheckRequiredEnvVars(t *testing.T) {
@@ -1051,6 +1070,24 @@ func TestAccPreCheckCocInstanceID(t *testing.T) {
 	}
 }
 
+// GetCocInstanceIDs returns the non-empty instance IDs of HW_COC_INSTANCE_IDS.
+func GetCocInstanceIDs() []string {
+	instanceIDs := make([]string, 0)
+	for _, v := range strings.Split(HW_COC_INSTANCE_IDS, ",") {
+		if id := strings.TrimSpace(v); id != "" {
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+	return instanceIDs
+}
+
+// lintignore:AT003
+func TestAccPreCheckCocInstanceIDs(t *testing.T) {
+	if len(GetCocInstanceIDs()) < 2 {
+		t.Skip("HW_COC_INSTANCE_IDS must be set to at least two instance IDs for the acceptance test")
+	}
+}
+
 // lintignore:AT003
 func TestAccPrecheckKooGallery(t *testing.T) {
 	if HW_KOOGALLERY_ASSET == "" {
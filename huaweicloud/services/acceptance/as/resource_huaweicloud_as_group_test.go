@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/chnsz/golangsdk/openstack/autoscaling/v1/groups"
+	"github.com/chnsz/golangsdk/openstack/autoscaling/v1/instances"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
@@ -157,6 +158,74 @@ func TestAccASGroup_sourceDestCheck(t *testing.T) {
 	})
 }
 
+func TestAccASGroup_instanceRefresh(t *testing.T) {
+	var asGroup groups.Group
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_as_group.acc_as_group"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckASGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testASGroup_instanceRefresh(rName, "huaweicloud_as_configuration.acc_as_config.id"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckASGroupExists(resourceName, &asGroup),
+					resource.TestCheckResourceAttr(resourceName, "instances.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "instance_refresh.0.min_healthy_percentage", "50"),
+					resource.TestCheckResourceAttr(resourceName, "instance_refresh.0.batch_size", "1"),
+				),
+			},
+			{
+				Config: testASGroup_instanceRefresh(rName, "huaweicloud_as_configuration.refresh.id"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckASGroupExists(resourceName, &asGroup),
+					resource.TestCheckResourceAttrPair(resourceName, "scaling_configuration_id",
+						"huaweicloud_as_configuration.refresh", "id"),
+					resource.TestCheckResourceAttr(resourceName, "instances.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "status", "INSERVICE"),
+					testAccCheckASGroupInstancesRefreshed(resourceName),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckASGroupInstancesRefreshed checks whether all instances are launched from the current configuration.
+func testAccCheckASGroupInstancesRefreshed(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conf := acceptance.TestAccProvider.Meta().(*config.Config)
+		asClient, err := conf.AutoscalingV1Client(acceptance.HW_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("error creating autoscaling client: %s", err)
+		}
+
+		page, err := instances.List(asClient, rs.Primary.ID, nil).AllPages()
+		if err != nil {
+			return err
+		}
+		allIns, err := page.(instances.InstancePage).Extract()
+		if err != nil {
+			return err
+		}
+
+		configurationID := rs.Primary.Attributes["scaling_configuration_id"]
+		for _, ins := range allIns {
+			if ins.ConfigurationID != configurationID {
+				return fmt.Errorf("the instance %s is launched from %s, but expected %s", ins.ID,
+					ins.ConfigurationID, configurationID)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckASGroupDestroy(s *terraform.State) error {
 	conf := acceptance.TestAccProvider.Meta().(*config.Config)
 	asClient, err := conf.AutoscalingV1Client(acceptance.HW_REGION_NAME)
@@ -446,3 +515,50 @@ resource "huaweicloud_as_group" "acc_as_group"{
 }
 `, testASGroup_Base(rName), rName)
 }
+
+func testASGroup_instanceRefresh(rName, configurationID string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_as_configuration" "refresh" {
+  scaling_configuration_name = "%[2]s-refresh"
+  instance_config {
+    image    = data.huaweicloud_images_image.test.id
+    flavor   = data.huaweicloud_compute_flavors.test.ids[0]
+    key_name = huaweicloud_kps_keypair.acc_key.id
+    disk {
+      size        = 50
+      volume_type = "SSD"
+      disk_type   = "SYS"
+    }
+  }
+}
+
+resource "huaweicloud_as_group" "acc_as_group"{
+  scaling_group_name       = "%[2]s"
+  scaling_configuration_id = %[3]s
+  desire_instance_number   = 2
+  min_instance_number      = 1
+  max_instance_number      = 3
+  delete_instances         = "yes"
+  force_delete             = true
+  vpc_id                   = huaweicloud_vpc.test.id
+
+  networks {
+    id = huaweicloud_vpc_subnet.test.id
+  }
+  security_groups {
+    id = huaweicloud_networking_secgroup.test.id
+  }
+
+  instance_refresh {
+    min_healthy_percentage = 50
+    batch_size             = 1
+    instance_warmup        = 30
+    checkpoint_percentages = [50]
+    checkpoint_delay       = 30
+    auto_rollback          = true
+  }
+}
+`, testASGroup_Base(rName), rName, configurationID)
+}
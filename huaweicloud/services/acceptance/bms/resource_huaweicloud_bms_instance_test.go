@@ -49,6 +49,16 @@ func TestAccBmsInstance_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "auto_renew", "true"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"admin_pass", "agency_name", "eip_id", "iptype", "eip_charge_mode", "sharetype", "bandwidth_size",
+					"bandwidth_charge_mode", "system_disk_type", "system_disk_size", "data_disks", "tags",
+					"charging_mode", "period_unit", "period", "auto_renew",
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,192 @@
+//nolint:revive
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/fakecloud"
+)
+
+var (
+	// HW_TF_CASSETTE_MODE is the mode of the cassettes, the valid values are record and replay
+	HW_TF_CASSETTE_MODE = os.Getenv("HW_TF_CASSETTE_MODE")
+	// HW_TF_CASSETTE_DIR is the directory of the cassettes, defaults to testdata/cassettes of the test package
+	HW_TF_CASSETTE_DIR = os.Getenv("HW_TF_CASSETTE_DIR")
+)
+
+const (
+	defaultCassetteDir = "testdata/cassettes"
+	randomCharset      = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// Cassette records the HTTP interactions of a test when HW_TF_CASSETTE_MODE is record, and replays them offline
+// when HW_TF_CASSETTE_MODE is replay. The cassette of each test is stored in {HW_TF_CASSETTE_DIR}/{test name}.json.
+// A nil cassette is returned if HW_TF_CASSETTE_MODE is not set, and the methods of it fall back to the live
+// acceptance tests, e.g.
+//
+//	func TestAccVpc_basic(t *testing.T) {
+//		cassette := acceptance.NewCassette(t)
+//		name := cassette.RandomAccResourceName()
+//
+//		resource.ParallelTest(t, resource.TestCase{
+//			PreCheck:          func() { cassette.PreCheck(t) },
+//			ProviderFactories: cassette.ProviderFactories(),
+//			...
+//		})
+//	}
+//
+// The check functions must use the client configuration returned by Config, so that their requests are recorded too.
+type Cassette struct {
+	*config.Cassette
+
+	provider *schema.Provider
+	mu       sync.Mutex
+	rand     *rand.Rand
+}
+
+// NewCassette creates the cassette of the test, the recorded interactions are saved when the test finishes.
+func NewCassette(t *testing.T) *Cassette {
+	if HW_TF_CASSETTE_MODE == "" {
+		return nil
+	}
+
+	cassette := newConfigCassette(t, HW_TF_CASSETTE_MODE)
+	return &Cassette{
+		Cassette: cassette,
+		provider: huaweicloud.ProviderWithCassette(cassette),
+		//nolint:gosec // the random values are only used to name the test resources
+		rand: rand.New(rand.NewSource(cassette.Seed)),
+	}
+}
+
+// NewFakeCloudCassette creates the cassette of the test which is recorded against the fake cloud. The cassette is
+// replayed by default so that the test runs offline without the fake cloud, and it is recorded again if
+// HW_TF_CASSETTE_MODE is record.
+func NewFakeCloudCassette(t *testing.T) *Cassette {
+	mode := HW_TF_CASSETTE_MODE
+	if mode == "" {
+		mode = config.CassetteModeReplay
+	}
+
+	cassette := newConfigCassette(t, mode)
+	configure := fakecloud.ConfigureReplay
+	if cassette.Mode == config.CassetteModeRecord {
+		server := fakecloud.NewServer()
+		t.Cleanup(server.Close)
+		cassette.HostAliases = server.HostAliases()
+		configure = server.Configure
+	}
+
+	return &Cassette{
+		Cassette: cassette,
+		provider: huaweicloud.ProviderWithConfigureHook(func(c *config.Config) {
+			configure(c)
+			c.Cassette = cassette
+		}),
+		//nolint:gosec // the random values are only used to name the test resources
+		rand: rand.New(rand.NewSource(cassette.Seed)),
+	}
+}
+
+// newConfigCassette creates the cassette which is stored in {HW_TF_CASSETTE_DIR}/{test name}.json, the recorded
+// interactions are saved when the test finishes.
+func newConfigCassette(t *testing.T, mode string) *config.Cassette {
+	dir := HW_TF_CASSETTE_DIR
+	if dir == "" {
+		dir = defaultCassetteDir
+	}
+	path := filepath.Join(dir, strings.ReplaceAll(t.Name(), "/", "_")+".json")
+
+	cassette, err := config.NewCassette(path, mode)
+	if err != nil {
+		t.Fatalf("error creating the cassette: %s", err)
+	}
+	if cassette.Mode == config.CassetteModeRecord {
+		t.Cleanup(func() {
+			if err := cassette.Save(); err != nil {
+				t.Errorf("error saving the cassette %s: %s", cassette.Path, err)
+			}
+		})
+	}
+	return cassette
+}
+
+// PreCheck only checks the region in replay mode, as the requests are not sent to the cloud.
+func (c *Cassette) PreCheck(t *testing.T) {
+	if c == nil || c.Mode != config.CassetteModeReplay {
+		TestAccPreCheck(t)
+		return
+	}
+	preCheckRequiredEnvVars(t)
+}
+
+// ProviderFactories returns the factories of the provider which records or replays the interactions by the cassette.
+func (c *Cassette) ProviderFactories() map[string]func() (*schema.Provider, error) {
+	if c == nil {
+		return TestAccProviderFactories
+	}
+
+	return map[string]func() (*schema.Provider, error){
+		"huaweicloud": func() (*schema.Provider, error) {
+			return c.provider, nil
+		},
+	}
+}
+
+// Config returns the client configuration of the provider which is configured by the test.
+func (c *Cassette) Config() *config.Config {
+	if c == nil {
+		return TestAccProvider.Meta().(*config.Config)
+	}
+	return c.provider.Meta().(*config.Config)
+}
+
+// ConfigureProvider configures the provider of cassette with the raw configuration, it is used by the tests which
+// drive the resources by ResourceLifecycle instead of the Terraform CLI.
+func (c *Cassette) ConfigureProvider(t *testing.T, raw map[string]interface{}) *config.Config {
+	t.Helper()
+
+	if diags := c.provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		t.Fatalf("error configuring the provider: %v", diags)
+	}
+	return c.Config()
+}
+
+// RandomAccResourceName returns a random name which is the same in record and replay modes.
+func (c *Cassette) RandomAccResourceName() string {
+	if c == nil {
+		return RandomAccResourceName()
+	}
+	return fmt.Sprintf("tf_test_%s", c.randString(5))
+}
+
+// RandomAccResourceNameWithDash returns a random name with dash which is the same in record and replay modes.
+func (c *Cassette) RandomAccResourceNameWithDash() string {
+	if c == nil {
+		return RandomAccResourceNameWithDash()
+	}
+	return fmt.Sprintf("tf-test-%s", c.randString(5))
+}
+
+func (c *Cassette) randString(length int) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = randomCharset[c.rand.Intn(len(randomCharset))]
+	}
+	return string(result)
+}
@@ -2,6 +2,7 @@ package coc
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -74,18 +75,75 @@ func TestAccScriptExecute_basic(t *testing.T) {
 					resource.TestCheckResourceAttrPair(resourceName, "script_id", "huaweicloud_coc_script.test", "id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 					resource.TestCheckResourceAttrSet(resourceName, "finished_at"),
+					resource.TestCheckResourceAttr(resourceName, "execute_instances.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "execute_instances.0.instance_id",
+						acceptance.HW_COC_INSTANCE_ID),
+					resource.TestCheckResourceAttr(resourceName, "execute_instances.0.status", "FINISHED"),
+					resource.TestCheckResourceAttrSet(resourceName, "outputs.%"),
 				),
 			},
 			{
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"instance_id", "parameters"},
+				ImportStateVerifyIgnore: []string{"parameters"},
 			},
 		},
 	})
 }
 
+func TestAccScriptExecute_batch(t *testing.T) {
+	var obj interface{}
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_coc_script_execute.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&obj,
+		getScriptExecuteResourceFunc,
+	)
+
+	// lintignore:AT001
+	// without CheckDestroy because the ticket ID always exits.
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckInternal(t)
+			acceptance.TestAccPreCheckCocInstanceIDs(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// each instance is executed in its own batch
+				Config: tesScriptExecute_batch(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "status", "FINISHED"),
+					resource.TestCheckResourceAttr(resourceName, "batch_size", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rotation_strategy", "CONTINUE"),
+					resource.TestCheckResourceAttr(resourceName, "execute_instances.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "execute_instances.0.batch_index", "1"),
+					resource.TestCheckResourceAttr(resourceName, "execute_instances.0.status", "FINISHED"),
+					resource.TestCheckResourceAttr(resourceName, "execute_instances.1.batch_index", "2"),
+					resource.TestCheckResourceAttr(resourceName, "execute_instances.1.status", "FINISHED"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rollback_execution_id", ""),
+				),
+			},
+			{
+				// the script fails in the first batch, so the second batch is canceled and the executed instance is
+				// rolled back
+				Config:      tesScriptExecute_batchFailure(rName),
+				ExpectError: regexp.MustCompile(`the execution of COC script \(.+\) is not finished, the status is`),
+			},
+		},
+	})
+}
+
+func testScriptExecute_instanceIDs() string {
+	return fmt.Sprintf(`["%s"]`, strings.Join(acceptance.GetCocInstanceIDs(), `", "`))
+}
+
 func tesScriptExecute_basic(name, instanceID string) string {
 	return fmt.Sprintf(`
 %s
@@ -106,3 +164,74 @@ resource "huaweicloud_coc_script_execute" "test" {
   }
 }`, tesScript_updated(name), instanceID)
 }
+
+func tesScriptExecute_batch(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_coc_script_execute" "test" {
+  script_id         = huaweicloud_coc_script.test.id
+  instance_ids      = %s
+  timeout           = 600
+  execute_user      = "root"
+  batch_size        = 1
+  rotation_strategy = "CONTINUE"
+  success_rate      = 100
+
+  parameters {
+    name  = "name"
+    value = "somebody"
+  }
+  parameters {
+    name  = "company"
+    value = "HuaweiCloud"
+  }
+
+  rollback {
+    script_id = huaweicloud_coc_script.test.id
+
+    parameters {
+      name  = "name"
+      value = "rollback"
+    }
+  }
+}`, tesScript_updated(name), testScriptExecute_instanceIDs())
+}
+
+func tesScriptExecute_batchFailure(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_coc_script" "failure" {
+  name        = "%[2]s_failure"
+  description = "a demo script which always fails"
+  risk_level  = "LOW"
+  version     = "1.0.0"
+  type        = "SHELL"
+
+  content = <<EOF
+#! /bin/bash
+echo "forced failure"
+exit 1
+EOF
+}
+
+resource "huaweicloud_coc_script_execute" "test" {
+  script_id         = huaweicloud_coc_script.failure.id
+  instance_ids      = %[3]s
+  timeout           = 600
+  execute_user      = "root"
+  batch_size        = 1
+  rotation_strategy = "PAUSE"
+  success_rate      = 100
+
+  rollback {
+    script_id = huaweicloud_coc_script.test.id
+
+    parameters {
+      name  = "name"
+      value = "rollback"
+    }
+  }
+}`, tesScript_updated(name), name, testScriptExecute_instanceIDs())
+}
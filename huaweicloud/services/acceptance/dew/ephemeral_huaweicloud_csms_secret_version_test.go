@@ -0,0 +1,42 @@
+package dew
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+// The ephemeral resources require Terraform 1.10 or later, and their values are not saved in the state, so the test
+// only checks whether the secret version can be opened.
+func TestAccCsmsSecretVersionEphemeral_basic(t *testing.T) {
+	name := acceptance.RandomAccResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV5ProviderFactories: acceptance.TestAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCsmsSecretVersionEphemeral_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("huaweicloud_csms_secret.test", "name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccCsmsSecretVersionEphemeral_basic(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_csms_secret" "test" {
+  name        = "%s"
+  secret_text = "this is a password"
+}
+
+ephemeral "huaweicloud_csms_secret_version" "test" {
+  secret_name = huaweicloud_csms_secret.test.name
+}
+`, name)
+}
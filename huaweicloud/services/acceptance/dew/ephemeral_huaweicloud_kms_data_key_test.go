@@ -0,0 +1,43 @@
+package dew
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+// The ephemeral resources require Terraform 1.10 or later, and their values are not saved in the state, so the test
+// only checks whether the data key can be opened.
+func TestAccKmsDataKeyEphemeral_basic(t *testing.T) {
+	keyAlias := acceptance.RandomAccResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheckKms(t) },
+		ProtoV5ProviderFactories: acceptance.TestAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKmsDataKeyEphemeral_basic(keyAlias),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("huaweicloud_kms_key.test", "key_alias", keyAlias),
+				),
+			},
+		},
+	})
+}
+
+func testAccKmsDataKeyEphemeral_basic(keyAlias string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_kms_key" "test" {
+  key_alias    = "%s"
+  pending_days = "7"
+}
+
+ephemeral "huaweicloud_kms_data_key" "test" {
+  key_id         = huaweicloud_kms_key.test.id
+  datakey_length = "512"
+}
+`, keyAlias)
+}
@@ -55,6 +55,14 @@ func TestAccDliPackage_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "updated_at"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"object_path", "is_async",
+				},
+			},
 		},
 	})
 }
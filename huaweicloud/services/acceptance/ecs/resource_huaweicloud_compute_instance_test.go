@@ -11,6 +11,8 @@ import (
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/ecs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/vpc"
 )
 
 func TestAccComputeInstance_basic(t *testing.T) {
@@ -83,6 +85,71 @@ func TestAccComputeInstance_basic(t *testing.T) {
 	})
 }
 
+// TestComputeInstance_cassette replays the creation, update and import of the instance from the cassette recorded
+// against the fake cloud.
+func TestComputeInstance_cassette(t *testing.T) {
+	cassette := acceptance.NewFakeCloudCassette(t)
+	cfg := cassette.ConfigureProvider(t, map[string]interface{}{"region": "cn-north-4"})
+	rName := cassette.RandomAccResourceName()
+
+	vpcLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceVirtualPrivateCloudV1(), Meta: cfg}
+	defer vpcLifecycle.Destroy(t)
+	vpcLifecycle.Apply(t, map[string]interface{}{
+		"name": rName,
+		"cidr": "192.168.0.0/16",
+	})
+
+	subnetLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceVpcSubnetV1(), Meta: cfg}
+	defer subnetLifecycle.Destroy(t)
+	subnetLifecycle.Apply(t, map[string]interface{}{
+		"name":       rName,
+		"cidr":       "192.168.0.0/24",
+		"gateway_ip": "192.168.0.1",
+		"vpc_id":     vpcLifecycle.ID(),
+	})
+
+	secGroupLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceNetworkingSecGroup(), Meta: cfg}
+	defer secGroupLifecycle.Destroy(t)
+	secGroupLifecycle.Apply(t, map[string]interface{}{
+		"name": rName,
+	})
+
+	lifecycle := acceptance.ResourceLifecycle{Resource: ecs.ResourceComputeInstance(), Meta: cfg}
+	defer lifecycle.Destroy(t)
+
+	instanceConfig := func(name, description string, tags map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":               name,
+			"description":        description,
+			"image_id":           "image-fake",
+			"flavor_id":          "s6.small.1",
+			"availability_zone":  "cn-north-4a",
+			"security_group_ids": []interface{}{secGroupLifecycle.ID()},
+			"system_disk_type":   "SSD",
+			"network": []interface{}{
+				map[string]interface{}{"uuid": subnetLifecycle.ID()},
+			},
+			"data_disks": []interface{}{
+				map[string]interface{}{"type": "SSD", "size": 20},
+			},
+			"tags": tags,
+		}
+	}
+
+	d := lifecycle.Apply(t, instanceConfig(rName, "terraform test", map[string]interface{}{"foo": "bar"}))
+	if d.Get("status") != "ACTIVE" || d.Get("access_ip_v4") != "192.168.0.2" {
+		t.Errorf("unexpected instance after creation: status %v, IP %v", d.Get("status"), d.Get("access_ip_v4"))
+	}
+
+	d = lifecycle.Apply(t, instanceConfig(rName+"-update", "terraform test update",
+		map[string]interface{}{"foo": "bar2", "key2": "value2"}))
+	if d.Get("name") != rName+"-update" || d.Get("tags.foo") != "bar2" {
+		t.Errorf("unexpected instance after update: name %v, tags %v", d.Get("name"), d.Get("tags"))
+	}
+
+	lifecycle.ImportStateVerify(t, "stop_before_destroy", "delete_eip_on_termination", "data_disks")
+}
+
 func TestAccComputeInstance_prePaid(t *testing.T) {
 	var instance cloudservers.CloudServer
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/evs"
 )
 
 func getVolumeResourceFunc(conf *config.Config, state *terraform.ResourceState) (interface{}, error) {
@@ -113,6 +114,41 @@ func TestAccEvsVolume_basic(t *testing.T) {
 	})
 }
 
+// TestEvsVolume_cassette replays the creation, update and import of the volume from the cassette recorded against
+// the fake cloud.
+func TestEvsVolume_cassette(t *testing.T) {
+	cassette := acceptance.NewFakeCloudCassette(t)
+	cfg := cassette.ConfigureProvider(t, map[string]interface{}{"region": "cn-north-4"})
+	rName := cassette.RandomAccResourceName()
+
+	lifecycle := acceptance.ResourceLifecycle{Resource: evs.ResourceEvsVolume(), Meta: cfg}
+	defer lifecycle.Destroy(t)
+
+	d := lifecycle.Apply(t, map[string]interface{}{
+		"name":              rName,
+		"description":       "test volume",
+		"availability_zone": "cn-north-4a",
+		"volume_type":       "SSD",
+		"size":              10,
+	})
+	if d.Get("size") != 10 || d.Get("description") != "test volume" {
+		t.Errorf("unexpected volume after creation: size %v, description %v", d.Get("size"), d.Get("description"))
+	}
+
+	d = lifecycle.Apply(t, map[string]interface{}{
+		"name":              rName + "_update",
+		"description":       "test volume update",
+		"availability_zone": "cn-north-4a",
+		"volume_type":       "SSD",
+		"size":              20,
+	})
+	if d.Get("name") != rName+"_update" || d.Get("size") != 20 {
+		t.Errorf("unexpected volume after update: name %v, size %v", d.Get("name"), d.Get("size"))
+	}
+
+	lifecycle.ImportStateVerify(t, "cascade")
+}
+
 func TestAccEvsVolume_withEpsId(t *testing.T) {
 	var volume cloudvolumes.Volume
 	rName := acceptance.RandomAccResourceName()
@@ -0,0 +1,62 @@
+//nolint:revive
+package acceptance
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/fakecloud"
+)
+
+// FakeCloud runs the tests of VPC, ECS and EVS resources against an in-process fake cloud, the endpoints and
+// credentials of the provider are overridden so that no cloud account is required, e.g.
+//
+//	func TestAccVpc_fake(t *testing.T) {
+//		fake := acceptance.NewFakeCloud(t)
+//
+//		resource.ParallelTest(t, resource.TestCase{
+//			PreCheck:          func() { fake.PreCheck(t) },
+//			ProviderFactories: fake.ProviderFactories(),
+//			CheckDestroy:      rc.WithConfigFunc(fake.Config).CheckResourceDestroy(),
+//			...
+//		})
+//	}
+//
+// The check functions must use the client configuration returned by Config, so that their requests are sent to the
+// fake cloud too.
+type FakeCloud struct {
+	*fakecloud.Server
+
+	provider *schema.Provider
+}
+
+// NewFakeCloud starts the fake cloud of the test, it is closed when the test finishes.
+func NewFakeCloud(t *testing.T) *FakeCloud {
+	server := fakecloud.NewServer()
+	t.Cleanup(server.Close)
+
+	return &FakeCloud{
+		Server:   server,
+		provider: huaweicloud.ProviderWithConfigureHook(server.Configure),
+	}
+}
+
+// PreCheck does nothing as the tests of fake cloud require neither the region nor the credentials.
+func (*FakeCloud) PreCheck(*testing.T) {}
+
+// ProviderFactories returns the factories of the provider which sends all requests to the fake cloud.
+func (f *FakeCloud) ProviderFactories() map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"huaweicloud": func() (*schema.Provider, error) {
+			return f.provider, nil
+		},
+	}
+}
+
+// Config returns the client configuration of the provider which is configured by the test.
+func (f *FakeCloud) Config() *config.Config {
+	return f.provider.Meta().(*config.Config)
+}
@@ -0,0 +1,594 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+const (
+	kindServer = "cloudservers"
+
+	defaultRootVolumeSize = 40
+)
+
+func registerECS(s *Server, rt *router) {
+	rt.handle("POST", "/v1.1/{project_id}/cloudservers", s.createServers)
+	rt.handle("GET", "/v1/{project_id}/cloudservers/detail", s.listServers)
+	rt.handle("POST", "/v1/{project_id}/cloudservers/delete", s.deleteServers)
+	rt.handle("GET", "/v1/{project_id}/cloudservers/{server_id}", s.getServer)
+	rt.handle("PUT", "/v1/{project_id}/cloudservers/{server_id}", s.updateServer)
+	rt.handle("POST", "/v1/{project_id}/cloudservers/{server_id}/metadata", s.updateServerMetadata)
+	rt.handle("DELETE", "/v1/{project_id}/cloudservers/{server_id}/metadata/{key}", s.deleteServerMetadata)
+	rt.handle("GET", "/v1/{project_id}/cloudservers/{server_id}/block_device", s.listBlockDevices)
+	rt.handle("GET", "/v1/{project_id}/cloudservers/{server_id}/block_device/{volume_id}", s.getBlockDevice)
+	rt.handle("POST", "/v1/{project_id}/cloudservers/{server_id}/attachvolume", s.attachServerVolume)
+	rt.handle("DELETE", "/v1/{project_id}/cloudservers/{server_id}/detachvolume/{volume_id}", s.detachServerVolume)
+	rt.handle("GET", "/v1/{project_id}/jobs/{job_id}", s.getJob)
+
+	registerTags(s, rt)
+}
+
+// serverViewLocked returns the server with the addresses, security groups and tags, which are derived from the ports
+// and volumes of the server.
+func (s *Server) serverViewLocked(id string) map[string]interface{} {
+	server, _ := s.getLocked(kindServer, id)
+	metadata, _ := server["metadata"].(map[string]interface{})
+
+	addresses := make([]interface{}, 0)
+	securityGroups := make([]interface{}, 0)
+	for i, port := range s.listLocked(kindPort, matchField("device_id", id)) {
+		for _, item := range port["fixed_ips"].([]interface{}) {
+			addresses = append(addresses, map[string]interface{}{
+				"version":                 "4",
+				"addr":                    getString(item.(map[string]interface{}), "ip_address"),
+				"OS-EXT-IPS-MAC:mac_addr": port["mac_address"],
+				"OS-EXT-IPS:port_id":      port["id"],
+				"OS-EXT-IPS:type":         "fixed",
+			})
+		}
+		// the security groups of the server are the same as the primary port
+		if i > 0 {
+			continue
+		}
+		groupIDs, _ := port["security_groups"].([]interface{})
+		for _, groupID := range groupIDs {
+			if group, ok := s.getLocked(kindSecGroup, fmt.Sprint(groupID)); ok {
+				securityGroups = append(securityGroups, map[string]interface{}{
+					"id":   group["id"],
+					"name": group["name"],
+				})
+			}
+		}
+	}
+	server["addresses"] = map[string]interface{}{getString(metadata, "vpc_id"): addresses}
+	server["security_groups"] = securityGroups
+
+	tags := s.tagsLocked(kindServer, id)
+	tagList := make([]string, 0, len(tags))
+	for k, v := range tags {
+		tagList = append(tagList, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(tagList)
+	server["tags"] = tagList
+	return server
+}
+
+// addServerVolumeLocked attaches the volume to the server and records it in the volumes_attached of the server.
+func (s *Server) addServerVolumeLocked(serverID, volumeID, device, bootIndex string) error {
+	if err := s.attachVolumeLocked(volumeID, serverID, device); err != nil {
+		return err
+	}
+	s.updateLocked(kindServer, serverID, func(server map[string]interface{}) {
+		attached, _ := server["os-extended-volumes:volumes_attached"].([]interface{})
+		server["os-extended-volumes:volumes_attached"] = append(attached, map[string]interface{}{
+			"id":                    volumeID,
+			"delete_on_termination": fmt.Sprint(bootIndex == "0"),
+			"bootIndex":             bootIndex,
+			"device":                device,
+		})
+	})
+	return nil
+}
+
+func (s *Server) removeServerVolumeLocked(serverID, volumeID string) {
+	s.detachVolumeLocked(volumeID, serverID)
+	s.updateLocked(kindServer, serverID, func(server map[string]interface{}) {
+		attached, _ := server["os-extended-volumes:volumes_attached"].([]interface{})
+		result := make([]interface{}, 0, len(attached))
+		for _, item := range attached {
+			if getString(item.(map[string]interface{}), "id") != volumeID {
+				result = append(result, item)
+			}
+		}
+		server["os-extended-volumes:volumes_attached"] = result
+	})
+}
+
+// serverVolumeLocked returns the attached volume of the server in the format of volumes_attached.
+func (s *Server) serverVolumeLocked(serverID, volumeID string) (map[string]interface{}, bool) {
+	server, ok := s.getLocked(kindServer, serverID)
+	if !ok {
+		return nil, false
+	}
+	attached, _ := server["os-extended-volumes:volumes_attached"].([]interface{})
+	for _, item := range attached {
+		if volume := item.(map[string]interface{}); getString(volume, "id") == volumeID {
+			return volume, true
+		}
+	}
+	return nil, false
+}
+
+// validateServerLocked checks the VPC, subnets and security groups of the server request.
+func (s *Server) validateServerLocked(opts map[string]interface{}) error {
+	vpcID := getString(opts, "vpcid")
+	if !s.existsLocked(kindVpc, vpcID) {
+		return fmt.Errorf("the VPC %s does not exist", vpcID)
+	}
+
+	nics, _ := opts["nics"].([]interface{})
+	if len(nics) == 0 {
+		return fmt.Errorf("nics is required")
+	}
+	for _, item := range nics {
+		nic, _ := item.(map[string]interface{})
+		subnet, ok := s.getLocked(kindSubnet, getString(nic, "subnet_id"))
+		if !ok || subnet["vpc_id"] != vpcID {
+			return fmt.Errorf("the subnet %s does not exist in the VPC %s", getString(nic, "subnet_id"), vpcID)
+		}
+	}
+
+	groups, _ := opts["security_groups"].([]interface{})
+	for _, item := range groups {
+		group, _ := item.(map[string]interface{})
+		if !s.existsLocked(kindSecGroup, getString(group, "id")) {
+			return fmt.Errorf("the security group %s does not exist", getString(group, "id"))
+		}
+	}
+	return nil
+}
+
+// putServerLocked creates a server with the ports and volumes of the request, and returns the server ID.
+func (s *Server) putServerLocked(projectID, name string, opts map[string]interface{}) (string, error) {
+	availabilityZone := getString(opts, "availability_zone")
+	epsID := getString(opts, "enterprise_project_id")
+	if extendParam, _ := opts["extendparam"].(map[string]interface{}); getString(extendParam,
+		"enterpriseProjectId") != "" {
+		epsID = getString(extendParam, "enterpriseProjectId")
+	}
+	if epsID == "" {
+		epsID = defaultEpsID
+	}
+
+	metadata := map[string]interface{}{}
+	if raw, ok := opts["metadata"].(map[string]interface{}); ok {
+		for k, v := range raw {
+			metadata[k] = v
+		}
+	}
+	metadata["charging_mode"] = "0"
+	metadata["vpc_id"] = getString(opts, "vpcid")
+	metadata["metering.image_id"] = getString(opts, "imageRef")
+	metadata["os_bit"] = "64"
+
+	schedulerHints, _ := opts["os:scheduler_hints"].(map[string]interface{})
+	if schedulerHints == nil {
+		schedulerHints = map[string]interface{}{}
+	}
+	if group := getString(schedulerHints, "group"); group != "" {
+		schedulerHints["group"] = []interface{}{group}
+	}
+
+	server := s.putLocked(kindServer, map[string]interface{}{
+		"name":        name,
+		"description": getString(opts, "description"),
+		"status":      "ACTIVE",
+		"flavor": map[string]interface{}{
+			"id":    getString(opts, "flavorRef"),
+			"name":  getString(opts, "flavorRef"),
+			"vcpus": "2",
+			"ram":   "4096",
+			"disk":  "0",
+		},
+		"image":                                map[string]interface{}{"id": getString(opts, "imageRef")},
+		"key_name":                             getString(opts, "key_name"),
+		"metadata":                             metadata,
+		"enterprise_project_id":                epsID,
+		"tenant_id":                            projectID,
+		"user_id":                              s.DomainID,
+		"hostId":                               newID(),
+		"accessIPv4":                           "",
+		"accessIPv6":                           "",
+		"locked":                               false,
+		"config_drive":                         "",
+		"os:scheduler_hints":                   schedulerHints,
+		"os-extended-volumes:volumes_attached": []interface{}{},
+		"sys_tags": []interface{}{
+			map[string]interface{}{"key": "_sys_enterprise_project_id", "value": epsID},
+		},
+		"OS-EXT-AZ:availability_zone":      availabilityZone,
+		"OS-EXT-SRV-ATTR:hostname":         name,
+		"OS-EXT-SRV-ATTR:root_device_name": "/dev/vda",
+		"OS-EXT-STS:vm_state":              "active",
+		"OS-EXT-STS:power_state":           1,
+		"OS-SRV-USG:launched_at":           now(),
+		"created":                          now(),
+		"updated":                          now(),
+	})
+	serverID := server["id"].(string)
+
+	securityGroups := make([]interface{}, 0)
+	groups, _ := opts["security_groups"].([]interface{})
+	for _, item := range groups {
+		group, _ := item.(map[string]interface{})
+		securityGroups = append(securityGroups, getString(group, "id"))
+	}
+	nics, _ := opts["nics"].([]interface{})
+	for _, item := range nics {
+		nic, _ := item.(map[string]interface{})
+		_, err := s.createPortLocked(getString(nic, "subnet_id"), getString(nic, "ip_address"), serverID,
+			"compute:"+availabilityZone, securityGroups)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	rootVolume, _ := opts["root_volume"].(map[string]interface{})
+	rootSize := getInt(rootVolume, "size")
+	if rootSize <= 0 {
+		rootSize = defaultRootVolumeSize
+	}
+	volume := s.putVolumeLocked(projectID, fmt.Sprintf("%s-volume-0000", name), getString(rootVolume, "volumetype"),
+		availabilityZone, rootSize, rootVolume)
+	s.updateLocked(kindVolume, volume["id"].(string), func(volume map[string]interface{}) {
+		volume["bootable"] = "true"
+	})
+	if err := s.addServerVolumeLocked(serverID, volume["id"].(string), "/dev/vda", "0"); err != nil {
+		return "", err
+	}
+
+	dataVolumes, _ := opts["data_volumes"].([]interface{})
+	for i, item := range dataVolumes {
+		dataVolume, _ := item.(map[string]interface{})
+		volume := s.putVolumeLocked(projectID, fmt.Sprintf("%s-volume-%04d", name, i+1),
+			getString(dataVolume, "volumetype"), availabilityZone, getInt(dataVolume, "size"), dataVolume)
+		device := fmt.Sprintf("/dev/vd%c", 'b'+i)
+		if err := s.addServerVolumeLocked(serverID, volume["id"].(string), device, ""); err != nil {
+			return "", err
+		}
+	}
+
+	s.setTagsLocked(kindServer, serverID, parseTagList(opts["server_tags"]))
+	return serverID, nil
+}
+
+// createServers creates the servers and all of them are active when the job is returned, the yearly/monthly billing
+// mode is not supported.
+func (s *Server) createServers(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	opts, err := readBody(r, "server")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+	if extendParam, _ := opts["extendparam"].(map[string]interface{}); getString(extendParam,
+		"chargingMode") == "prePaid" {
+		writeBadRequest(w, "the prePaid servers are not supported by the fake cloud")
+		return
+	}
+	for _, key := range []string{"name", "imageRef", "flavorRef", "vpcid", "availability_zone"} {
+		if getString(opts, key) == "" {
+			writeBadRequest(w, "%s is required", key)
+			return
+		}
+	}
+	count := getInt(opts, "count")
+	if count <= 0 {
+		count = 1
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.validateServerLocked(opts); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	serverIDs := make([]interface{}, count)
+	subJobs := make([]interface{}, count)
+	for i := 0; i < count; i++ {
+		name := getString(opts, "name")
+		if count > 1 {
+			name = fmt.Sprintf("%s-%04d", name, i+1)
+		}
+		serverID, err := s.putServerLocked(params["project_id"], name, opts)
+		if err != nil {
+			writeBadRequest(w, "%s", err)
+			return
+		}
+		serverIDs[i] = serverID
+		subJobs[i] = map[string]interface{}{
+			"job_id":   newID(),
+			"job_type": "createSingleServer",
+			"status":   "SUCCESS",
+			"entities": map[string]interface{}{"server_id": serverID},
+		}
+	}
+
+	jobID := s.newJobLocked("createServer", map[string]interface{}{
+		"sub_jobs_total": count,
+		"sub_jobs":       subJobs,
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"job_id":    jobID,
+		"serverIds": serverIDs,
+	})
+}
+
+func (s *Server) listServers(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	servers := s.listLocked(kindServer, func(obj map[string]interface{}) bool {
+		return (query.Get("name") == "" || query.Get("name") == obj["name"]) &&
+			(query.Get("status") == "" || query.Get("status") == obj["status"]) &&
+			(query.Get("flavor") == "" || query.Get("flavor") == obj["flavor"].(map[string]interface{})["id"])
+	})
+	result := make([]interface{}, len(servers))
+	for i, server := range servers {
+		result[i] = s.serverViewLocked(server["id"].(string))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"servers": result,
+		"count":   len(result),
+	})
+}
+
+func (s *Server) getServer(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["server_id"]
+	if !s.existsLocked(kindServer, id) {
+		writeNotFound(w, "server", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"server": s.serverViewLocked(id)})
+}
+
+func (s *Server) updateServer(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "server")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["server_id"]
+	ok := s.updateLocked(kindServer, id, func(server map[string]interface{}) {
+		mergeFields(server, body, "name", "description")
+		if hostname := getString(body, "hostname"); hostname != "" {
+			server["OS-EXT-SRV-ATTR:hostname"] = hostname
+		}
+		server["updated"] = now()
+	})
+	if !ok {
+		writeNotFound(w, "server", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"server": s.serverViewLocked(id)})
+}
+
+func (s *Server) updateServerMetadata(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "metadata")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["server_id"]
+	var metadata map[string]interface{}
+	ok := s.updateLocked(kindServer, id, func(server map[string]interface{}) {
+		metadata, _ = server["metadata"].(map[string]interface{})
+		for k, v := range body {
+			metadata[k] = v
+		}
+	})
+	if !ok {
+		writeNotFound(w, "server", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"metadata": metadata})
+}
+
+func (s *Server) deleteServerMetadata(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["server_id"]
+	ok := s.updateLocked(kindServer, id, func(server map[string]interface{}) {
+		metadata, _ := server["metadata"].(map[string]interface{})
+		delete(metadata, params["key"])
+	})
+	if !ok {
+		writeNotFound(w, "server", id)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// deleteServers deletes the servers with their ports and system disks, the data disks are only deleted when
+// delete_volume is true.
+func (s *Server) deleteServers(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+	servers, _ := body["servers"].([]interface{})
+	deleteVolume := getBool(body, "delete_volume", false)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serverIDs := make([]string, len(servers))
+	for i, item := range servers {
+		server, _ := item.(map[string]interface{})
+		serverIDs[i] = getString(server, "id")
+		if !s.existsLocked(kindServer, serverIDs[i]) {
+			writeNotFound(w, "server", serverIDs[i])
+			return
+		}
+	}
+
+	subJobs := make([]interface{}, len(serverIDs))
+	for i, serverID := range serverIDs {
+		for _, port := range s.listLocked(kindPort, matchField("device_id", serverID)) {
+			s.deleteLocked(kindPort, port["id"].(string))
+		}
+
+		server, _ := s.getLocked(kindServer, serverID)
+		attached, _ := server["os-extended-volumes:volumes_attached"].([]interface{})
+		for _, item := range attached {
+			volume := item.(map[string]interface{})
+			volumeID := getString(volume, "id")
+			s.detachVolumeLocked(volumeID, serverID)
+			if getString(volume, "bootIndex") == "0" || deleteVolume {
+				s.deleteLocked(kindVolume, volumeID)
+			}
+		}
+
+		s.deleteLocked(kindServer, serverID)
+		subJobs[i] = map[string]interface{}{
+			"job_id":   newID(),
+			"job_type": "deleteSingleServer",
+			"status":   "SUCCESS",
+			"entities": map[string]interface{}{"server_id": serverID},
+		}
+	}
+
+	jobID := s.newJobLocked("deleteServer", map[string]interface{}{
+		"sub_jobs_total": len(serverIDs),
+		"sub_jobs":       subJobs,
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+// blockDeviceLocked returns the volume attachment in the format of the block device API.
+func (s *Server) blockDeviceLocked(serverID string, attached map[string]interface{}) map[string]interface{} {
+	volumeID := getString(attached, "id")
+	volume, _ := s.getLocked(kindVolume, volumeID)
+	bootIndex := -1
+	if getString(attached, "bootIndex") == "0" {
+		bootIndex = 0
+	}
+	return map[string]interface{}{
+		"id":         volumeID,
+		"serverId":   serverID,
+		"volumeId":   volumeID,
+		"device":     getString(attached, "device"),
+		"bootIndex":  bootIndex,
+		"size":       getInt(volume, "size"),
+		"pciAddress": "0000:02:01.0",
+		"bus":        "virtio",
+	}
+}
+
+func (s *Server) listBlockDevices(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serverID := params["server_id"]
+	server, ok := s.getLocked(kindServer, serverID)
+	if !ok {
+		writeNotFound(w, "server", serverID)
+		return
+	}
+
+	attached, _ := server["os-extended-volumes:volumes_attached"].([]interface{})
+	result := make([]interface{}, len(attached))
+	for i, item := range attached {
+		result[i] = s.blockDeviceLocked(serverID, item.(map[string]interface{}))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volumeAttachments": result})
+}
+
+func (s *Server) getBlockDevice(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serverID, volumeID := params["server_id"], params["volume_id"]
+	attached, ok := s.serverVolumeLocked(serverID, volumeID)
+	if !ok {
+		writeNotFound(w, "block device", volumeID)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"volumeAttachment": s.blockDeviceLocked(serverID, attached),
+	})
+}
+
+func (s *Server) attachServerVolume(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "volumeAttachment")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serverID, volumeID := params["server_id"], getString(body, "volumeId")
+	server, ok := s.getLocked(kindServer, serverID)
+	if !ok {
+		writeNotFound(w, "server", serverID)
+		return
+	}
+
+	device := getString(body, "device")
+	if device == "" {
+		attached, _ := server["os-extended-volumes:volumes_attached"].([]interface{})
+		device = fmt.Sprintf("/dev/vd%c", 'a'+len(attached))
+	}
+	if err := s.addServerVolumeLocked(serverID, volumeID, device, ""); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	jobID := s.newJobLocked("attachVolume", map[string]interface{}{
+		"server_id": serverID,
+		"volume_id": volumeID,
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+func (s *Server) detachServerVolume(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serverID, volumeID := params["server_id"], params["volume_id"]
+	attached, ok := s.serverVolumeLocked(serverID, volumeID)
+	if !ok {
+		writeNotFound(w, "block device", volumeID)
+		return
+	}
+	if getString(attached, "bootIndex") == "0" {
+		writeBadRequest(w, "the system disk %s cannot be detached from a running server", volumeID)
+		return
+	}
+
+	s.removeServerVolumeLocked(serverID, volumeID)
+	jobID := s.newJobLocked("detachVolume", map[string]interface{}{
+		"server_id": serverID,
+		"volume_id": volumeID,
+	})
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"job_id": jobID})
+}
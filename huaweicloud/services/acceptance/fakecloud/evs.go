@@ -0,0 +1,316 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	kindVolume = "cloudvolumes"
+	kindJob    = "jobs"
+)
+
+func registerEVS(s *Server, rt *router) {
+	rt.handle("POST", "/v2.1/{project_id}/cloudvolumes", s.createVolumes)
+	rt.handle("POST", "/v2.1/{project_id}/cloudvolumes/{volume_id}/action", s.volumeAction)
+	rt.handle("GET", "/v2/{project_id}/cloudvolumes/detail", s.listVolumes)
+	rt.handle("GET", "/v2/{project_id}/cloudvolumes/{volume_id}", s.getVolume)
+	rt.handle("PUT", "/v2/{project_id}/cloudvolumes/{volume_id}", s.updateVolume)
+	rt.handle("DELETE", "/v2/{project_id}/cloudvolumes/{volume_id}", s.deleteVolume)
+	rt.handle("GET", "/v1/{project_id}/jobs/{job_id}", s.getJob)
+
+	registerTags(s, rt)
+}
+
+// putVolumeLocked stores a volume of the request, which is in the format of the EVS or ECS API.
+func (s *Server) putVolumeLocked(projectID, name, volumeType, availabilityZone string, size int,
+	body map[string]interface{}) map[string]interface{} {
+	metadata, _ := body["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	epsID := getString(body, "enterprise_project_id")
+	if epsID == "" {
+		epsID = defaultEpsID
+	}
+
+	volume := s.putLocked(kindVolume, map[string]interface{}{
+		"name":                         name,
+		"description":                  getString(body, "description"),
+		"size":                         size,
+		"volume_type":                  volumeType,
+		"availability_zone":            availabilityZone,
+		"attachments":                  []interface{}{},
+		"metadata":                     metadata,
+		"multiattach":                  getBool(body, "multiattach", false),
+		"bootable":                     "false",
+		"encrypted":                    false,
+		"enterprise_project_id":        epsID,
+		"snapshot_id":                  getString(body, "snapshot_id"),
+		"service_type":                 "EVS",
+		"wwn":                          fmt.Sprintf("688860300%023d", len(s.resources[kindVolume])+1),
+		"os-vol-tenant-attr:tenant_id": projectID,
+		"created_at":                   now(),
+		"updated_at":                   now(),
+	})
+	id := volume["id"].(string)
+	for _, key := range []string{"iops", "throughput"} {
+		volume[key] = map[string]interface{}{
+			"id":        newID(),
+			"total_val": getInt(body, key),
+			"volume_id": id,
+			"frozened":  false,
+		}
+	}
+
+	tags := make(map[string]string)
+	if raw, ok := body["tags"].(map[string]interface{}); ok {
+		for k, v := range raw {
+			tags[k], _ = v.(string)
+		}
+	}
+	s.setTagsLocked(kindVolume, id, tags)
+	return volume
+}
+
+// volumeViewLocked returns the volume with the status and tags.
+func (s *Server) volumeViewLocked(id string) map[string]interface{} {
+	volume, _ := s.getLocked(kindVolume, id)
+	if attachments, _ := volume["attachments"].([]interface{}); len(attachments) > 0 {
+		volume["status"] = "in-use"
+	} else {
+		volume["status"] = "available"
+	}
+	volume["tags"] = s.tagsLocked(kindVolume, id)
+	volume["links"] = []interface{}{}
+	return volume
+}
+
+func (s *Server) createVolumes(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+	opts, ok := body["volume"].(map[string]interface{})
+	if !ok {
+		writeBadRequest(w, "volume is missing in the request body")
+		return
+	}
+	if bssParam, _ := body["bssParam"].(map[string]interface{}); getString(bssParam, "chargingMode") == "prePaid" {
+		writeBadRequest(w, "the prePaid volumes are not supported by the fake cloud")
+		return
+	}
+
+	size := getInt(opts, "size")
+	if size <= 0 {
+		writeBadRequest(w, "the size of volume is missing")
+		return
+	}
+	if getString(opts, "availability_zone") == "" || getString(opts, "volume_type") == "" {
+		writeBadRequest(w, "availability_zone and volume_type are required")
+		return
+	}
+	count := getInt(opts, "count")
+	if count <= 0 {
+		count = 1
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	volumeIDs := make([]interface{}, count)
+	subJobs := make([]interface{}, count)
+	for i := 0; i < count; i++ {
+		name := getString(opts, "name")
+		if count > 1 {
+			name = fmt.Sprintf("%s-%04d", name, i+1)
+		}
+		volume := s.putVolumeLocked(params["project_id"], name, getString(opts, "volume_type"),
+			getString(opts, "availability_zone"), size, opts)
+		volumeIDs[i] = volume["id"]
+		subJobs[i] = map[string]interface{}{
+			"job_id":   newID(),
+			"job_type": "createSingleVolume",
+			"status":   "SUCCESS",
+			"entities": map[string]interface{}{"volume_id": volume["id"]},
+		}
+	}
+
+	entities := map[string]interface{}{
+		"name":        getString(opts, "name"),
+		"size":        size,
+		"volume_type": getString(opts, "volume_type"),
+		"sub_jobs":    subJobs,
+	}
+	if count == 1 {
+		entities["volume_id"] = volumeIDs[0]
+	}
+	jobID := s.newJobLocked("batchCreateVolumes", entities)
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"job_id":     jobID,
+		"volume_ids": volumeIDs,
+	})
+}
+
+func (s *Server) listVolumes(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	volumes := s.listLocked(kindVolume, func(obj map[string]interface{}) bool {
+		return (query.Get("name") == "" || query.Get("name") == obj["name"]) &&
+			(query.Get("availability_zone") == "" || query.Get("availability_zone") == obj["availability_zone"])
+	})
+	result := make([]interface{}, len(volumes))
+	for i, volume := range volumes {
+		result[i] = s.volumeViewLocked(volume["id"].(string))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"volumes": pageItems(result, query),
+		"count":   len(result),
+	})
+}
+
+func (s *Server) getVolume(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["volume_id"]
+	if !s.existsLocked(kindVolume, id) {
+		writeNotFound(w, "volume", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volume": s.volumeViewLocked(id)})
+}
+
+func (s *Server) updateVolume(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "volume")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["volume_id"]
+	ok := s.updateLocked(kindVolume, id, func(volume map[string]interface{}) {
+		mergeFields(volume, body, "name", "description")
+		volume["updated_at"] = now()
+	})
+	if !ok {
+		writeNotFound(w, "volume", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volume": s.volumeViewLocked(id)})
+}
+
+// volumeAction only supports expanding the capacity of volume.
+func (s *Server) volumeAction(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "os-extend")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["volume_id"]
+	volume, ok := s.getLocked(kindVolume, id)
+	if !ok {
+		writeNotFound(w, "volume", id)
+		return
+	}
+	newSize := getInt(body, "new_size")
+	if newSize <= getInt(volume, "size") {
+		writeBadRequest(w, "the new size %d must be greater than the current size %d", newSize,
+			getInt(volume, "size"))
+		return
+	}
+
+	s.updateLocked(kindVolume, id, func(volume map[string]interface{}) {
+		volume["size"] = newSize
+		volume["updated_at"] = now()
+	})
+	jobID := s.newJobLocked("extendVolume", map[string]interface{}{
+		"volume_id": id,
+		"size":      newSize,
+	})
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"job_id": jobID})
+}
+
+func (s *Server) deleteVolume(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["volume_id"]
+	volume, ok := s.getLocked(kindVolume, id)
+	if !ok {
+		writeNotFound(w, "volume", id)
+		return
+	}
+	if attachments, _ := volume["attachments"].([]interface{}); len(attachments) > 0 {
+		writeBadRequest(w, "the volume %s is in use and cannot be deleted", id)
+		return
+	}
+
+	s.deleteLocked(kindVolume, id)
+	writeJSON(w, http.StatusOK, nil)
+}
+
+// getJob returns the job of ECS or EVS, all jobs are completed when they are created.
+func (s *Server) getJob(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["job_id"]
+	job, ok := s.getLocked(kindJob, id)
+	if !ok {
+		writeNotFound(w, "job", id)
+		return
+	}
+	delete(job, "id")
+	writeJSON(w, http.StatusOK, job)
+}
+
+// attachVolumeLocked attaches the volume to the server, the ID of the attachment is the same as the volume ID.
+func (s *Server) attachVolumeLocked(volumeID, serverID, device string) error {
+	volume, ok := s.getLocked(kindVolume, volumeID)
+	if !ok {
+		return fmt.Errorf("the volume %s does not exist", volumeID)
+	}
+	attachments, _ := volume["attachments"].([]interface{})
+	if len(attachments) > 0 && !getBool(volume, "multiattach", false) {
+		return fmt.Errorf("the volume %s is in use", volumeID)
+	}
+
+	s.updateLocked(kindVolume, volumeID, func(volume map[string]interface{}) {
+		volume["attachments"] = append(attachments, map[string]interface{}{
+			"id":            volumeID,
+			"attachment_id": newID(),
+			"volume_id":     volumeID,
+			"server_id":     serverID,
+			"device":        device,
+			"host_name":     "",
+			"attached_at":   now(),
+		})
+	})
+	return nil
+}
+
+func (s *Server) detachVolumeLocked(volumeID, serverID string) {
+	s.updateLocked(kindVolume, volumeID, func(volume map[string]interface{}) {
+		attachments, _ := volume["attachments"].([]interface{})
+		result := make([]interface{}, 0, len(attachments))
+		for _, item := range attachments {
+			if attachment, ok := item.(map[string]interface{}); ok && attachment["server_id"] != serverID {
+				result = append(result, attachment)
+			}
+		}
+		volume["attachments"] = result
+	})
+}
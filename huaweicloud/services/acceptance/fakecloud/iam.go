@@ -0,0 +1,119 @@
+package fakecloud
+
+import (
+	"net/http"
+	"sort"
+	"time"
+)
+
+func registerIAM(s *Server, rt *router) {
+	rt.handle("POST", "/v3/auth/tokens", s.createToken)
+	rt.handle("GET", "/v3/auth/domains", s.listDomains)
+	rt.handle("GET", "/v3/auth/projects", s.listProjects)
+	rt.handle("GET", "/v3/projects", s.listProjects)
+}
+
+func (s *Server) domain() map[string]interface{} {
+	return map[string]interface{}{
+		"id":      s.DomainID,
+		"name":    DomainName,
+		"enabled": true,
+	}
+}
+
+func (s *Server) project(name, id string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          id,
+		"name":        name,
+		"domain_id":   s.DomainID,
+		"parent_id":   s.DomainID,
+		"enabled":     true,
+		"is_domain":   false,
+		"description": "",
+	}
+}
+
+// createToken issues a token of the scope in the request, the password and other authentication methods are not
+// verified.
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, err := readBody(r, "auth")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	issuedAt := time.Now().UTC()
+	token := map[string]interface{}{
+		"methods":    []string{"password"},
+		"issued_at":  issuedAt.Format(time.RFC3339Nano),
+		"expires_at": issuedAt.Add(24 * time.Hour).Format(time.RFC3339Nano),
+		"user": map[string]interface{}{
+			"id":     s.DomainID,
+			"name":   DomainName,
+			"domain": s.domain(),
+		},
+		"catalog": []interface{}{},
+		"roles":   []interface{}{},
+	}
+
+	scope, _ := body["scope"].(map[string]interface{})
+	if project, ok := scope["project"].(map[string]interface{}); ok {
+		name, _ := project["name"].(string)
+		id, _ := project["id"].(string)
+
+		s.mu.Lock()
+		for projectName, projectID := range s.projects {
+			if projectID == id {
+				name = projectName
+			}
+		}
+		if name == "" {
+			name = DefaultRegion
+		}
+		id = s.projectIDLocked(name)
+		s.mu.Unlock()
+
+		projectScope := s.project(name, id)
+		projectScope["domain"] = s.domain()
+		token["project"] = projectScope
+	} else {
+		token["domain"] = s.domain()
+	}
+
+	w.Header().Set("X-Subject-Token", newID())
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"token": token})
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"domains": []interface{}{s.domain()},
+		"links":   map[string]interface{}{},
+	})
+}
+
+// listProjects returns the projects filtered by name, the project of a region is created when it is queried.
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name := r.URL.Query().Get("name"); name != "" {
+		s.projectIDLocked(name)
+	}
+
+	names := make([]string, 0, len(s.projects))
+	for name := range s.projects {
+		if filter := r.URL.Query().Get("name"); filter == "" || filter == name {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	projects := make([]interface{}, len(names))
+	for i, name := range names {
+		projects[i] = s.project(name, s.projects[name])
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"projects": projects,
+		"links":    map[string]interface{}{},
+	})
+}
@@ -0,0 +1,179 @@
+package fakecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// handlerFunc handles the request of a route, the params are the values of path placeholders.
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+// router dispatches the requests by method and path, the placeholders (e.g. {vpc_id}) in the patterns match any
+// single segment. The routes are matched in order of registration, so the literal paths should be registered before
+// the placeholders of the same position.
+type router struct {
+	service string
+	routes  []route
+}
+
+func newRouter(service string) *router {
+	return &router{service: service}
+}
+
+func (rt *router) handle(method, pattern string, handler handlerFunc) {
+	rt.routes = append(rt.routes, route{
+		method:   method,
+		segments: splitPath(pattern),
+		handler:  handler,
+	})
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the clients may join the endpoint and path with duplicate slashes
+	segments := splitPath(r.URL.Path)
+	for _, route := range rt.routes {
+		if route.method != r.Method {
+			continue
+		}
+		if params, ok := matchSegments(route.segments, segments); ok {
+			log.Printf("[DEBUG] fake %s service handles %s %s", rt.service, r.Method, r.URL.Path)
+			route.handler(w, r, params)
+			return
+		}
+	}
+
+	log.Printf("[WARN] fake %s service does not support %s %s", rt.service, r.Method, r.URL.Path)
+	writeError(w, http.StatusNotImplemented, "APIGW.0101",
+		fmt.Sprintf("the API %s %s is not supported by the fake %s service", r.Method, r.URL.Path, rt.service))
+}
+
+func splitPath(p string) []string {
+	cleaned := strings.Trim(path.Clean("/"+p), "/")
+	if cleaned == "" {
+		return nil
+	}
+	return strings.Split(cleaned, "/")
+}
+
+func matchSegments(pattern, segments []string) (map[string]string, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, p := range pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			params[strings.Trim(p, "{}")] = segments[i]
+			continue
+		}
+		if p != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", newID())
+	w.WriteHeader(statusCode)
+	if body == nil {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("[WARN] failed to write the response of fake cloud: %s", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error_code": code,
+		"error_msg":  message,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, "Common.0404", fmt.Sprintf("the %s %s could not be found", kind, id))
+}
+
+func writeBadRequest(w http.ResponseWriter, format string, a ...interface{}) {
+	writeError(w, http.StatusBadRequest, "Common.0400", fmt.Sprintf(format, a...))
+}
+
+// readBody parses the JSON body of request, the object of key is returned if key is not empty.
+func readBody(r *http.Request, key string) (map[string]interface{}, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	body := make(map[string]interface{})
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, fmt.Errorf("the request body is not a valid JSON: %s", err)
+		}
+	}
+	if key == "" {
+		return body, nil
+	}
+
+	obj, ok := body[key].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is missing in the request body", key)
+	}
+	return obj, nil
+}
+
+func getString(obj map[string]interface{}, key string) string {
+	v, _ := obj[key].(string)
+	return v
+}
+
+func getBool(obj map[string]interface{}, key string, defaultValue bool) bool {
+	if v, ok := obj[key].(bool); ok {
+		return v
+	}
+	return defaultValue
+}
+
+func getInt(obj map[string]interface{}, key string) int {
+	// the numbers are decoded as float64
+	v, _ := obj[key].(float64)
+	return int(v)
+}
+
+// mergeFields copies the values of keys which are present in src to dst.
+func mergeFields(dst, src map[string]interface{}, keys ...string) {
+	for _, key := range keys {
+		if v, ok := src[key]; ok {
+			dst[key] = v
+		}
+	}
+}
+
+// pageItems returns the page of items specified by the offset and limit of query, which is used by the list APIs
+// paged by offset, e.g. the EVS disks.
+func pageItems(items []interface{}, query url.Values) []interface{} {
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if offset >= len(items) {
+		return []interface{}{}
+	}
+	items = items[offset:]
+	if limit, _ := strconv.Atoi(query.Get("limit")); limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
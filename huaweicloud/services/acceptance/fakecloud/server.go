@@ -0,0 +1,295 @@
+// Package fakecloud provides an in-process fake of the IAM, VPC, ECS and EVS APIs which are called by the
+// services/vpc, services/ecs and services/evs packages. The resources are kept in memory and the asynchronous jobs
+// complete immediately, so that the create/read/update/delete/import tests of these packages can run without a
+// cloud account.
+package fakecloud
+
+import (
+	"encoding/json"
+	"log"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const (
+	// DefaultRegion is the region used by Configure if the region of config is empty
+	DefaultRegion = "cn-north-4"
+	// DomainName is the name of the account of fake cloud
+	DomainName = "fake-domain"
+	// AccessKey and SecretKey are the credentials set by Configure, any credentials are accepted by the fake cloud
+	AccessKey = "fake-access-key"
+	SecretKey = "fake-secret-key"
+
+	timeLayout = "2006-01-02T15:04:05Z"
+)
+
+// the services of fake cloud, ims and dns have no API implemented, they are served so that the optional requests
+// (e.g. querying the image name of an instance) fail fast instead of being sent to the real cloud
+var serviceRegistrations = map[string]func(s *Server, rt *router){
+	"iam": registerIAM,
+	"vpc": registerVPC,
+	"ecs": registerECS,
+	"evs": registerEVS,
+	"ims": nil,
+	"dns": nil,
+}
+
+// Server is the fake cloud, each service is served by an individual HTTP server on the loopback interface as the
+// paths of different services may be the same, e.g. /v1/{project_id}/jobs/{job_id} of ECS and EVS.
+type Server struct {
+	// DomainID is the ID of the account of fake cloud
+	DomainID string
+
+	services map[string]*httptest.Server
+
+	mu sync.Mutex
+	// projects is the map of project name (the same as the region) and project ID
+	projects map[string]string
+	// resources is the map of kind (e.g. vpcs) and the resources of this kind keyed by the ID
+	resources map[string]map[string]map[string]interface{}
+	// tags is the map of resource ("{kind}/{id}") and the tags of the resource
+	tags map[string]map[string]string
+}
+
+// NewServer starts the fake cloud, the caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		DomainID:  newID(),
+		services:  make(map[string]*httptest.Server, len(serviceRegistrations)),
+		projects:  make(map[string]string),
+		resources: make(map[string]map[string]map[string]interface{}),
+		tags:      make(map[string]map[string]string),
+	}
+
+	for service, register := range serviceRegistrations {
+		rt := newRouter(service)
+		if register != nil {
+			register(s, rt)
+		}
+		s.services[service] = httptest.NewServer(rt)
+	}
+	return s
+}
+
+// Close shuts down the servers of all services.
+func (s *Server) Close() {
+	for _, srv := range s.services {
+		srv.Close()
+	}
+}
+
+// URL returns the base URL of the service, e.g. http://127.0.0.1:12345, or an empty string if the service is not
+// supported.
+func (s *Server) URL(service string) string {
+	if srv, ok := s.services[service]; ok {
+		return srv.URL
+	}
+	return ""
+}
+
+// Configure points the identity endpoint and the customizing endpoints of the supported services to the fake cloud,
+// and replaces the credentials with the fake AK/SK. It is used as the configure hook of the provider.
+func (s *Server) Configure(c *config.Config) {
+	configure(c, s.URL)
+}
+
+// HostAliases returns the alias hosts of the services keyed by the addresses of the servers, which are used to record
+// the cassettes against the fake cloud, e.g. 127.0.0.1:12345 -> vpc.fakecloud.test
+func (s *Server) HostAliases() map[string]string {
+	aliases := make(map[string]string, len(s.services))
+	for service, srv := range s.services {
+		aliases[srv.Listener.Addr().String()] = aliasHost(service)
+	}
+	return aliases
+}
+
+// ConfigureReplay is the configure hook to replay the cassettes recorded against the fake cloud, the endpoints are
+// pointed to the alias hosts which are never resolved, as the requests are not sent in replay mode.
+func ConfigureReplay(c *config.Config) {
+	configure(c, func(service string) string {
+		return "http://" + aliasHost(service)
+	})
+}
+
+func aliasHost(service string) string {
+	return service + ".fakecloud.test"
+}
+
+// configure overrides the configuration of provider with the fake credentials and the base URLs of the services.
+func configure(c *config.Config, baseURL func(service string) string) {
+	if c.Region == "" {
+		c.Region = DefaultRegion
+	}
+	// the project of each region is created on demand, and the name of it is the same as the region
+	c.TenantName = c.Region
+	c.TenantID = ""
+	c.DomainID = ""
+	c.DomainName = ""
+	c.DelegatedProject = ""
+
+	c.AccessKey = AccessKey
+	c.SecretKey = SecretKey
+	c.SecurityToken = ""
+	c.Token = ""
+	c.Username = ""
+	c.UserID = ""
+	c.Password = ""
+	c.AssumeRoles = nil
+	c.WebIdentity = nil
+	c.SharedConfigFile = ""
+	c.Profile = ""
+	c.CredentialProcess = ""
+	c.DiscoveryCacheFile = ""
+
+	c.IdentityEndpoint = baseURL("iam") + "/v3"
+	c.RegionEndpoints = nil
+	if c.Endpoints == nil {
+		c.Endpoints = make(map[string]string)
+	}
+	for service := range serviceRegistrations {
+		endpoint := baseURL(service) + "/"
+		c.Endpoints[service] = endpoint
+		for _, key := range config.GetServiceDerivedCatalogKeys(service) {
+			c.Endpoints[key] = endpoint
+		}
+	}
+}
+
+// ProjectID returns the ID of the project of region, the project is created if it does not exist.
+func (s *Server) ProjectID(region string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.projectIDLocked(region)
+}
+
+func (s *Server) projectIDLocked(region string) string {
+	if id, ok := s.projects[region]; ok {
+		return id
+	}
+
+	id := newID()
+	s.projects[region] = id
+	return id
+}
+
+// Resource returns a copy of the resource, it is used by the tests to check the state of fake cloud.
+func (s *Server) Resource(kind, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.getLocked(kind, id)
+}
+
+func (s *Server) getLocked(kind, id string) (map[string]interface{}, bool) {
+	obj, ok := s.resources[kind][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(obj), true
+}
+
+func (s *Server) existsLocked(kind, id string) bool {
+	_, ok := s.resources[kind][id]
+	return ok
+}
+
+// putLocked stores the resource, the ID of it is generated if missing.
+func (s *Server) putLocked(kind string, obj map[string]interface{}) map[string]interface{} {
+	id, _ := obj["id"].(string)
+	if id == "" {
+		id = newID()
+		obj["id"] = id
+	}
+
+	if _, ok := s.resources[kind]; !ok {
+		s.resources[kind] = make(map[string]map[string]interface{})
+	}
+	s.resources[kind][id] = obj
+	return obj
+}
+
+// updateLocked modifies the stored resource in place by fn, false is returned if the resource does not exist.
+func (s *Server) updateLocked(kind, id string, fn func(obj map[string]interface{})) bool {
+	obj, ok := s.resources[kind][id]
+	if !ok {
+		return false
+	}
+	fn(obj)
+	return true
+}
+
+func (s *Server) deleteLocked(kind, id string) bool {
+	if !s.existsLocked(kind, id) {
+		return false
+	}
+	delete(s.resources[kind], id)
+	delete(s.tags, tagKey(kind, id))
+	return true
+}
+
+// listLocked returns the copies of the resources which match the filter, the result is sorted by the creation time.
+func (s *Server) listLocked(kind string, filter func(obj map[string]interface{}) bool) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(s.resources[kind]))
+	for _, obj := range s.resources[kind] {
+		if filter == nil || filter(obj) {
+			result = append(result, copyObject(obj))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		ci, _ := result[i]["created_at"].(string)
+		cj, _ := result[j]["created_at"].(string)
+		if ci != cj {
+			return ci < cj
+		}
+		return result[i]["id"].(string) < result[j]["id"].(string)
+	})
+	return result
+}
+
+func (s *Server) newJobLocked(jobType string, entities map[string]interface{}) string {
+	now := time.Now().UTC().Format(timeLayout)
+	job := s.putLocked("jobs", map[string]interface{}{
+		"job_type":   jobType,
+		"status":     "SUCCESS",
+		"entities":   entities,
+		"begin_time": now,
+		"end_time":   now,
+	})
+	job["job_id"] = job["id"]
+	return job["id"].(string)
+}
+
+func newID() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		log.Printf("[WARN] failed to generate the UUID: %s", err)
+	}
+	return id
+}
+
+func now() string {
+	return time.Now().UTC().Format(timeLayout)
+}
+
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		log.Printf("[WARN] failed to copy the object of fake cloud: %s", err)
+		return obj
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		log.Printf("[WARN] failed to copy the object of fake cloud: %s", err)
+		return obj
+	}
+	return result
+}
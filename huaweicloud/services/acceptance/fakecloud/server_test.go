@@ -0,0 +1,255 @@
+package fakecloud_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/fakecloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/ecs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/evs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/vpc"
+)
+
+func newFakeConfig(t *testing.T) (*acceptance.FakeCloud, *config.Config) {
+	fake := acceptance.NewFakeCloud(t)
+	provider, err := fake.ProviderFactories()["huaweicloud"]()
+	if err != nil {
+		t.Fatalf("error creating the provider: %s", err)
+	}
+
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"region": "cn-south-1",
+	}))
+	if diags.HasError() {
+		t.Fatalf("error configuring the provider: %v", diags)
+	}
+	return fake, fake.Config()
+}
+
+func TestFakeCloud_configure(t *testing.T) {
+	t.Parallel()
+
+	fake, cfg := newFakeConfig(t)
+
+	if cfg.AccessKey != fakecloud.AccessKey || cfg.SecretKey != fakecloud.SecretKey {
+		t.Errorf("the credentials are not replaced by the fake cloud")
+	}
+	if cfg.DomainID != fake.DomainID {
+		t.Errorf("expected the domain ID %s, but got %s", fake.DomainID, cfg.DomainID)
+	}
+	if projectID := cfg.GetProjectID("cn-south-1"); projectID != fake.ProjectID("cn-south-1") {
+		t.Errorf("expected the project ID %s, but got %s", fake.ProjectID("cn-south-1"), projectID)
+	}
+	for _, service := range []string{"vpc", "networkv2", "vpcv3", "ecs", "evs"} {
+		if cfg.Endpoints[service] == "" {
+			t.Errorf("the endpoint of %s is not pointed to the fake cloud", service)
+		}
+	}
+}
+
+func TestFakeCloud_vpcAndSubnet(t *testing.T) {
+	t.Parallel()
+
+	fake, cfg := newFakeConfig(t)
+
+	vpcLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceVirtualPrivateCloudV1(), Meta: cfg}
+	defer vpcLifecycle.Destroy(t)
+	vpcData := vpcLifecycle.Apply(t, map[string]interface{}{
+		"name": "vpc-fake",
+		"cidr": "192.168.0.0/16",
+		"tags": map[string]interface{}{"foo": "bar"},
+	})
+	if name := vpcData.Get("name"); name != "vpc-fake" {
+		t.Errorf("expected the VPC name vpc-fake, but got %v", name)
+	}
+	if tags := vpcData.Get("tags").(map[string]interface{}); tags["foo"] != "bar" {
+		t.Errorf("expected the VPC tags {foo: bar}, but got %v", tags)
+	}
+
+	vpcData = vpcLifecycle.Apply(t, map[string]interface{}{
+		"name":        "vpc-fake-update",
+		"cidr":        "192.168.0.0/16",
+		"description": "updated by the fake cloud",
+		"tags":        map[string]interface{}{"foo": "baz", "key": "value"},
+	})
+	if name := vpcData.Get("name"); name != "vpc-fake-update" {
+		t.Errorf("expected the VPC name vpc-fake-update, but got %v", name)
+	}
+	if tags := vpcData.Get("tags").(map[string]interface{}); len(tags) != 2 || tags["foo"] != "baz" {
+		t.Errorf("expected the VPC tags {foo: baz, key: value}, but got %v", tags)
+	}
+	vpcLifecycle.ImportStateVerify(t)
+
+	subnetLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceVpcSubnetV1(), Meta: cfg}
+	defer subnetLifecycle.Destroy(t)
+	subnetData := subnetLifecycle.Apply(t, map[string]interface{}{
+		"name":       "subnet-fake",
+		"cidr":       "192.168.0.0/24",
+		"gateway_ip": "192.168.0.1",
+		"vpc_id":     vpcLifecycle.ID(),
+	})
+	if subnetID := subnetData.Get("ipv4_subnet_id"); subnetID == "" {
+		t.Errorf("the IPv4 subnet ID is not set")
+	}
+
+	subnetData = subnetLifecycle.Apply(t, map[string]interface{}{
+		"name":        "subnet-fake-update",
+		"cidr":        "192.168.0.0/24",
+		"gateway_ip":  "192.168.0.1",
+		"vpc_id":      vpcLifecycle.ID(),
+		"description": "updated by the fake cloud",
+	})
+	if name := subnetData.Get("name"); name != "subnet-fake-update" {
+		t.Errorf("expected the subnet name subnet-fake-update, but got %v", name)
+	}
+	subnetLifecycle.ImportStateVerify(t)
+
+	vpcID := vpcLifecycle.ID()
+	subnetLifecycle.Destroy(t)
+	vpcLifecycle.Destroy(t)
+	if _, ok := fake.Resource("vpcs", vpcID); ok {
+		t.Errorf("the VPC %s still exists", vpcID)
+	}
+}
+
+func TestFakeCloud_secGroup(t *testing.T) {
+	t.Parallel()
+
+	fake, cfg := newFakeConfig(t)
+
+	lifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceNetworkingSecGroup(), Meta: cfg}
+	defer lifecycle.Destroy(t)
+	d := lifecycle.Apply(t, map[string]interface{}{
+		"name":        "secgroup-fake",
+		"description": "created by the fake cloud",
+	})
+	if rules := d.Get("rules").([]interface{}); len(rules) != 4 {
+		t.Errorf("expected 4 default rules, but got %d", len(rules))
+	}
+
+	d = lifecycle.Apply(t, map[string]interface{}{
+		"name":        "secgroup-fake-update",
+		"description": "updated by the fake cloud",
+	})
+	if description := d.Get("description"); description != "updated by the fake cloud" {
+		t.Errorf("expected the description updated by the fake cloud, but got %v", description)
+	}
+	lifecycle.ImportStateVerify(t, "delete_default_rules")
+
+	id := lifecycle.ID()
+	lifecycle.Destroy(t)
+	if _, ok := fake.Resource("security-groups", id); ok {
+		t.Errorf("the security group %s still exists", id)
+	}
+}
+
+func TestFakeCloud_volume(t *testing.T) {
+	t.Parallel()
+
+	fake, cfg := newFakeConfig(t)
+
+	lifecycle := acceptance.ResourceLifecycle{Resource: evs.ResourceEvsVolume(), Meta: cfg}
+	defer lifecycle.Destroy(t)
+	d := lifecycle.Apply(t, map[string]interface{}{
+		"name":              "volume-fake",
+		"availability_zone": "cn-south-1a",
+		"volume_type":       "SSD",
+		"size":              10,
+	})
+	if size := d.Get("size"); size != 10 {
+		t.Errorf("expected the volume size 10, but got %v", size)
+	}
+
+	d = lifecycle.Apply(t, map[string]interface{}{
+		"name":              "volume-fake-update",
+		"availability_zone": "cn-south-1a",
+		"volume_type":       "SSD",
+		"size":              20,
+	})
+	if size := d.Get("size"); size != 20 {
+		t.Errorf("expected the volume size 20, but got %v", size)
+	}
+	lifecycle.ImportStateVerify(t, "cascade")
+
+	id := lifecycle.ID()
+	lifecycle.Destroy(t)
+	if _, ok := fake.Resource("cloudvolumes", id); ok {
+		t.Errorf("the volume %s still exists", id)
+	}
+}
+
+func TestFakeCloud_computeInstance(t *testing.T) {
+	t.Parallel()
+
+	fake, cfg := newFakeConfig(t)
+
+	vpcLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceVirtualPrivateCloudV1(), Meta: cfg}
+	defer vpcLifecycle.Destroy(t)
+	vpcLifecycle.Apply(t, map[string]interface{}{
+		"name": "vpc-fake",
+		"cidr": "192.168.0.0/16",
+	})
+	subnetLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceVpcSubnetV1(), Meta: cfg}
+	defer subnetLifecycle.Destroy(t)
+	subnetLifecycle.Apply(t, map[string]interface{}{
+		"name":       "subnet-fake",
+		"cidr":       "192.168.0.0/24",
+		"gateway_ip": "192.168.0.1",
+		"vpc_id":     vpcLifecycle.ID(),
+	})
+	secGroupLifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceNetworkingSecGroup(), Meta: cfg}
+	defer secGroupLifecycle.Destroy(t)
+	secGroupLifecycle.Apply(t, map[string]interface{}{
+		"name": "secgroup-fake",
+	})
+
+	instanceConfig := func(name string, tags map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":               name,
+			"image_id":           "image-fake",
+			"flavor_id":          "s6.small.1",
+			"availability_zone":  "cn-south-1a",
+			"security_group_ids": []interface{}{secGroupLifecycle.ID()},
+			"system_disk_type":   "SSD",
+			"network": []interface{}{
+				map[string]interface{}{"uuid": subnetLifecycle.ID()},
+			},
+			"data_disks": []interface{}{
+				map[string]interface{}{"type": "SSD", "size": 20},
+			},
+			"tags": tags,
+		}
+	}
+
+	lifecycle := acceptance.ResourceLifecycle{Resource: ecs.ResourceComputeInstance(), Meta: cfg}
+	defer lifecycle.Destroy(t)
+	d := lifecycle.Apply(t, instanceConfig("ecs-fake", map[string]interface{}{"foo": "bar"}))
+	if ip := d.Get("access_ip_v4"); ip != "192.168.0.2" {
+		t.Errorf("expected the IP address 192.168.0.2, but got %v", ip)
+	}
+	if disks := d.Get("volume_attached").([]interface{}); len(disks) != 2 {
+		t.Errorf("expected 2 attached volumes, but got %d", len(disks))
+	}
+	if tags := d.Get("tags").(map[string]interface{}); tags["foo"] != "bar" {
+		t.Errorf("expected the instance tags {foo: bar}, but got %v", tags)
+	}
+
+	d = lifecycle.Apply(t, instanceConfig("ecs-fake-update", map[string]interface{}{"foo": "bar2"}))
+	if name := d.Get("name"); name != "ecs-fake-update" {
+		t.Errorf("expected the instance name ecs-fake-update, but got %v", name)
+	}
+	if tags := d.Get("tags").(map[string]interface{}); tags["foo"] != "bar2" {
+		t.Errorf("expected the instance tags {foo: bar2}, but got %v", tags)
+	}
+	lifecycle.ImportStateVerify(t, "stop_before_destroy", "delete_eip_on_termination", "data_disks")
+
+	id := lifecycle.ID()
+	lifecycle.Destroy(t)
+	if _, ok := fake.Resource("cloudservers", id); ok {
+		t.Errorf("the instance %s still exists", id)
+	}
+}
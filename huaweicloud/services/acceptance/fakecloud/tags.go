@@ -0,0 +1,118 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// registerTags registers the common tag APIs of the resources, e.g. /v2.0/{project_id}/vpcs/{vpc_id}/tags.
+func registerTags(s *Server, rt *router) {
+	rt.handle("GET", "/{version}/{project_id}/{kind}/{id}/tags", s.getTags)
+	rt.handle("POST", "/{version}/{project_id}/{kind}/{id}/tags/action", s.tagsAction)
+}
+
+func tagKey(kind, id string) string {
+	return fmt.Sprintf("%s/%s", kind, id)
+}
+
+func (s *Server) tagsLocked(kind, id string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range s.tags[tagKey(kind, id)] {
+		result[k] = v
+	}
+	return result
+}
+
+func (s *Server) setTagsLocked(kind, id string, tags map[string]string) {
+	key := tagKey(kind, id)
+	if _, ok := s.tags[key]; !ok {
+		s.tags[key] = make(map[string]string)
+	}
+	for k, v := range tags {
+		s.tags[key][k] = v
+	}
+}
+
+// tagListLocked returns the tags in the format of [{"key": "k", "value": "v"}], sorted by the key.
+func (s *Server) tagListLocked(kind, id string) []map[string]interface{} {
+	tags := s.tagsLocked(kind, id)
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]map[string]interface{}, len(keys))
+	for i, k := range keys {
+		result[i] = map[string]interface{}{
+			"key":   k,
+			"value": tags[k],
+		}
+	}
+	return result
+}
+
+// parseTagList converts the tags in the format of [{"key": "k", "value": "v"}] to a map.
+func parseTagList(raw interface{}) map[string]string {
+	result := make(map[string]string)
+	list, _ := raw.([]interface{})
+	for _, item := range list {
+		tag, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key, _ := tag["key"].(string)
+		value, _ := tag["value"].(string)
+		if key != "" {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func (s *Server) getTags(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kind, id := params["kind"], params["id"]
+	if !s.existsLocked(kind, id) {
+		writeNotFound(w, kind, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tags": s.tagListLocked(kind, id),
+	})
+}
+
+func (s *Server) tagsAction(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kind, id := params["kind"], params["id"]
+	if !s.existsLocked(kind, id) {
+		writeNotFound(w, kind, id)
+		return
+	}
+
+	tags := parseTagList(body["tags"])
+	switch body["action"] {
+	case "create":
+		s.setTagsLocked(kind, id, tags)
+	case "delete":
+		key := tagKey(kind, id)
+		for k := range tags {
+			delete(s.tags[key], k)
+		}
+	default:
+		writeBadRequest(w, "the tag action %v is not supported", body["action"])
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
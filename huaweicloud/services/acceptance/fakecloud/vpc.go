@@ -0,0 +1,764 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+)
+
+const (
+	kindVpc        = "vpcs"
+	kindSubnet     = "subnets"
+	kindPort       = "ports"
+	kindSecGroup   = "security-groups"
+	kindSecRule    = "security-group-rules"
+	defaultEpsID   = "0"
+	ipv4AnyAddress = "0.0.0.0/0"
+	ipv6AnyAddress = "::/0"
+)
+
+func registerVPC(s *Server, rt *router) {
+	// VPC v1
+	rt.handle("POST", "/v1/{project_id}/vpcs", s.createVpc)
+	rt.handle("GET", "/v1/{project_id}/vpcs", s.listVpcs)
+	rt.handle("GET", "/v1/{project_id}/vpcs/{vpc_id}", s.getVpc)
+	rt.handle("PUT", "/v1/{project_id}/vpcs/{vpc_id}", s.updateVpc)
+	rt.handle("DELETE", "/v1/{project_id}/vpcs/{vpc_id}", s.deleteVpc)
+	rt.handle("PUT", "/v1/{project_id}/vpcs/{vpc_id}/subnets/{subnet_id}", s.updateSubnet)
+	rt.handle("DELETE", "/v1/{project_id}/vpcs/{vpc_id}/subnets/{subnet_id}", s.deleteSubnet)
+	rt.handle("POST", "/v1/{project_id}/subnets", s.createSubnet)
+	rt.handle("GET", "/v1/{project_id}/subnets", s.listSubnets)
+	rt.handle("GET", "/v1/{project_id}/subnets/{subnet_id}", s.getSubnet)
+	rt.handle("GET", "/v1/{project_id}/ports", s.listPorts)
+	rt.handle("GET", "/v1/{project_id}/ports/{port_id}", s.getPort)
+	rt.handle("PUT", "/v1/{project_id}/ports/{port_id}", s.updatePort)
+	rt.handle("POST", "/v1/{project_id}/security-groups", s.createSecGroup)
+	rt.handle("GET", "/v1/{project_id}/security-groups", s.listSecGroups)
+	rt.handle("GET", "/v1/{project_id}/security-groups/{security_group_id}", s.getSecGroup)
+	rt.handle("DELETE", "/v1/{project_id}/security-groups/{security_group_id}", s.deleteSecGroup)
+	rt.handle("POST", "/v1/{project_id}/security-group-rules", s.createSecRule)
+	rt.handle("GET", "/v1/{project_id}/security-group-rules", s.listSecRules)
+	rt.handle("GET", "/v1/{project_id}/security-group-rules/{rule_id}", s.getSecRule)
+	rt.handle("DELETE", "/v1/{project_id}/security-group-rules/{rule_id}", s.deleteSecRule)
+
+	// VPC v2.0 (Neutron)
+	rt.handle("PUT", "/v2.0/security-groups/{security_group_id}", s.updateSecGroup)
+
+	// VPC v3
+	rt.handle("GET", "/v3/{project_id}/vpc/vpcs/{vpc_id}", s.getVpc)
+	rt.handle("PUT", "/v3/{project_id}/vpc/vpcs/{vpc_id}/add-extend-cidr", s.addExtendCidrs)
+	rt.handle("PUT", "/v3/{project_id}/vpc/vpcs/{vpc_id}/remove-extend-cidr", s.removeExtendCidrs)
+	rt.handle("POST", "/v3/{project_id}/vpc/security-groups", s.createSecGroup)
+	rt.handle("GET", "/v3/{project_id}/vpc/security-groups", s.listSecGroups)
+	rt.handle("GET", "/v3/{project_id}/vpc/security-groups/{security_group_id}", s.getSecGroup)
+	rt.handle("PUT", "/v3/{project_id}/vpc/security-groups/{security_group_id}", s.updateSecGroup)
+	rt.handle("DELETE", "/v3/{project_id}/vpc/security-groups/{security_group_id}", s.deleteSecGroup)
+	rt.handle("POST", "/v3/{project_id}/vpc/security-group-rules", s.createSecRule)
+	rt.handle("GET", "/v3/{project_id}/vpc/security-group-rules", s.listSecRules)
+	rt.handle("GET", "/v3/{project_id}/vpc/security-group-rules/{rule_id}", s.getSecRule)
+	rt.handle("DELETE", "/v3/{project_id}/vpc/security-group-rules/{rule_id}", s.deleteSecRule)
+
+	registerTags(s, rt)
+}
+
+func (s *Server) createVpc(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "vpc")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+	if _, _, err := net.ParseCIDR(getString(body, "cidr")); err != nil {
+		writeBadRequest(w, "invalid CIDR %q of VPC", getString(body, "cidr"))
+		return
+	}
+
+	epsID := getString(body, "enterprise_project_id")
+	if epsID == "" {
+		epsID = defaultEpsID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vpc := s.putLocked(kindVpc, map[string]interface{}{
+		"name":                  getString(body, "name"),
+		"description":           getString(body, "description"),
+		"cidr":                  getString(body, "cidr"),
+		"extend_cidrs":          []interface{}{},
+		"enterprise_project_id": epsID,
+		"status":                "OK",
+		"routes":                []interface{}{},
+		"enable_shared_snat":    false,
+		"project_id":            params["project_id"],
+		"created_at":            now(),
+		"updated_at":            now(),
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": s.vpcViewLocked(vpc["id"].(string))})
+}
+
+func (s *Server) vpcViewLocked(id string) map[string]interface{} {
+	vpc, _ := s.getLocked(kindVpc, id)
+	vpc["tags"] = s.tagListLocked(kindVpc, id)
+	vpc["cloud_resources"] = []interface{}{
+		map[string]interface{}{
+			"resource_type":  "virsubnet",
+			"resource_count": len(s.listLocked(kindSubnet, matchField("vpc_id", id))),
+		},
+	}
+	return vpc
+}
+
+func (s *Server) listVpcs(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vpcs := s.listLocked(kindVpc, func(obj map[string]interface{}) bool {
+		return (query.Get("id") == "" || query.Get("id") == obj["id"]) &&
+			(query.Get("enterprise_project_id") == "" || query.Get("enterprise_project_id") == "all_granted_eps" ||
+				query.Get("enterprise_project_id") == obj["enterprise_project_id"])
+	})
+	result := make([]interface{}, len(vpcs))
+	for i, vpc := range vpcs {
+		result[i] = s.vpcViewLocked(vpc["id"].(string))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpcs": result})
+}
+
+func (s *Server) getVpc(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["vpc_id"]
+	if !s.existsLocked(kindVpc, id) {
+		writeNotFound(w, "VPC", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": s.vpcViewLocked(id)})
+}
+
+func (s *Server) updateVpc(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "vpc")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["vpc_id"]
+	ok := s.updateLocked(kindVpc, id, func(vpc map[string]interface{}) {
+		mergeFields(vpc, body, "name", "description", "cidr")
+		vpc["updated_at"] = now()
+	})
+	if !ok {
+		writeNotFound(w, "VPC", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": s.vpcViewLocked(id)})
+}
+
+func (s *Server) deleteVpc(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["vpc_id"]
+	if !s.existsLocked(kindVpc, id) {
+		writeNotFound(w, "VPC", id)
+		return
+	}
+	if len(s.listLocked(kindSubnet, matchField("vpc_id", id))) > 0 {
+		writeError(w, http.StatusConflict, "VPC.0021", fmt.Sprintf("the VPC %s still has subnets", id))
+		return
+	}
+
+	s.deleteLocked(kindVpc, id)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) addExtendCidrs(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.changeExtendCidrs(w, r, params, true)
+}
+
+func (s *Server) removeExtendCidrs(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.changeExtendCidrs(w, r, params, false)
+}
+
+func (s *Server) changeExtendCidrs(w http.ResponseWriter, r *http.Request, params map[string]string, add bool) {
+	body, err := readBody(r, "vpc")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+	cidrs, _ := body["extend_cidrs"].([]interface{})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["vpc_id"]
+	ok := s.updateLocked(kindVpc, id, func(vpc map[string]interface{}) {
+		existing, _ := vpc["extend_cidrs"].([]interface{})
+		result := make([]interface{}, 0, len(existing)+len(cidrs))
+		for _, cidr := range existing {
+			if add || !containsValue(cidrs, cidr) {
+				result = append(result, cidr)
+			}
+		}
+		if add {
+			for _, cidr := range cidrs {
+				if !containsValue(result, cidr) {
+					result = append(result, cidr)
+				}
+			}
+		}
+		vpc["extend_cidrs"] = result
+		vpc["updated_at"] = now()
+	})
+	if !ok {
+		writeNotFound(w, "VPC", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": s.vpcViewLocked(id)})
+}
+
+func (s *Server) createSubnet(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "subnet")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	cidr := getString(body, "cidr")
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		writeBadRequest(w, "invalid CIDR %q of subnet", cidr)
+		return
+	}
+	gatewayIP := getString(body, "gateway_ip")
+	if ip := net.ParseIP(gatewayIP); ip == nil || !ipNet.Contains(ip) {
+		writeBadRequest(w, "the gateway IP %q is not in the CIDR %s", gatewayIP, cidr)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vpcID := getString(body, "vpc_id")
+	if !s.existsLocked(kindVpc, vpcID) {
+		writeBadRequest(w, "the VPC %s does not exist", vpcID)
+		return
+	}
+
+	dnsList, _ := body["dnsList"].([]interface{})
+	if len(dnsList) == 0 {
+		for _, key := range []string{"primary_dns", "secondary_dns"} {
+			if dns := getString(body, key); dns != "" {
+				dnsList = append(dnsList, dns)
+			}
+		}
+	}
+	extraDhcpOpts, _ := body["extra_dhcp_opts"].([]interface{})
+	if extraDhcpOpts == nil {
+		extraDhcpOpts = []interface{}{}
+	}
+
+	subnet := s.putLocked(kindSubnet, map[string]interface{}{
+		"name":              getString(body, "name"),
+		"description":       getString(body, "description"),
+		"cidr":              cidr,
+		"gateway_ip":        gatewayIP,
+		"dhcp_enable":       getBool(body, "dhcp_enable", true),
+		"ipv6_enable":       getBool(body, "ipv6_enable", false),
+		"primary_dns":       getString(body, "primary_dns"),
+		"secondary_dns":     getString(body, "secondary_dns"),
+		"dnsList":           dnsList,
+		"availability_zone": getString(body, "availability_zone"),
+		"vpc_id":            vpcID,
+		"extra_dhcp_opts":   extraDhcpOpts,
+		"neutron_subnet_id": newID(),
+		"status":            "ACTIVE",
+		"tenant_id":         params["project_id"],
+		"created_at":        now(),
+		"updated_at":        now(),
+	})
+	// the ID of subnet is used as the network ID of Neutron
+	subnet["neutron_network_id"] = subnet["id"]
+
+	view, _ := s.getLocked(kindSubnet, subnet["id"].(string))
+	writeJSON(w, http.StatusOK, map[string]interface{}{"subnet": view})
+}
+
+func (s *Server) listSubnets(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	vpcID := r.URL.Query().Get("vpc_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subnets := s.listLocked(kindSubnet, func(obj map[string]interface{}) bool {
+		return vpcID == "" || obj["vpc_id"] == vpcID
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"subnets": subnets})
+}
+
+func (s *Server) getSubnet(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["subnet_id"]
+	subnet, ok := s.getLocked(kindSubnet, id)
+	if !ok {
+		writeNotFound(w, "subnet", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"subnet": subnet})
+}
+
+func (s *Server) updateSubnet(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "subnet")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["subnet_id"]
+	subnet, ok := s.getLocked(kindSubnet, id)
+	if !ok || subnet["vpc_id"] != params["vpc_id"] {
+		writeNotFound(w, "subnet", id)
+		return
+	}
+
+	s.updateLocked(kindSubnet, id, func(subnet map[string]interface{}) {
+		mergeFields(subnet, body, "name", "description", "dhcp_enable", "ipv6_enable", "primary_dns",
+			"secondary_dns", "dnsList", "extra_dhcp_opts")
+		subnet["updated_at"] = now()
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"subnet": map[string]interface{}{
+			"id":     id,
+			"status": "ACTIVE",
+		},
+	})
+}
+
+func (s *Server) deleteSubnet(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["subnet_id"]
+	subnet, ok := s.getLocked(kindSubnet, id)
+	if !ok || subnet["vpc_id"] != params["vpc_id"] {
+		writeNotFound(w, "subnet", id)
+		return
+	}
+	if len(s.listLocked(kindPort, matchField("network_id", id))) > 0 {
+		writeError(w, http.StatusConflict, "VPC.0300", fmt.Sprintf("the subnet %s still has ports in use", id))
+		return
+	}
+
+	s.deleteLocked(kindSubnet, id)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// createPortLocked creates a port in the subnet, the IP address is allocated if it is empty.
+func (s *Server) createPortLocked(subnetID, ipAddress, deviceID, deviceOwner string,
+	securityGroups []interface{}) (map[string]interface{}, error) {
+	subnet, ok := s.getLocked(kindSubnet, subnetID)
+	if !ok {
+		return nil, fmt.Errorf("the subnet %s does not exist", subnetID)
+	}
+
+	_, ipNet, err := net.ParseCIDR(getString(subnet, "cidr"))
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]bool{getString(subnet, "gateway_ip"): true}
+	for _, port := range s.listLocked(kindPort, matchField("network_id", subnetID)) {
+		for _, fixedIP := range port["fixed_ips"].([]interface{}) {
+			used[getString(fixedIP.(map[string]interface{}), "ip_address")] = true
+		}
+	}
+
+	if ipAddress == "" {
+		ipAddress = allocateIP(ipNet, used)
+		if ipAddress == "" {
+			return nil, fmt.Errorf("no available IP address in the subnet %s", subnetID)
+		}
+	} else if ip := net.ParseIP(ipAddress); ip == nil || !ipNet.Contains(ip) || used[ipAddress] {
+		return nil, fmt.Errorf("the IP address %s is invalid or in use", ipAddress)
+	}
+
+	if securityGroups == nil {
+		securityGroups = []interface{}{}
+	}
+	port := s.putLocked(kindPort, map[string]interface{}{
+		"name":           "",
+		"network_id":     subnetID,
+		"admin_state_up": true,
+		"mac_address":    newMACAddress(),
+		"fixed_ips": []interface{}{
+			map[string]interface{}{
+				"subnet_id":  subnet["neutron_subnet_id"],
+				"ip_address": ipAddress,
+			},
+		},
+		"device_id":             deviceID,
+		"device_owner":          deviceOwner,
+		"status":                "ACTIVE",
+		"security_groups":       securityGroups,
+		"allowed_address_pairs": []interface{}{},
+		"extra_dhcp_opts":       []interface{}{},
+		"port_security_enabled": true,
+		"created_at":            now(),
+	})
+	return copyObject(port), nil
+}
+
+// allocateIP returns the first unused host address of the network, the first 2 addresses are reserved.
+func allocateIP(ipNet *net.IPNet, used map[string]bool) string {
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return ""
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	size := 1 << uint(bits-ones)
+	for i := 2; i < size-1; i++ {
+		candidate := make(net.IP, len(ip))
+		copy(candidate, ip)
+		offset := i
+		for j := len(candidate) - 1; j >= 0 && offset > 0; j-- {
+			sum := int(candidate[j]) + offset
+			candidate[j] = byte(sum % 256)
+			offset = sum / 256
+		}
+		if !used[candidate.String()] {
+			return candidate.String()
+		}
+	}
+	return ""
+}
+
+func newMACAddress() string {
+	id := newID()
+	return fmt.Sprintf("fa:16:3e:%s:%s:%s", id[0:2], id[2:4], id[4:6])
+}
+
+func (s *Server) listPorts(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ports := s.listLocked(kindPort, func(obj map[string]interface{}) bool {
+		return (query.Get("device_id") == "" || query.Get("device_id") == obj["device_id"]) &&
+			(query.Get("network_id") == "" || query.Get("network_id") == obj["network_id"])
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"ports": ports})
+}
+
+func (s *Server) getPort(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["port_id"]
+	port, ok := s.getLocked(kindPort, id)
+	if !ok {
+		writeNotFound(w, "port", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"port": port})
+}
+
+func (s *Server) updatePort(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "port")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["port_id"]
+	ok := s.updateLocked(kindPort, id, func(port map[string]interface{}) {
+		mergeFields(port, body, "name", "security_groups", "allowed_address_pairs", "extra_dhcp_opts")
+	})
+	if !ok {
+		writeNotFound(w, "port", id)
+		return
+	}
+	port, _ := s.getLocked(kindPort, id)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"port": port})
+}
+
+// createSecGroup creates a security group with the default rules, which allow all outbound traffic and the inbound
+// traffic from the instances in the same security group.
+func (s *Server) createSecGroup(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "security_group")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+	if getString(body, "name") == "" {
+		writeBadRequest(w, "the name of security group is missing")
+		return
+	}
+
+	epsID := getString(body, "enterprise_project_id")
+	if epsID == "" {
+		epsID = defaultEpsID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group := s.putLocked(kindSecGroup, map[string]interface{}{
+		"name":                  getString(body, "name"),
+		"description":           getString(body, "description"),
+		"vpc_id":                getString(body, "vpc_id"),
+		"enterprise_project_id": epsID,
+		"project_id":            params["project_id"],
+		"created_at":            now(),
+		"updated_at":            now(),
+	})
+	groupID := group["id"].(string)
+	for _, ethertype := range []string{"IPv4", "IPv6"} {
+		anyAddress := ipv4AnyAddress
+		if ethertype == "IPv6" {
+			anyAddress = ipv6AnyAddress
+		}
+		s.putSecRuleLocked(groupID, params["project_id"], map[string]interface{}{
+			"direction":       "ingress",
+			"ethertype":       ethertype,
+			"remote_group_id": groupID,
+		})
+		s.putSecRuleLocked(groupID, params["project_id"], map[string]interface{}{
+			"direction":        "egress",
+			"ethertype":        ethertype,
+			"remote_ip_prefix": anyAddress,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group": s.secGroupViewLocked(groupID)})
+}
+
+func (s *Server) secGroupViewLocked(id string) map[string]interface{} {
+	group, _ := s.getLocked(kindSecGroup, id)
+	group["security_group_rules"] = s.listLocked(kindSecRule, matchField("security_group_id", id))
+	return group
+}
+
+func (s *Server) listSecGroups(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := s.listLocked(kindSecGroup, func(obj map[string]interface{}) bool {
+		return (len(query["id"]) == 0 || containsString(query["id"], obj["id"])) &&
+			(len(query["name"]) == 0 || containsString(query["name"], obj["name"])) &&
+			(query.Get("vpc_id") == "" || query.Get("vpc_id") == obj["vpc_id"])
+	})
+	result := make([]interface{}, len(groups))
+	for i, group := range groups {
+		result[i] = s.secGroupViewLocked(group["id"].(string))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_groups": result})
+}
+
+func (s *Server) getSecGroup(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["security_group_id"]
+	if !s.existsLocked(kindSecGroup, id) {
+		writeNotFound(w, "security group", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group": s.secGroupViewLocked(id)})
+}
+
+func (s *Server) updateSecGroup(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "security_group")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["security_group_id"]
+	ok := s.updateLocked(kindSecGroup, id, func(group map[string]interface{}) {
+		mergeFields(group, body, "name", "description")
+		group["updated_at"] = now()
+	})
+	if !ok {
+		writeNotFound(w, "security group", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group": s.secGroupViewLocked(id)})
+}
+
+func (s *Server) deleteSecGroup(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["security_group_id"]
+	if !s.existsLocked(kindSecGroup, id) {
+		writeNotFound(w, "security group", id)
+		return
+	}
+	for _, port := range s.listLocked(kindPort, nil) {
+		if containsValue(port["security_groups"].([]interface{}), id) {
+			writeError(w, http.StatusConflict, "VPC.0602",
+				fmt.Sprintf("the security group %s is in use by port %s", id, port["id"]))
+			return
+		}
+	}
+
+	for _, rule := range s.listLocked(kindSecRule, matchField("security_group_id", id)) {
+		s.deleteLocked(kindSecRule, rule["id"].(string))
+	}
+	s.deleteLocked(kindSecGroup, id)
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) putSecRuleLocked(groupID, projectID string, fields map[string]interface{}) map[string]interface{} {
+	rule := map[string]interface{}{
+		"security_group_id":       groupID,
+		"description":             "",
+		"direction":               "",
+		"ethertype":               "IPv4",
+		"protocol":                "",
+		"port_range_min":          nil,
+		"port_range_max":          nil,
+		"multiport":               "",
+		"remote_ip_prefix":        "",
+		"remote_group_id":         "",
+		"remote_address_group_id": "",
+		"action":                  "allow",
+		"priority":                1,
+		"project_id":              projectID,
+		"created_at":              now(),
+		"updated_at":              now(),
+	}
+	for k, v := range fields {
+		rule[k] = v
+	}
+	return s.putLocked(kindSecRule, rule)
+}
+
+func (s *Server) createSecRule(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r, "security_group_rule")
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	direction := getString(body, "direction")
+	if direction != "ingress" && direction != "egress" {
+		writeBadRequest(w, "invalid direction %q of security group rule", direction)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groupID := getString(body, "security_group_id")
+	if !s.existsLocked(kindSecGroup, groupID) {
+		writeNotFound(w, "security group", groupID)
+		return
+	}
+
+	fields := map[string]interface{}{}
+	mergeFields(fields, body, "description", "direction", "ethertype", "protocol", "port_range_min",
+		"port_range_max", "multiport", "remote_ip_prefix", "remote_group_id", "remote_address_group_id", "action",
+		"priority")
+	// the port range of v1 API and the multiport of v3 API are converted to each other
+	if multiport := getString(body, "multiport"); multiport != "" {
+		var min, max int
+		if n, _ := fmt.Sscanf(multiport, "%d-%d", &min, &max); n == 2 {
+			fields["port_range_min"], fields["port_range_max"] = min, max
+		} else if n == 1 {
+			fields["port_range_min"], fields["port_range_max"] = min, min
+		}
+	} else if min, max := getInt(body, "port_range_min"), getInt(body, "port_range_max"); min > 0 {
+		if min == max {
+			fields["multiport"] = fmt.Sprintf("%d", min)
+		} else {
+			fields["multiport"] = fmt.Sprintf("%d-%d", min, max)
+		}
+	}
+
+	rule := s.putSecRuleLocked(groupID, params["project_id"], fields)
+	view, _ := s.getLocked(kindSecRule, rule["id"].(string))
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"security_group_rule": view})
+}
+
+func (s *Server) listSecRules(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	groupID := r.URL.Query().Get("security_group_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules := s.listLocked(kindSecRule, func(obj map[string]interface{}) bool {
+		return groupID == "" || obj["security_group_id"] == groupID
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group_rules": rules})
+}
+
+func (s *Server) getSecRule(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["rule_id"]
+	rule, ok := s.getLocked(kindSecRule, id)
+	if !ok {
+		writeNotFound(w, "security group rule", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group_rule": rule})
+}
+
+func (s *Server) deleteSecRule(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := params["rule_id"]
+	if !s.deleteLocked(kindSecRule, id) {
+		writeNotFound(w, "security group rule", id)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func matchField(key string, value interface{}) func(obj map[string]interface{}) bool {
+	return func(obj map[string]interface{}) bool {
+		return obj[key] == value
+	}
+}
+
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, value interface{}) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package fgs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/fgs"
+)
+
+func getReservedInstancesFunc(conf *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	client, err := conf.FgsV2Client(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating FunctionGraph v2 client: %s", err)
+	}
+	return fgs.GetFunctionReservedInstances(client, state.Primary.Attributes["function_urn"],
+		state.Primary.Attributes["qualifier_type"], state.Primary.Attributes["qualifier_name"])
+}
+
+func TestAccFunctionReservedInstances_basic(t *testing.T) {
+	var (
+		reservedInstances interface{}
+
+		name  = acceptance.RandomAccResourceName()
+		rName = "huaweicloud_fgs_function_reserved_instances.test"
+	)
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&reservedInstances,
+		getReservedInstancesFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFunctionReservedInstances_basic_step1(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(rName, "function_urn", "huaweicloud_fgs_function.test", "urn"),
+					resource.TestCheckResourceAttr(rName, "qualifier_type", "alias"),
+					resource.TestCheckResourceAttr(rName, "qualifier_name", "demo"),
+					resource.TestCheckResourceAttr(rName, "min_count", "1"),
+					resource.TestCheckResourceAttr(rName, "idle_mode", "false"),
+				),
+			},
+			{
+				Config: testAccFunctionReservedInstances_basic_step2(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "min_count", "2"),
+					resource.TestCheckResourceAttr(rName, "idle_mode", "true"),
+					resource.TestCheckResourceAttr(rName, "tactics_config.0.cron_configs.#", "1"),
+					resource.TestCheckResourceAttr(rName, "tactics_config.0.cron_configs.0.name", "scale-out"),
+					resource.TestCheckResourceAttr(rName, "tactics_config.0.cron_configs.0.count", "3"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// the scheduled policies are removed
+				Config: testAccFunctionReservedInstances_basic_step1(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "min_count", "1"),
+					resource.TestCheckResourceAttr(rName, "tactics_config.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccFunctionReservedInstances_base(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_fgs_function" "test" {
+  functiongraph_version = "v2"
+  name                  = "%[1]s"
+  app                   = "default"
+  handler               = "index.handler"
+  memory_size           = 128
+  timeout               = 3
+  runtime               = "Python2.7"
+  code_type             = "inline"
+  func_code             = "dCA9ICdIZWxsbyBtZXNzYWdlOiAnICsganN="
+
+  versions {
+    name = "latest"
+
+    aliases {
+      name = "demo"
+    }
+  }
+}
+`, name)
+}
+
+func testAccFunctionReservedInstances_basic_step1(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_fgs_function_reserved_instances" "test" {
+  function_urn   = huaweicloud_fgs_function.test.urn
+  qualifier_type = "alias"
+  qualifier_name = "demo"
+  min_count      = 1
+}
+`, testAccFunctionReservedInstances_base(name))
+}
+
+func testAccFunctionReservedInstances_basic_step2(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_fgs_function_reserved_instances" "test" {
+  function_urn   = huaweicloud_fgs_function.test.urn
+  qualifier_type = "alias"
+  qualifier_name = "demo"
+  min_count      = 2
+  idle_mode      = true
+
+  tactics_config {
+    cron_configs {
+      name         = "scale-out"
+      cron         = "0 */10 * * * ?"
+      count        = 3
+      start_time   = 1704067200
+      expired_time = 4102444800
+    }
+  }
+}
+`, testAccFunctionReservedInstances_base(name))
+}
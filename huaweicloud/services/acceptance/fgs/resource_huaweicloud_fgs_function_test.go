@@ -2,6 +2,8 @@ package fgs
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -88,13 +90,94 @@ func TestAccFgsV2Function_basic(t *testing.T) {
 					"func_code",
 					"xrole",
 					"agency",
-					"tags",
 				},
 			},
 		},
 	})
 }
 
+func TestAccFgsV2Function_source(t *testing.T) {
+	var (
+		f            function.Function
+		randName     = acceptance.RandomAccResourceName()
+		resourceName = "huaweicloud_fgs_function.test"
+		sourceDir    = t.TempDir()
+		sourceHash   string
+	)
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&f,
+		getResourceObj,
+	)
+
+	writeSource := func(output string) {
+		content := fmt.Sprintf("def handler(event, context):\n    return '%s'\n", output)
+		if err := os.WriteFile(filepath.Join(sourceDir, "index.py"), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeSource("hello")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFgsV2Function_source(randName, sourceDir),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "code_type", "obs"),
+					resource.TestCheckResourceAttr(resourceName, "code_filename", "index.zip"),
+					resource.TestCheckResourceAttrSet(resourceName, "code_url"),
+					resource.TestCheckResourceAttrSet(resourceName, "published_version"),
+					resource.TestCheckResourceAttrWith(resourceName, "source_code_hash", func(value string) error {
+						sourceHash = value
+						return nil
+					}),
+				),
+			},
+			{
+				PreConfig: func() { writeSource("hello again") },
+				Config:    testAccFgsV2Function_source(randName, sourceDir),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrWith(resourceName, "source_code_hash", func(value string) error {
+						if value == sourceHash {
+							return fmt.Errorf("the source_code_hash should be changed after the source is changed")
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccFgsV2Function_source(rName, sourceDir string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%[1]s"
+  acl           = "private"
+  force_destroy = true
+}
+
+resource "huaweicloud_fgs_function" "test" {
+  name              = "%[1]s"
+  app               = "default"
+  handler           = "index.handler"
+  memory_size       = 128
+  timeout           = 3
+  runtime           = "Python3.9"
+  agency            = "function_vpc_trust"
+  source_dir        = "%[2]s"
+  source_bucket     = huaweicloud_obs_bucket.test.bucket
+  source_object_key = "%[1]s/index.zip"
+}
+`, rName, sourceDir)
+}
+
 func TestAccFgsV2Function_withEpsId(t *testing.T) {
 	var f function.Function
 	randName := acceptance.RandomAccResourceName()
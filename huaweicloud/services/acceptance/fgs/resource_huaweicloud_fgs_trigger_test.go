@@ -66,6 +66,12 @@ func TestAccFunctionGraphTrigger_basic(t *testing.T) {
 						"${huaweicloud_fgs_function.test.urn}"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccTriggerImportStateFunc(resourceName),
+			},
 		},
 	})
 }
@@ -114,6 +120,12 @@ func TestAccFunctionGraphTrigger_cronTimer(t *testing.T) {
 						"${huaweicloud_fgs_function.test.urn}"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccTriggerImportStateFunc(resourceName),
+			},
 		},
 	})
 }
@@ -146,6 +158,12 @@ func TestAccFunctionGraphTrigger_smn(t *testing.T) {
 						"${huaweicloud_fgs_function.test.urn}"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccTriggerImportStateFunc(resourceName),
+			},
 		},
 	})
 }
@@ -177,10 +195,30 @@ func TestAccFunctionGraphTrigger_lts(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "lts.0.log_topic_id"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccTriggerImportStateFunc(resourceName),
+			},
 		},
 	})
 }
 
+func testAccTriggerImportStateFunc(rsName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[rsName]
+		if !ok {
+			return "", fmt.Errorf("resource (%s) not found", rsName)
+		}
+		if rs.Primary.Attributes["function_urn"] == "" {
+			return "", fmt.Errorf("missing some attributes, want '{function_urn}/{id}', but '%s/%s'",
+				rs.Primary.Attributes["function_urn"], rs.Primary.ID)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["function_urn"], rs.Primary.ID), nil
+	}
+}
+
 func testAccFunctionGraphTimingTrigger_base(rName string) string {
 	//nolint:revive
 	return fmt.Sprintf(`
@@ -66,6 +66,14 @@ func TestAccIdentityAccessKey_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "description", "access key by terraform updated"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"secret_file", "pgp_key", "secret", "key_fingerprint", "encrypted_secret", "user_name",
+				},
+			},
 		},
 	})
 }
@@ -48,6 +48,11 @@ func TestAccIdentityGroupMembership_basic(t *testing.T) {
 					testAccCheckIdentityGroupMembershipExists(resourceName, []string{userName2}),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
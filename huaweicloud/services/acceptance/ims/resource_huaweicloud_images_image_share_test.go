@@ -101,10 +101,37 @@ func TestAccImsImageShare_basic(t *testing.T) {
 						"huaweicloud_images_image.test", "id"),
 				),
 			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImageShareImportStateFunc(rName),
+			},
 		},
 	})
 }
 
+func testAccImageShareImportStateFunc(rsName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[rsName]
+		if !ok {
+			return "", fmt.Errorf("resource (%s) not found", rsName)
+		}
+
+		projectIds := make([]string, 0)
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "target_project_ids.") && k != "target_project_ids.#" {
+				projectIds = append(projectIds, v)
+			}
+		}
+		if rs.Primary.Attributes["source_image_id"] == "" || len(projectIds) == 0 {
+			return "", fmt.Errorf("missing some attributes, want '{source_image_id}/{project_id},...', but '%s/%s'",
+				rs.Primary.Attributes["source_image_id"], strings.Join(projectIds, ","))
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["source_image_id"], strings.Join(projectIds, ",")), nil
+	}
+}
+
 func testImsImageShare_basic(imageName string) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -0,0 +1,170 @@
+//nolint:revive
+package acceptance
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// ResourceLifecycle drives a resource through the same steps as resource.TestCase without the Terraform CLI: each
+// configuration is planned and applied, then the state is refreshed and planned again to make sure no changes are
+// left. It is used by the offline tests which run against the fake cloud or replay the cassettes, e.g.
+//
+//	vpc := acceptance.ResourceLifecycle{Resource: vpc.ResourceVirtualPrivateCloudV1(), Meta: cfg}
+//	vpc.Apply(t, map[string]interface{}{"name": "vpc-test", "cidr": "192.168.0.0/16"})
+//	vpc.Apply(t, map[string]interface{}{"name": "vpc-test-update", "cidr": "192.168.0.0/16"})
+//	vpc.ImportStateVerify(t)
+//	vpc.Destroy(t)
+type ResourceLifecycle struct {
+	Resource *schema.Resource
+	Meta     interface{}
+
+	state *terraform.InstanceState
+}
+
+// Apply creates or updates the resource with the raw configuration, and returns the refreshed state.
+func (l *ResourceLifecycle) Apply(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	ctx := context.Background()
+	cfg := terraform.NewResourceConfigRaw(raw)
+	if diags := l.Resource.Validate(cfg); diags.HasError() {
+		t.Fatalf("invalid configuration: %v", diags)
+	}
+
+	diff, err := l.Resource.Diff(ctx, l.state, cfg, l.Meta)
+	if err != nil {
+		t.Fatalf("error planning the resource: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		state, diags := l.Resource.Apply(ctx, l.state, diff, l.Meta)
+		if state != nil && state.ID != "" {
+			// the state is kept so that the resource can still be destroyed
+			l.state = state
+		}
+		if diags.HasError() {
+			t.Fatalf("error applying the resource: %v", diags)
+		}
+	}
+	l.refresh(t)
+
+	diff, err = l.Resource.Diff(ctx, l.state, cfg, l.Meta)
+	if err != nil {
+		t.Fatalf("error planning the resource after apply: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("the plan was not empty after apply: %v", diff.Attributes)
+	}
+	return l.Resource.Data(l.state)
+}
+
+// ImportStateVerify imports the resource by its ID, and checks the imported attributes are the same as the state
+// except the ignored ones, which are matched by prefix.
+func (l *ResourceLifecycle) ImportStateVerify(t *testing.T, ignore ...string) {
+	t.Helper()
+
+	if l.Resource.Importer == nil {
+		t.Fatalf("the resource does not support import")
+	}
+
+	ctx := context.Background()
+	d := l.Resource.Data(nil)
+	d.SetId(l.state.ID)
+	var results []*schema.ResourceData
+	var err error
+	if l.Resource.Importer.StateContext != nil {
+		results, err = l.Resource.Importer.StateContext(ctx, d, l.Meta)
+	} else {
+		//nolint:staticcheck // some resources still use the deprecated State function
+		results, err = l.Resource.Importer.State(d, l.Meta)
+	}
+	if err != nil {
+		t.Fatalf("error importing the resource %s: %s", l.state.ID, err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 imported resource, but got %d", len(results))
+	}
+
+	imported, diags := l.Resource.RefreshWithoutUpgrade(ctx, results[0].State(), l.Meta)
+	if diags.HasError() {
+		t.Fatalf("error reading the imported resource %s: %v", l.state.ID, diags)
+	}
+	if imported == nil {
+		t.Fatalf("the imported resource %s is not found", l.state.ID)
+	}
+
+	expected := filterAttributes(l.state.Attributes, ignore)
+	actual := filterAttributes(imported.Attributes, ignore)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("the imported attributes are different from the state:\nexpected: %v\nactual:   %v", expected, actual)
+	}
+}
+
+// Destroy deletes the resource, it does nothing if the resource is not created.
+func (l *ResourceLifecycle) Destroy(t *testing.T) {
+	t.Helper()
+
+	if l.state == nil {
+		return
+	}
+	_, diags := l.Resource.Apply(context.Background(), l.state, &terraform.InstanceDiff{Destroy: true}, l.Meta)
+	if diags.HasError() {
+		t.Fatalf("error destroying the resource %s: %v", l.state.ID, diags)
+	}
+	l.state = nil
+}
+
+// ID returns the ID of the resource, or an empty string if the resource is not created.
+func (l *ResourceLifecycle) ID() string {
+	if l.state == nil {
+		return ""
+	}
+	return l.state.ID
+}
+
+func (l *ResourceLifecycle) refresh(t *testing.T) {
+	t.Helper()
+
+	if l.state == nil {
+		t.Fatalf("the resource is not created")
+	}
+	state, diags := l.Resource.RefreshWithoutUpgrade(context.Background(), l.state, l.Meta)
+	if diags.HasError() {
+		t.Fatalf("error reading the resource %s: %v", l.state.ID, diags)
+	}
+	if state == nil {
+		t.Fatalf("the resource %s is not found after apply", l.state.ID)
+	}
+	l.state = state
+}
+
+// filterAttributes removes the ignored attributes and the timeouts, like ImportStateVerifyIgnore of TestStep. The
+// empty collections are removed too, as they are omitted or counted as zero depending on whether they are set.
+func filterAttributes(attributes map[string]string, ignore []string) map[string]string {
+	result := make(map[string]string, len(attributes))
+	for k, v := range attributes {
+		if strings.HasPrefix(k, "timeouts.") {
+			continue
+		}
+		if v == "0" && (strings.HasSuffix(k, ".#") || strings.HasSuffix(k, ".%")) {
+			continue
+		}
+
+		ignored := false
+		for _, prefix := range ignore {
+			if strings.HasPrefix(k, prefix) {
+				ignored = true
+				break
+			}
+		}
+		if !ignored {
+			result[k] = v
+		}
+	}
+	return result
+}
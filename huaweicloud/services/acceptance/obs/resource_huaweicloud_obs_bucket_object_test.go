@@ -1,6 +1,7 @@
 package obs
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"testing"
@@ -93,6 +94,54 @@ func TestAccObsBucketObject_content(t *testing.T) {
 	})
 }
 
+func TestAccObsBucketObject_multipart(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "huaweicloud_obs_bucket_object.object"
+
+	tmpFile, err := os.CreateTemp("", "tf-acc-obs-obj-multipart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	// write 3 MB data to the tempfile, which is uploaded in 3 parts
+	err = os.WriteFile(tmpFile.Name(), bytes.Repeat([]byte("0123456789abcdef"), 3*1024*1024/16), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckOBS(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckObsBucketObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObsBucketObjectConfig_multipart(rInt, tmpFile.Name(), "foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObsBucketObjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "size", "3145728"),
+					resource.TestCheckResourceAttr(resourceName, "cache_control", "max-age=3600"),
+					resource.TestCheckResourceAttr(resourceName, "content_disposition", "attachment"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.Owner", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key", "value"),
+					resource.TestCheckResourceAttrSet(resourceName, "etag"),
+				),
+			},
+			{
+				// update the metadata without uploading again
+				Config: testAccObsBucketObjectConfig_multipart(rInt, tmpFile.Name(), "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "size", "3145728"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.Owner", "bar"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckObsBucketObjectDestroy(s *terraform.State) error {
 	conf := acceptance.TestAccProvider.Meta().(*config.Config)
 	obsClient, err := conf.ObjectStorageClient(acceptance.HW_REGION_NAME)
@@ -238,3 +287,31 @@ encryption   = true
 }
 `, randInt, source)
 }
+
+func testAccObsBucketObjectConfig_multipart(randInt int, source, owner string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "object_bucket" {
+  bucket = "tf-acc-test-bucket-%[1]d"
+}
+
+resource "huaweicloud_obs_bucket_object" "object" {
+  bucket              = huaweicloud_obs_bucket.object_bucket.bucket
+  key                 = "test-key"
+  source              = "%[2]s"
+  source_hash         = filemd5("%[2]s")
+  cache_control       = "max-age=3600"
+  content_disposition = "attachment"
+  multipart_threshold = 1
+  part_size           = 1
+  parallel_parts      = 3
+
+  metadata = {
+    Owner = "%[3]s"
+  }
+
+  tags = {
+    key = "value"
+  }
+}
+`, randInt, source, owner)
+}
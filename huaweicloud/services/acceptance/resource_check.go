@@ -21,6 +21,7 @@ type ResourceCheck struct {
 	resourceObject  interface{}
 	getResourceFunc ServiceFunc
 	resourceType    string
+	configFunc      func() *config.Config
 }
 
 const (
@@ -64,6 +65,20 @@ func InitResourceCheck(rName string, rObject interface{}, getResourceFunc Servic
 	}
 }
 
+// WithConfigFunc sets the function which returns the client configuration used to query the resource, it is used
+// when the test runs with a provider other than TestAccProvider, e.g. the provider of FakeCloud or Cassette.
+func (rc *ResourceCheck) WithConfigFunc(fn func() *config.Config) *ResourceCheck {
+	rc.configFunc = fn
+	return rc
+}
+
+func (rc *ResourceCheck) config() *config.Config {
+	if rc.configFunc != nil {
+		return rc.configFunc()
+	}
+	return TestAccProvider.Meta().(*config.Config)
+}
+
 func parseVariableToName(variable string) (string, string, error) {
 	var name, field string
 
@@ -148,7 +163,7 @@ func (rc *ResourceCheck) CheckResourceDestroy() resource.TestCheckFunc {
 			return fmt.Errorf("the 'getResourceFunc' is nil, please set it during initialization")
 		}
 
-		conf := TestAccProvider.Meta().(*config.Config)
+		conf := rc.config()
 		for _, rs := range s.RootModule().Resources {
 			if rs.Type != resourceType {
 				continue
@@ -180,7 +195,7 @@ func (rc *ResourceCheck) checkResourceExists(s *terraform.State) error {
 		return fmt.Errorf("the 'getResourceFunc' is nil, please set it during initialization")
 	}
 
-	conf := TestAccProvider.Meta().(*config.Config)
+	conf := rc.config()
 	r, err := rc.getResourceFunc(conf, rs)
 	if err != nil {
 		return fmt.Errorf("checking resource %s %s exists error: %s ",
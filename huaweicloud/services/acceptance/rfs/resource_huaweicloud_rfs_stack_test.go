@@ -2,6 +2,8 @@ package rfs
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -62,9 +64,12 @@ func TestAccStack_basic(t *testing.T) { // the template file is json format.
 				),
 			},
 			{
+				// the template is deployed directly without the preview
 				Config: testAccStack_withBody_step2(name, updateTemplateInJsonFormat(name), variableContent),
 				Check: resource.ComposeTestCheckFunc(
 					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "execution_plan_name", ""),
+					resource.TestCheckResourceAttr(rName, "planned_changes.#", "0"),
 				),
 			},
 			{
@@ -75,6 +80,8 @@ func TestAccStack_basic(t *testing.T) { // the template file is json format.
 					"agency",
 					"template_body",
 					"vars_body",
+					"execution_plan_name",
+					"planned_changes",
 				},
 			},
 		},
@@ -175,12 +182,74 @@ func TestAccStack_withBody_HCL(t *testing.T) {
 					"agency",
 					"template_body",
 					"vars_body",
+					"execution_plan_name",
+					"planned_changes",
 				},
 			},
 		},
 	})
 }
 
+func TestAccStack_previewChanges(t *testing.T) {
+	var (
+		obj stacks.Stack
+
+		rName = "huaweicloud_rfs_stack.test"
+		name  = acceptance.RandomAccResourceNameWithDash()
+	)
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getStackesourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStack_previewChanges(name, basicTemplateInJsonFormat(name)),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "preview_changes", "true"),
+					resource.TestCheckResourceAttr(rName, "execution_plan_name", ""),
+				),
+			},
+			{
+				// the execution plan is created during plan and applied
+				Config: testAccStack_previewChanges(name, updateTemplateInJsonFormat(name)),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(rName, "execution_plan_name"),
+					resource.TestCheckResourceAttrSet(rName, "planned_changes.0.resource_type"),
+					resource.TestCheckResourceAttrSet(rName, "planned_changes.0.action"),
+				),
+			},
+		},
+	})
+}
+
+func testAccStack_previewChanges(name, template string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_rfs_stack" "test" {
+  name            = "%[1]s"
+  preview_changes = true
+
+  agency {
+    name          = "rf_admin_trust" // System RF agency
+    provider_name = "huaweicloud"
+  }
+
+  template_body = %[2]s
+  vars_body     = %[3]s
+}
+`, name, template, variableContent)
+}
+
 func TestAccStack_withUri_JSON(t *testing.T) {
 	var (
 		obj stacks.Stack
@@ -232,6 +301,8 @@ func TestAccStack_withUri_JSON(t *testing.T) {
 					"agency",
 					"template_uri",
 					"vars_uri",
+					"execution_plan_name",
+					"planned_changes",
 				},
 			},
 		},
@@ -371,6 +442,8 @@ func TestAccStack_withUri_HCL(t *testing.T) {
 					"agency",
 					"template_uri",
 					"vars_uri",
+					"execution_plan_name",
+					"planned_changes",
 				},
 			},
 		},
@@ -429,6 +502,8 @@ func TestAccStack_archive(t *testing.T) {
 					"agency",
 					"template_uri",
 					"vars_uri",
+					"execution_plan_name",
+					"planned_changes",
 				},
 			},
 		},
@@ -466,6 +541,156 @@ resource "huaweicloud_rfs_stack" "test" {
 `, name, acceptance.HW_RF_TEMPLATE_ARCHIVE_URI, acceptance.HW_RF_VARIABLES_ARCHIVE_URI)
 }
 
+func TestAccStack_templateDir(t *testing.T) {
+	var (
+		obj stacks.Stack
+
+		rName       = "huaweicloud_rfs_stack.test"
+		name        = acceptance.RandomAccResourceNameWithDash()
+		templateDir = t.TempDir()
+	)
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getStackesourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			writeStackTemplateFile(t, templateDir, basicTemplateFileInHclFormat(name))
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStack_templateDir(name, templateDir),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(rName, "template_hash"),
+					resource.TestCheckResourceAttrSet(rName, "template_uri"),
+					resource.TestCheckResourceAttr(rName, "outputs.vpc_name", name),
+				),
+			},
+			{
+				PreConfig: func() {
+					writeStackTemplateFile(t, templateDir, updateTemplateFileInHclFormat(name))
+				},
+				// the execution plan is created and applied after the archive is uploaded
+				Config: testAccStack_templateDir(name, templateDir),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(rName, "execution_plan_name"),
+					resource.TestCheckResourceAttr(rName, "planned_changes.#", "1"),
+					resource.TestCheckResourceAttr(rName, "planned_changes.0.resource_type", "huaweicloud_vpc_subnet"),
+					resource.TestCheckResourceAttr(rName, "planned_changes.0.resource_name", "test"),
+					resource.TestCheckResourceAttr(rName, "planned_changes.0.action", "ADD"),
+					resource.TestCheckResourceAttr(rName, "outputs.vpc_name", name),
+					resource.TestCheckResourceAttrSet(rName, "outputs.subnet_id"),
+				),
+			},
+		},
+	})
+}
+
+func writeStackTemplateFile(t *testing.T, templateDir, content string) {
+	if err := os.WriteFile(filepath.Join(templateDir, "main.tf"), []byte(content), 0600); err != nil {
+		t.Fatalf("error writing the template file: %s", err)
+	}
+}
+
+func testAccStack_templateDir(name, templateDir string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%[1]s"
+  acl           = "private"
+  force_destroy = true
+}
+
+resource "huaweicloud_obs_bucket_policy" "test" {
+  bucket = huaweicloud_obs_bucket.test.bucket
+  policy = <<EOT
+{
+  "Statement": [
+    {
+      "Sid": "RF-Access",
+      "Effect": "Allow",
+      "Principal": {
+        "ID": ["*"]
+      },
+      "Action": [
+        "GetObject"
+      ],
+      "Resource": [
+        "${huaweicloud_obs_bucket.test.bucket}/*"
+      ]
+    }
+  ]
+}
+EOT
+}
+
+resource "huaweicloud_rfs_stack" "test" {
+  name = "%[1]s"
+
+  agency {
+    name          = "rf_admin_trust" // System RF agency
+    provider_name = "huaweicloud"
+  }
+
+  template_dir    = "%[2]s"
+  template_bucket = huaweicloud_obs_bucket.test.bucket
+  preview_changes = true
+
+  depends_on = [huaweicloud_obs_bucket_policy.test]
+}
+`, name, templateDir)
+}
+
+func basicTemplateFileInHclFormat(name string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    huaweicloud = {
+      source  = "huawei.com/provider/huaweicloud"
+      version = ">= 1.41.0"
+    }
+  }
+}
+
+provider "huaweicloud" {
+  region = "%[1]s"
+}
+
+resource "huaweicloud_vpc" "test" {
+  name = "%[2]s"
+  cidr = "192.168.0.0/16"
+}
+
+output "vpc_name" {
+  value = huaweicloud_vpc.test.name
+}
+`, acceptance.HW_REGION_NAME, name)
+}
+
+func updateTemplateFileInHclFormat(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_vpc_subnet" "test" {
+  vpc_id     = huaweicloud_vpc.test.id
+  name       = "%[2]s"
+  cidr       = cidrsubnet(huaweicloud_vpc.test.cidr, 4, 1)
+  gateway_ip = cidrhost(cidrsubnet(huaweicloud_vpc.test.cidr, 4, 1), 1)
+}
+
+output "subnet_id" {
+  value = huaweicloud_vpc_subnet.test.id
+}
+`, basicTemplateFileInHclFormat(name), name)
+}
+
 func basicTemplateInJsonFormat(name string) string {
 	return fmt.Sprintf(`<<EOT
 {
@@ -0,0 +1,69 @@
+package rms
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDatasourceAdvancedQueryResults_basic(t *testing.T) {
+	name := acceptance.RandomAccResourceName()
+	rName := "data.huaweicloud_rms_advanced_query_results.test"
+	byQueryId := "data.huaweicloud_rms_advanced_query_results.query_id_filter"
+	dc := acceptance.InitDataSourceCheck(rName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceAdvancedQueryResults_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "select_fields.#", "2"),
+					resource.TestCheckResourceAttrSet(rName, "results.0.id"),
+					resource.TestCheckResourceAttrSet(rName, "results_json"),
+					resource.TestCheckResourceAttr(rName, "rows.0.columns.0.name", "id"),
+					resource.TestCheckResourceAttr(rName, "rows.0.columns.0.type", "string"),
+					resource.TestCheckResourceAttrPair(rName, "rows.0.columns.0.string_value", rName, "results.0.id"),
+					resource.TestCheckResourceAttrPair(byQueryId, "results.#", rName, "results.#"),
+					resource.TestCheckOutput("vpc_is_found", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceAdvancedQueryResults_basic(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "huaweicloud_rms_advanced_query" "test" {
+  name       = "%[1]s"
+  expression = "select id, name from resources where provider = 'vpc' and type = 'vpcs'"
+}
+
+data "huaweicloud_rms_advanced_query_results" "test" {
+  expression = huaweicloud_rms_advanced_query.test.expression
+
+  depends_on = [huaweicloud_vpc.test]
+}
+
+data "huaweicloud_rms_advanced_query_results" "query_id_filter" {
+  query_id = huaweicloud_rms_advanced_query.test.id
+
+  depends_on = [huaweicloud_vpc.test]
+}
+
+output "vpc_is_found" {
+  value = contains([for v in jsondecode(data.huaweicloud_rms_advanced_query_results.test.results_json) : v.id],
+  huaweicloud_vpc.test.id)
+}
+`, name)
+}
@@ -0,0 +1,73 @@
+package rms
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDatasourceResources_basic(t *testing.T) {
+	name := acceptance.RandomAccResourceName()
+	rName := "data.huaweicloud_rms_resources.test"
+	dc := acceptance.InitDataSourceCheck(rName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceResources_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "resources.#", "1"),
+					resource.TestCheckResourceAttrPair(rName, "resources.0.id", "huaweicloud_vpc.test", "id"),
+					resource.TestCheckResourceAttr(rName, "resources.0.name", name),
+					resource.TestCheckResourceAttr(rName, "resources.0.provider_name", "vpc"),
+					resource.TestCheckResourceAttr(rName, "resources.0.type", "vpcs"),
+					resource.TestCheckResourceAttr(rName, "resources.0.tags.owner", name),
+					resource.TestCheckResourceAttrSet(rName, "resources.0.properties"),
+					resource.TestCheckOutput("provider_filter_is_useful", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceResources_basic(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "192.168.0.0/16"
+
+  tags = {
+    owner = "%[1]s"
+  }
+}
+
+data "huaweicloud_rms_resources" "test" {
+  provider_name = "vpc"
+  type          = "vpcs"
+
+  tags = {
+    owner = "%[1]s"
+  }
+
+  depends_on = [huaweicloud_vpc.test]
+}
+
+data "huaweicloud_rms_resources" "provider_filter" {
+  provider_name = "vpc"
+
+  depends_on = [huaweicloud_vpc.test]
+}
+
+output "provider_filter_is_useful" {
+  value = length(data.huaweicloud_rms_resources.provider_filter.resources) > 0 && alltrue(
+    [for v in data.huaweicloud_rms_resources.provider_filter.resources[*].provider_name : v == "vpc"]
+  )
+}
+`, name)
+}
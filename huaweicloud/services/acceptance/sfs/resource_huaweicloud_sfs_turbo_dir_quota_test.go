@@ -77,6 +77,12 @@ func TestAccSfsTurboDirQuota_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "inode", "30"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccSfsTurboImportStateFunc(resourceName, "path"),
+			},
 		},
 	})
 }
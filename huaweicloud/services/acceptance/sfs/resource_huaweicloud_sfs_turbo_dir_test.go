@@ -66,10 +66,41 @@ func TestAccSfsTurboDir_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "path", path),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccSfsTurboImportStateFunc(resourceName, "path"),
+				ImportStateVerifyIgnore: []string{
+					"mode", "uid", "gid",
+				},
+			},
 		},
 	})
 }
 
+// testAccSfsTurboImportStateFunc returns the import ID in the format '<share_id>/<attrName>', the ID of the
+// resource is used when the attrName is 'id'.
+func testAccSfsTurboImportStateFunc(rsName, attrName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[rsName]
+		if !ok {
+			return "", fmt.Errorf("resource (%s) not found", rsName)
+		}
+
+		shareId := rs.Primary.Attributes["share_id"]
+		attrValue := rs.Primary.Attributes[attrName]
+		if attrName == "id" {
+			attrValue = rs.Primary.ID
+		}
+		if shareId == "" || attrValue == "" {
+			return "", fmt.Errorf("missing some attributes, want '{share_id}/{%s}', but '%s/%s'", attrName, shareId,
+				attrValue)
+		}
+		return fmt.Sprintf("%s/%s", shareId, strings.TrimPrefix(attrValue, "/")), nil
+	}
+}
+
 func testSfsTurboDirBasic(rName string, path string) string {
 	return fmt.Sprintf(`
 %[1]s
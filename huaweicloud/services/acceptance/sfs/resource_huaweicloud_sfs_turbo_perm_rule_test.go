@@ -74,6 +74,12 @@ func TestAccSFSTruboPermRule_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "user_type", "root_squash"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccSfsTurboImportStateFunc(resourceName, "id"),
+			},
 		},
 	})
 }
@@ -94,10 +94,37 @@ func TestAccResourceTags_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(rName, "tags.creator", "terraform"),
 				),
 			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceTagsImportStateFunc(rName),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["tags.foo"] != "baar" {
+						return fmt.Errorf("the tags of the ER instance are not imported: %v", states)
+					}
+					return nil
+				},
+			},
 		},
 	})
 }
 
+func testAccResourceTagsImportStateFunc(rsName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[rsName]
+		if !ok {
+			return "", fmt.Errorf("resource (%s) not found", rsName)
+		}
+		resourceType := rs.Primary.Attributes["resources.0.resource_type"]
+		resourceId := rs.Primary.Attributes["resources.0.resource_id"]
+		if resourceType == "" || resourceId == "" {
+			return "", fmt.Errorf("missing some attributes, want '{project_id}/{resource_type}:{resource_id}', "+
+				"but '%s/%s:%s'", rs.Primary.Attributes["project_id"], resourceType, resourceId)
+		}
+		return fmt.Sprintf("%s/%s:%s", rs.Primary.Attributes["project_id"], resourceType, resourceId), nil
+	}
+}
+
 func testAccResourceTags_base() string {
 	var (
 		name         = acceptance.RandomAccResourceName()
@@ -12,6 +12,7 @@ import (
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/vpc"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
 )
 
@@ -58,6 +59,38 @@ func TestAccVpcV1_basic(t *testing.T) {
 	})
 }
 
+// TestVpcV1_cassette replays the steps of TestAccVpcV1_basic from the cassette recorded against the fake cloud.
+func TestVpcV1_cassette(t *testing.T) {
+	cassette := acceptance.NewFakeCloudCassette(t)
+	cfg := cassette.ConfigureProvider(t, map[string]interface{}{"region": "cn-north-4"})
+	rName := cassette.RandomAccResourceName()
+
+	lifecycle := acceptance.ResourceLifecycle{Resource: vpc.ResourceVirtualPrivateCloudV1(), Meta: cfg}
+	defer lifecycle.Destroy(t)
+
+	d := lifecycle.Apply(t, map[string]interface{}{
+		"name":        rName,
+		"cidr":        "192.168.0.0/16",
+		"description": "created by acc test",
+		"tags":        map[string]interface{}{"foo": "bar", "key": "value"},
+	})
+	if d.Get("status") != "OK" || d.Get("tags.key") != "value" {
+		t.Errorf("unexpected VPC after creation: status %v, tags %v", d.Get("status"), d.Get("tags"))
+	}
+
+	d = lifecycle.Apply(t, map[string]interface{}{
+		"name":        rName + "_updated",
+		"cidr":        "192.168.0.0/16",
+		"description": "updated by acc test",
+		"tags":        map[string]interface{}{"foo1": "bar", "key": "value_updated"},
+	})
+	if d.Get("name") != rName+"_updated" || d.Get("tags.key") != "value_updated" {
+		t.Errorf("unexpected VPC after update: name %v, tags %v", d.Get("name"), d.Get("tags"))
+	}
+
+	lifecycle.ImportStateVerify(t)
+}
+
 func TestAccVpcV1_secondaryCIDR(t *testing.T) {
 	var vpc vpcs.Vpc
 
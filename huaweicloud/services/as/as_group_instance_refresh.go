@@ -0,0 +1,393 @@
+package as
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/autoscaling/v1/groups"
+	"github.com/chnsz/golangsdk/openstack/autoscaling/v1/instances"
+)
+
+func instanceRefreshSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"min_healthy_percentage": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      90,
+					ValidateFunc: validation.IntBetween(0, 100),
+					Description:  "The percentage of the desired instances which must be in service during the refresh.",
+				},
+				"batch_size": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The maximum number of instances to be replaced in each batch.",
+				},
+				"instance_warmup": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The time to wait after the new instances are in service, in seconds.",
+				},
+				"checkpoint_percentages": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntBetween(1, 100)},
+					Description: "The percentages of the replaced instances at which the refresh pauses.",
+				},
+				"checkpoint_delay": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      300,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The time to pause at each checkpoint, in seconds.",
+				},
+				"auto_rollback": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Description: "Whether to restore the previous scaling configuration and replace the new instances " +
+						"when the refresh fails.",
+				},
+			},
+		},
+	}
+}
+
+// instanceRefreshOpts is the configuration of the rolling replacement of the instances in the AS group.
+type instanceRefreshOpts struct {
+	MinHealthyPercentage  int
+	BatchSize             int
+	InstanceWarmup        time.Duration
+	CheckpointPercentages []int
+	CheckpointDelay       time.Duration
+	AutoRollback          bool
+}
+
+func buildInstanceRefreshOpts(d *schema.ResourceData) *instanceRefreshOpts {
+	rawList := d.Get("instance_refresh").([]interface{})
+	if len(rawList) == 0 || rawList[0] == nil {
+		return nil
+	}
+
+	raw := rawList[0].(map[string]interface{})
+	checkpoints := make([]int, 0)
+	for _, v := range raw["checkpoint_percentages"].([]interface{}) {
+		checkpoints = append(checkpoints, v.(int))
+	}
+	sort.Ints(checkpoints)
+
+	return &instanceRefreshOpts{
+		MinHealthyPercentage:  raw["min_healthy_percentage"].(int),
+		BatchSize:             raw["batch_size"].(int),
+		InstanceWarmup:        time.Duration(raw["instance_warmup"].(int)) * time.Second,
+		CheckpointPercentages: checkpoints,
+		CheckpointDelay:       time.Duration(raw["checkpoint_delay"].(int)) * time.Second,
+		AutoRollback:          raw["auto_rollback"].(bool),
+	}
+}
+
+// getOutdatedInstances returns the in-service instances which are launched from the other scaling configurations.
+// The instances which are added manually or protected from scaling in are not replaced.
+func getOutdatedInstances(allIns []instances.Instance, configurationID string) []string {
+	outdated := make([]string, 0, len(allIns))
+	for _, ins := range allIns {
+		if ins.ID == "" || ins.ConfigurationID == "" || ins.ConfigurationID == configurationID {
+			continue
+		}
+		if ins.Protected {
+			log.Printf("[WARN] the instance %s is protected from scaling in, skip replacing it", ins.ID)
+			continue
+		}
+		outdated = append(outdated, ins.ID)
+	}
+	return outdated
+}
+
+// instanceRefreshBatch is the number of the outdated instances to be removed in a batch, and the number of the new
+// instances to be launched before they are removed.
+type instanceRefreshBatch struct {
+	Size  int
+	Surge int
+}
+
+// planInstanceRefreshBatch computes the next batch of the remaining outdated instances. The new instances are launched
+// first as far as the maximum number of instances allows, then the outdated instances are removed as long as the
+// in-service instances are not less than the minimum healthy percentage of the desired number, and the instances in
+// the group are not less than the minimum number, which is required by the removal.
+func planInstanceRefreshBatch(desireNum, minNum, maxNum, remaining int,
+	opts *instanceRefreshOpts) (instanceRefreshBatch, error) {
+	batchSize := opts.BatchSize
+	if batchSize > remaining {
+		batchSize = remaining
+	}
+
+	surge := maxNum - desireNum
+	if surge > batchSize {
+		surge = batchSize
+	}
+	if surge < 0 {
+		surge = 0
+	}
+
+	// the number of the instances which must be in service, rounded up
+	minHealthy := (desireNum*opts.MinHealthyPercentage + 99) / 100
+	// the outdated instances which can be removed before the new instances are in service
+	if unavailable := desireNum - minHealthy; batchSize-surge > unavailable {
+		batchSize = surge + unavailable
+	}
+	// the instances cannot be removed if the number of the instances would be less than the minimum number
+	if removable := desireNum + surge - minNum; batchSize > removable {
+		batchSize = removable
+	}
+
+	if batchSize <= 0 {
+		return instanceRefreshBatch{}, fmt.Errorf("unable to replace the instances without launching the new instances "+
+			"first (desire_instance_number: %d, min_instance_number: %d, max_instance_number: %d), please increase "+
+			"max_instance_number or decrease min_healthy_percentage", desireNum, minNum, maxNum)
+	}
+	return instanceRefreshBatch{Size: batchSize, Surge: surge}, nil
+}
+
+// passInstanceRefreshCheckpoints returns the index of the next checkpoint after the instances are replaced, and whether
+// the refresh pauses. The refresh pauses once even if several checkpoints are passed by a batch, and never pauses after
+// all instances are replaced.
+func passInstanceRefreshCheckpoints(checkpoints []int, index, replaced, total int) (int, bool) {
+	percentage := replaced * 100 / total
+	passed := false
+	for index < len(checkpoints) && checkpoints[index] <= percentage {
+		index++
+		passed = true
+	}
+	return index, passed && replaced < total
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(duration):
+		return nil
+	}
+}
+
+// updateASGroupCapacity updates the desired number of instances, the scaling configuration is also updated if it is
+// not empty.
+func updateASGroupCapacity(ctx context.Context, client *golangsdk.ServiceClient, group *groups.Group,
+	configurationID string, desireNum int, timeout time.Duration) error {
+	updateOpts := groups.UpdateOpts{
+		ConfigurationID:      configurationID,
+		DesireInstanceNumber: desireNum,
+		MinInstanceNumber:    group.MinInstanceNumber,
+		MaxInstanceNumber:    group.MaxInstanceNumber,
+	}
+	log.Printf("[DEBUG] AS group %s capacity update options: %#v", group.ID, updateOpts)
+
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		if _, err := groups.Update(client, group.ID, updateOpts).Extract(); err != nil {
+			if isRetryableError(err) {
+				// waiting for the scaling activity to complete and try again
+				if waitErr := waitASGroupInstancesInService(ctx, client, group.ID, "", timeout); waitErr != nil {
+					return resource.NonRetryableError(fmt.Errorf("the AS group %s is not INSERVICE: %s", group.ID,
+						waitErr))
+				}
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+}
+
+// refreshInstancesInService waits for the number of the instances to be the same as the expected number, the
+// instances waiting for the lifecycle hooks, e.g. PENDING_WAIT and REMOVING_WAIT, are pending.
+func refreshInstancesInService(client *golangsdk.ServiceClient, groupID string, insNum int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		allIns, err := getInstancesInGroup(client, groupID, nil)
+		if err != nil {
+			return nil, "ERROR", err
+		}
+		if len(allIns) != insNum {
+			return allIns, "PENDING", nil
+		}
+		for _, ins := range allIns {
+			if strings.Contains(ins.LifeCycleStatus, "ING") {
+				return allIns, "PENDING", nil
+			}
+		}
+		return allIns, "INSERVICE", nil
+	}
+}
+
+func waitForInstancesInService(ctx context.Context, client *golangsdk.ServiceClient, groupID string, insNum int,
+	timeout time.Duration) ([]instances.Instance, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"PENDING"},
+		Target:       []string{"INSERVICE"},
+		Refresh:      refreshInstancesInService(client, groupID, insNum),
+		Timeout:      timeout,
+		Delay:        10 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	resp, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.([]instances.Instance), nil
+}
+
+// checkNewInstancesHealthy returns an error if any new instance fails the health check.
+func checkNewInstancesHealthy(allIns []instances.Instance, oldIDs map[string]bool) error {
+	var unhealthy []string
+	for _, ins := range allIns {
+		if !oldIDs[ins.ID] && ins.HealthStatus == "ERROR" {
+			unhealthy = append(unhealthy, ins.ID)
+		}
+	}
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("the new instances %v failed the health check", unhealthy)
+	}
+	return nil
+}
+
+// replaceASGroupInstances replaces the instances which are not launched from the scaling configuration in batches.
+// In each batch, the new instances are launched first if the maximum number of instances allows, then the outdated
+// instances are removed, and the desired number of instances is restored. The number of the in-service instances is
+// never less than the minimum healthy percentage of the desired number.
+func replaceASGroupInstances(ctx context.Context, client *golangsdk.ServiceClient, groupID, configurationID string,
+	opts *instanceRefreshOpts, timeout time.Duration) error {
+	group, err := groups.Get(client, groupID).Extract()
+	if err != nil {
+		return fmt.Errorf("error retrieving AS group %s: %s", groupID, err)
+	}
+
+	desireNum := group.DesireInstanceNumber
+	allIns, err := waitForInstancesInService(ctx, client, groupID, desireNum, timeout)
+	if err != nil {
+		return fmt.Errorf("error waiting for the instances to be INSERVICE: %s", err)
+	}
+	outdated := getOutdatedInstances(allIns, configurationID)
+	if len(outdated) == 0 {
+		log.Printf("[DEBUG] all instances in AS group %s are launched from %s", groupID, configurationID)
+		return nil
+	}
+
+	checkpointIndex := 0
+	for replaced := 0; replaced < len(outdated); {
+		plan, err := planInstanceRefreshBatch(desireNum, group.MinInstanceNumber, group.MaxInstanceNumber,
+			len(outdated)-replaced, opts)
+		if err != nil {
+			return err
+		}
+
+		batch := outdated[replaced : replaced+plan.Size]
+		oldIDs := make(map[string]bool, len(allIns))
+		for _, ins := range allIns {
+			oldIDs[ins.ID] = true
+		}
+		log.Printf("[DEBUG] replacing the instances %v in AS group %s, launching %d instances first", batch, groupID,
+			plan.Surge)
+
+		if plan.Surge > 0 {
+			if err := updateASGroupCapacity(ctx, client, &group, "", desireNum+plan.Surge, timeout); err != nil {
+				return fmt.Errorf("error increasing the desired number of instances: %s", err)
+			}
+			if _, err := waitForInstancesInService(ctx, client, groupID, desireNum+plan.Surge, timeout); err != nil {
+				return fmt.Errorf("error waiting for the new instances to be INSERVICE: %s", err)
+			}
+		}
+
+		removeOpts := []instances.BatchOpts{
+			{
+				Action:      "REMOVE",
+				Instances:   batch,
+				IsDeleteEcs: "yes",
+			},
+		}
+		if err := doBatchAction(ctx, client, timeout, groupID, removeOpts); err != nil {
+			return fmt.Errorf("error removing the instances %v: %s", batch, err)
+		}
+		if err := updateASGroupCapacity(ctx, client, &group, "", desireNum, timeout); err != nil {
+			return fmt.Errorf("error restoring the desired number of instances: %s", err)
+		}
+		if _, err := waitForInstancesInService(ctx, client, groupID, desireNum, timeout); err != nil {
+			return fmt.Errorf("error waiting for the instances to be replaced: %s", err)
+		}
+
+		if err := sleepContext(ctx, opts.InstanceWarmup); err != nil {
+			return err
+		}
+		allIns, err = getInstancesInGroup(client, groupID, nil)
+		if err != nil {
+			return err
+		}
+		if err := checkNewInstancesHealthy(allIns, oldIDs); err != nil {
+			return err
+		}
+
+		replaced += plan.Size
+		log.Printf("[DEBUG] %d of %d outdated instances in AS group %s are replaced", replaced, len(outdated), groupID)
+		var pause bool
+		checkpointIndex, pause = passInstanceRefreshCheckpoints(opts.CheckpointPercentages, checkpointIndex, replaced,
+			len(outdated))
+		if pause {
+			log.Printf("[DEBUG] pausing the instance refresh of AS group %s at the checkpoint for %s", groupID,
+				opts.CheckpointDelay)
+			if err := sleepContext(ctx, opts.CheckpointDelay); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// refreshASGroupInstances rolls the existing instances to the new scaling configuration. If the refresh fails and the
+// auto rollback is enabled, the previous scaling configuration is restored and the new instances are replaced.
+func refreshASGroupInstances(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData,
+	opts *instanceRefreshOpts) error {
+	groupID := d.Id()
+	oldConfigID, newConfigID := d.GetChange("scaling_configuration_id")
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	refreshErr := replaceASGroupInstances(ctx, client, groupID, newConfigID.(string), opts, timeout)
+	if refreshErr == nil || !opts.AutoRollback || oldConfigID.(string) == "" {
+		return refreshErr
+	}
+
+	log.Printf("[WARN] the instance refresh of AS group %s failed, rolling back to %s: %s", groupID, oldConfigID,
+		refreshErr)
+	group, err := groups.Get(client, groupID).Extract()
+	if err != nil {
+		return fmt.Errorf("%s, and failed to roll back: error retrieving AS group: %s", refreshErr, err)
+	}
+	err = updateASGroupCapacity(ctx, client, &group, oldConfigID.(string), group.DesireInstanceNumber, timeout)
+	if err != nil {
+		return fmt.Errorf("%s, and failed to roll back: error restoring the scaling configuration: %s", refreshErr, err)
+	}
+
+	rollbackOpts := *opts
+	rollbackOpts.CheckpointPercentages = nil
+	if err := replaceASGroupInstances(ctx, client, groupID, oldConfigID.(string), &rollbackOpts, timeout); err != nil {
+		return fmt.Errorf("%s, and failed to roll back: %s", refreshErr, err)
+	}
+	return fmt.Errorf("%s, the scaling configuration is rolled back to %s", refreshErr, oldConfigID)
+}
@@ -0,0 +1,204 @@
+package as
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chnsz/golangsdk/openstack/autoscaling/v1/instances"
+)
+
+func TestGetOutdatedInstances(t *testing.T) {
+	allIns := []instances.Instance{
+		{ID: "ins-current", ConfigurationID: "config-new"},
+		{ID: "ins-outdated-1", ConfigurationID: "config-old"},
+		// the instance added manually
+		{ID: "ins-manual"},
+		{ID: "ins-protected", ConfigurationID: "config-old", Protected: true},
+		// the instance which is being launched
+		{ConfigurationID: "config-old"},
+		{ID: "ins-outdated-2", ConfigurationID: "config-older"},
+	}
+	cases := []struct {
+		name            string
+		configurationID string
+		expected        []string
+	}{
+		{
+			name:            "replace with the new configuration",
+			configurationID: "config-new",
+			expected:        []string{"ins-outdated-1", "ins-outdated-2"},
+		},
+		{
+			name:            "roll back to the old configuration",
+			configurationID: "config-old",
+			expected:        []string{"ins-current", "ins-outdated-2"},
+		},
+	}
+
+	for _, tc := range cases {
+		actual := getOutdatedInstances(allIns, tc.configurationID)
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected the outdated instances %v, but got %v", tc.name, tc.expected, actual)
+		}
+	}
+
+	if actual := getOutdatedInstances(nil, "config-new"); len(actual) != 0 {
+		t.Errorf("expected no outdated instances in the empty group, but got %v", actual)
+	}
+}
+
+func TestPlanInstanceRefreshBatch(t *testing.T) {
+	cases := []struct {
+		name                 string
+		desireNum            int
+		minNum               int
+		maxNum               int
+		remaining            int
+		minHealthyPercentage int
+		batchSize            int
+		expected             instanceRefreshBatch
+		isErr                bool
+	}{
+		{
+			name:      "launch all new instances first",
+			desireNum: 4, minNum: 0, maxNum: 8, remaining: 4,
+			minHealthyPercentage: 100, batchSize: 2,
+			expected: instanceRefreshBatch{Size: 2, Surge: 2},
+		},
+		{
+			name:      "batch is limited by the remaining instances",
+			desireNum: 4, minNum: 0, maxNum: 8, remaining: 1,
+			minHealthyPercentage: 100, batchSize: 2,
+			expected: instanceRefreshBatch{Size: 1, Surge: 1},
+		},
+		{
+			name:      "surge is limited by the maximum number",
+			desireNum: 4, minNum: 0, maxNum: 5, remaining: 4,
+			minHealthyPercentage: 50, batchSize: 3,
+			expected: instanceRefreshBatch{Size: 3, Surge: 1},
+		},
+		{
+			name:      "batch is limited by the minimum healthy percentage",
+			desireNum: 4, minNum: 0, maxNum: 5, remaining: 4,
+			minHealthyPercentage: 75, batchSize: 4,
+			expected: instanceRefreshBatch{Size: 2, Surge: 1},
+		},
+		{
+			// 3 * 90% is rounded up to 3, so no instance can be unavailable
+			name:      "minimum healthy instances are rounded up",
+			desireNum: 3, minNum: 0, maxNum: 4, remaining: 3,
+			minHealthyPercentage: 90, batchSize: 3,
+			expected: instanceRefreshBatch{Size: 1, Surge: 1},
+		},
+		{
+			name:      "remove without surge",
+			desireNum: 4, minNum: 0, maxNum: 4, remaining: 4,
+			minHealthyPercentage: 50, batchSize: 4,
+			expected: instanceRefreshBatch{Size: 2, Surge: 0},
+		},
+		{
+			name:      "maximum number is less than the desired number",
+			desireNum: 4, minNum: 0, maxNum: 3, remaining: 4,
+			minHealthyPercentage: 50, batchSize: 1,
+			expected: instanceRefreshBatch{Size: 1, Surge: 0},
+		},
+		{
+			name:      "no instance can be launched or unavailable",
+			desireNum: 4, minNum: 0, maxNum: 4, remaining: 4,
+			minHealthyPercentage: 100, batchSize: 1,
+			isErr: true,
+		},
+		{
+			// the removal without surge is limited as the instances cannot be less than the minimum number
+			name:      "remove without surge above the minimum number",
+			desireNum: 4, minNum: 3, maxNum: 4, remaining: 4,
+			minHealthyPercentage: 0, batchSize: 4,
+			expected: instanceRefreshBatch{Size: 1, Surge: 0},
+		},
+		{
+			name:      "remove without surge at the minimum number",
+			desireNum: 4, minNum: 4, maxNum: 4, remaining: 4,
+			minHealthyPercentage: 0, batchSize: 2,
+			isErr: true,
+		},
+		{
+			// the new instances are launched first, so the minimum number is not reached
+			name:      "remove with surge at the minimum number",
+			desireNum: 4, minNum: 4, maxNum: 5, remaining: 4,
+			minHealthyPercentage: 0, batchSize: 2,
+			expected: instanceRefreshBatch{Size: 1, Surge: 1},
+		},
+	}
+
+	for _, tc := range cases {
+		opts := &instanceRefreshOpts{
+			MinHealthyPercentage: tc.minHealthyPercentage,
+			BatchSize:            tc.batchSize,
+		}
+		actual, err := planInstanceRefreshBatch(tc.desireNum, tc.minNum, tc.maxNum, tc.remaining, opts)
+		if tc.isErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, but got the batch %+v", tc.name, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.name, err)
+			continue
+		}
+		if actual != tc.expected {
+			t.Errorf("%s: expected the batch %+v, but got %+v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestPassInstanceRefreshCheckpoints(t *testing.T) {
+	cases := []struct {
+		name          string
+		checkpoints   []int
+		index         int
+		replaced      int
+		total         int
+		expectedIndex int
+		expectedPause bool
+	}{
+		{name: "no checkpoints", checkpoints: nil, replaced: 1, total: 4},
+		{
+			name:        "checkpoint not reached",
+			checkpoints: []int{50}, replaced: 1, total: 4,
+		},
+		{
+			name:        "checkpoint reached",
+			checkpoints: []int{50}, replaced: 2, total: 4,
+			expectedIndex: 1, expectedPause: true,
+		},
+		{
+			name:        "checkpoint passed before",
+			checkpoints: []int{50}, index: 1, replaced: 3, total: 4,
+			expectedIndex: 1,
+		},
+		{
+			// the refresh pauses once for the checkpoints passed by the same batch
+			name:        "several checkpoints passed",
+			checkpoints: []int{20, 40, 80}, replaced: 2, total: 4,
+			expectedIndex: 2, expectedPause: true,
+		},
+		{
+			name:        "percentage is rounded down",
+			checkpoints: []int{34}, replaced: 1, total: 3,
+		},
+		{
+			name:        "no pause after the last batch",
+			checkpoints: []int{50, 100}, index: 1, replaced: 4, total: 4,
+			expectedIndex: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		index, pause := passInstanceRefreshCheckpoints(tc.checkpoints, tc.index, tc.replaced, tc.total)
+		if index != tc.expectedIndex || pause != tc.expectedPause {
+			t.Errorf("%s: expected the next checkpoint %d and pausing %t, but got %d and %t",
+				tc.name, tc.expectedIndex, tc.expectedPause, index, pause)
+		}
+	}
+}
@@ -42,6 +42,7 @@ func ResourceASGroup() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
@@ -210,6 +211,7 @@ func ResourceASGroup() *schema.Resource {
 				Optional:    true,
 				Default:     "no",
 			},
+			"instance_refresh": instanceRefreshSchema(),
 			"force_delete": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -760,6 +762,16 @@ func resourceASGroupUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		}
 	}
 
+	// roll the existing instances to the new scaling configuration
+	if opts := buildInstanceRefreshOpts(d); opts != nil && d.HasChange("scaling_configuration_id") {
+		if !d.Get("enable").(bool) {
+			log.Printf("[WARN] the AS group %s is disabled, skip refreshing the instances", asgID)
+		} else if err := refreshASGroupInstances(ctx, asClient, d, opts); err != nil {
+			return append(resourceASGroupRead(ctx, d, meta),
+				diag.Errorf("error refreshing the instances of AS group %s: %s", asgID, err)...)
+		}
+	}
+
 	return resourceASGroupRead(ctx, d, meta)
 }
 
@@ -27,6 +27,10 @@ func ResourceBmsInstance() *schema.Resource {
 		UpdateContext: resourceBmsInstanceUpdate,
 		DeleteContext: resourceBmsInstanceDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
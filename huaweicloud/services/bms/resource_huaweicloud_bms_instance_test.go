@@ -0,0 +1,75 @@
+package bms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestResourceBmsInstanceImport(t *testing.T) {
+	serverID := "3f4cc4a4-1d8e-4bbd-9d7b-19a4f5f6a6a1"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/project-id/baremetalservers/"+serverID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"server": {"id": "%s", "name": "bms-test", "status": "ACTIVE", "key_name": "kp-test",
+"image": {"id": "image-id"}, "flavor": {"id": "physical.s4.large"}, "OS-EXT-AZ:availability_zone": "cn-north-4a",
+"security_groups": [{"id": "secgroup-id"}], "os-extended-volumes:volumes_attached": [{"id": "volume-id"}],
+"metadata": {"vpc_id": "vpc-id", "image_name": "CentOS 7.4", "op_svc_userid": "user-id"},
+"description": "created by terraform", "enterprise_project_id": "0"}}`, serverID)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cfg := &config.Config{
+		Region:   "cn-north-4",
+		HwClient: &golangsdk.ProviderClient{ProjectID: "project-id"},
+		Endpoints: map[string]string{
+			"bms":       server.URL + "/",
+			"networkv2": server.URL + "/",
+		},
+	}
+
+	r := ResourceBmsInstance()
+	d := r.TestResourceData()
+	d.SetId(serverID)
+	result, err := r.Importer.StateContext(context.Background(), d, cfg)
+	if err != nil {
+		t.Fatalf("error importing the BMS instance: %s", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected one imported BMS instance, but got %d", len(result))
+	}
+	if diags := r.ReadContext(context.Background(), result[0], cfg); diags.HasError() {
+		t.Fatalf("error reading the imported BMS instance: %v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"region":            "cn-north-4",
+		"name":              "bms-test",
+		"image_id":          "image-id",
+		"image_name":        "CentOS 7.4",
+		"flavor_id":         "physical.s4.large",
+		"key_pair":          "kp-test",
+		"vpc_id":            "vpc-id",
+		"user_id":           "user-id",
+		"availability_zone": "cn-north-4a",
+		"description":       "created by terraform",
+		"disk_ids.0":        "volume-id",
+	}
+	for key, value := range expected {
+		if got := result[0].Get(key); got != value {
+			t.Errorf("expected %s of the imported BMS instance is %v, but got %v", key, value, got)
+		}
+	}
+	if got := result[0].Get("security_groups").(*schema.Set).List(); len(got) != 1 || got[0] != "secgroup-id" {
+		t.Errorf("expected security_groups of the imported BMS instance is [secgroup-id], but got %v", got)
+	}
+}
@@ -491,7 +491,7 @@ func resourceVaultCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 	}
 
-	if err := utils.UpdateResourceTags(client, d, "vault", d.Id()); err != nil {
+	if err := utils.UpdateResourceTags(client, d, cfg.ProviderTags, "vault", d.Id()); err != nil {
 		return diag.Errorf("error setting tags of CBR vault: %s", err)
 	}
 
@@ -634,7 +634,7 @@ func resourceVaultRead(_ context.Context, d *schema.ResourceData, meta interface
 		d.Set("enterprise_project_id", resp.EnterpriseProjectID),
 		d.Set("backup_name_prefix", resp.BackupNamePrefix),
 		d.Set("is_multi_az", resp.Billing.IsMultiAz),
-		d.Set("tags", utils.TagsToMap(resp.Tags)),
+		d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, utils.TagsToMap(resp.Tags))),
 		d.Set("bind_rules", utils.TagsToMap(resp.BindRules.Tags)),
 		d.Set("policy", flattenPolicies(client, vaultId)),
 		d.Set("resources", flattenVaultResources(resp.Billing.ObjectType, resp.Resources)),
@@ -869,7 +869,7 @@ func resourceVaultUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	if d.HasChange("tags") {
-		if err = utils.UpdateResourceTags(client, d, "vault", vaultId); err != nil {
+		if err = utils.UpdateResourceTags(client, d, cfg.ProviderTags, "vault", vaultId); err != nil {
 			return diag.Errorf("failed to update tags: %s", err)
 		}
 	}
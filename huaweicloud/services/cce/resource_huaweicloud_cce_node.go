@@ -644,7 +644,7 @@ func resourceNodeRead(_ context.Context, d *schema.ResourceData, meta interface{
 	// fetch tags from ECS instance
 	if resourceTags, err := tags.Get(computeClient, "cloudservers", serverId).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		mErr = multierror.Append(mErr, d.Set("tags", tagmap))
+		mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagmap)))
 	} else {
 		log.Printf("[WARN] Error fetching tags of ECS instance (%s): %s", serverId, err)
 	}
@@ -683,7 +683,7 @@ func resourceNodeUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	// update node tags with ECS API
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(computeClient, d, "cloudservers", serverId)
+		tagErr := utils.UpdateResourceTags(computeClient, d, cfg.ProviderTags, "cloudservers", serverId)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of cce node %s: %s", d.Id(), tagErr)
 		}
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jmespath/go-jmespath"
 
 	"github.com/chnsz/golangsdk"
@@ -25,6 +26,9 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
+// the terminal statuses of the script execution ticket
+var scriptExecuteExitStatuses = []string{"FINISHED", "ABNORMAL", "CANCELED", "PAUSED"}
+
 func ResourceScriptExecute() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceScriptExecuteCreate,
@@ -47,9 +51,17 @@ func ResourceScriptExecute() *schema.Resource {
 				ForceNew: true,
 			},
 			"instance_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"instance_id", "instance_ids"},
+			},
+			"instance_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
 				ForceNew: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"timeout": {
 				Type:     schema.TypeInt,
@@ -65,20 +77,45 @@ func ResourceScriptExecute() *schema.Resource {
 				Type:     schema.TypeList,
 				Optional: true,
 				ForceNew: true,
+				Elem:     scriptExecuteParameterSchema(),
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"rotation_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "PAUSE",
+				ValidateFunc: validation.StringInSlice([]string{"CONTINUE", "PAUSE"}, false),
+			},
+			"success_rate": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      100,
+				ValidateFunc: validation.FloatBetween(0, 100),
+			},
+			"rollback": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"name": {
+						"script_id": {
 							Type:     schema.TypeString,
 							Required: true,
 							ForceNew: true,
 						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
+						"parameters": {
+							Type:     schema.TypeList,
+							Optional: true,
 							ForceNew: true,
-							DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-								return oldValue == defaultSensitiveValue
-							},
+							Elem:     scriptExecuteParameterSchema(),
 						},
 					},
 				},
@@ -101,6 +138,67 @@ func ResourceScriptExecute() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"execute_instances": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"batch_index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"output": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"finished_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"outputs": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rollback_execution_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func scriptExecuteParameterSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return oldValue == defaultSensitiveValue
+				},
+			},
 		},
 	}
 }
@@ -126,103 +224,151 @@ func buildExecuteParamsBody(rawParams interface{}) []map[string]interface{} {
 	return nil
 }
 
-func buildExecuteParamReqBody(d *schema.ResourceData) map[string]interface{} {
+func buildExecuteParamReqBody(d *schema.ResourceData, successRate, rawParams interface{}) map[string]interface{} {
 	return map[string]interface{}{
 		"resourceful":   true,
-		"success_rate":  100,
+		"success_rate":  successRate,
 		"timeout":       d.Get("timeout"),
 		"execute_user":  d.Get("execute_user"),
-		"script_params": buildExecuteParamsBody(d.Get("parameters")),
+		"script_params": buildExecuteParamsBody(rawParams),
 	}
 }
 
-func buildExecuteBatchesReqBody(info interface{}) []map[string]interface{} {
-	targetInstances := []map[string]interface{}{
-		{
+func buildTargetInstancesReqBody(infos []interface{}) []map[string]interface{} {
+	targetInstances := make([]map[string]interface{}, len(infos))
+	for i, info := range infos {
+		targetInstances[i] = map[string]interface{}{
 			"resource_id": utils.PathSearch("resource_id", info, nil),
 			"agent_sn":    utils.PathSearch("agent_id", info, nil),
 			"project_id":  utils.PathSearch("project_id", info, nil),
 			"region_id":   utils.PathSearch("region_id", info, nil),
-		},
+		}
 	}
+	return targetInstances
+}
 
-	return []map[string]interface{}{
-		{
-			"batch_index":       1,
-			"rotation_strategy": "PAUSE",
-			"target_instances":  targetInstances,
-		},
+// buildExecuteBatchesReqBody splits the instances into batches, all instances are executed in one batch if the batch
+// size is not specified.
+func buildExecuteBatchesReqBody(infos []interface{}, batchSize int, rotationStrategy string) []map[string]interface{} {
+	if batchSize <= 0 || batchSize > len(infos) {
+		batchSize = len(infos)
+	}
+
+	batches := make([]map[string]interface{}, 0, (len(infos)+batchSize-1)/batchSize)
+	for start := 0; start < len(infos); start += batchSize {
+		end := start + batchSize
+		if end > len(infos) {
+			end = len(infos)
+		}
+		batches = append(batches, map[string]interface{}{
+			"batch_index":       len(batches) + 1, // batch_index starts counting from 1
+			"rotation_strategy": rotationStrategy,
+			"target_instances":  buildTargetInstancesReqBody(infos[start:end]),
+		})
 	}
+	return batches
 }
 
-func buildCreateExecuteBodyParams(d *schema.ResourceData, extra interface{}) map[string]interface{} {
+func buildCreateExecuteBodyParams(d *schema.ResourceData, infos []interface{}) map[string]interface{} {
 	bodyParams := map[string]interface{}{
-		"execute_param":   buildExecuteParamReqBody(d),
-		"execute_batches": buildExecuteBatchesReqBody(extra),
+		"execute_param": buildExecuteParamReqBody(d, d.Get("success_rate"), d.Get("parameters")),
+		"execute_batches": buildExecuteBatchesReqBody(infos, d.Get("batch_size").(int),
+			d.Get("rotation_strategy").(string)),
 	}
 	return bodyParams
 }
 
-func resourceScriptExecuteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	cfg := meta.(*config.Config)
-	product := "coc"
-
-	client, err := cfg.NewServiceClient(product, cfg.GetRegion(d))
-	if err != nil {
-		return diag.Errorf("error creating COC client: %s", err)
-	}
-
-	instanceID := d.Get("instance_id").(string)
-	// sync the ECS instance to get informations about UniAgent
-	info, err := syncResourceInfo(ctx, client, d.Timeout(schema.TimeoutCreate), instanceID)
-	if err != nil {
-		return diag.Errorf("error synchronizing the instance %s in COC: %s", instanceID, err)
+func getScriptExecuteInstanceIDs(d *schema.ResourceData) []string {
+	if v, ok := d.GetOk("instance_id"); ok {
+		return []string{v.(string)}
 	}
+	return utils.ExpandToStringList(d.Get("instance_ids").([]interface{}))
+}
 
-	createExecuteHttpUrl := fmt.Sprintf("v1/job/scripts/%s", d.Get("script_id"))
+func executeScript(client *golangsdk.ServiceClient, scriptID string,
+	bodyParams map[string]interface{}) (string, error) {
+	createExecuteHttpUrl := fmt.Sprintf("v1/job/scripts/%s", scriptID)
 	createExecutePath := client.Endpoint + createExecuteHttpUrl
 
 	createExecuteOpt := golangsdk.RequestOpts{
 		KeepResponseBody: true,
 		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+		JSONBody:         utils.RemoveNil(bodyParams),
 	}
-
-	createExecuteOpt.JSONBody = utils.RemoveNil(buildCreateExecuteBodyParams(d, info))
 	createExecuteResp, err := client.Request("POST", createExecutePath, &createExecuteOpt)
 	if err != nil {
-		return diag.Errorf("error executing COC script: %s", err)
+		return "", err
 	}
 
 	createExecuteRespBody, err := utils.FlattenResponse(createExecuteResp)
 	if err != nil {
-		return diag.FromErr(err)
+		return "", err
 	}
 
 	id, err := jmespath.Search("data", createExecuteRespBody)
+	if err != nil || id == nil {
+		return "", fmt.Errorf("ID is not found in API response")
+	}
+	return id.(string), nil
+}
+
+func resourceScriptExecuteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	product := "coc"
+
+	client, err := cfg.NewServiceClient(product, cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating COC client: %s", err)
+	}
+
+	instanceIDs := getScriptExecuteInstanceIDs(d)
+	// sync the ECS instances to get informations about UniAgent
+	infos, err := syncResourceInfo(ctx, client, d.Timeout(schema.TimeoutCreate), instanceIDs)
 	if err != nil {
-		return diag.Errorf("error executing COC script: ID is not found in API response")
+		return diag.Errorf("error synchronizing the instances in COC: %s", err)
 	}
 
-	ticketID := id.(string)
+	ticketID, err := executeScript(client, d.Get("script_id").(string), buildCreateExecuteBodyParams(d, infos))
+	if err != nil {
+		return diag.Errorf("error executing COC script: %s", err)
+	}
 	d.SetId(ticketID)
 
-	// waiting the execution status of COC script
+	// waiting the execution of COC script to be in a terminal status
+	status, err := waitForScriptExecutionExited(ctx, client, ticketID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return append(resourceScriptExecuteRead(ctx, d, meta),
+			diag.Errorf("error waiting for the execution of COC script to complete: %s", err)...)
+	}
+	if status == "FINISHED" {
+		return resourceScriptExecuteRead(ctx, d, meta)
+	}
+
+	diags := diag.Errorf("the execution of COC script (%s) is not finished, the status is %s", ticketID, status)
+	if _, ok := d.GetOk("rollback"); ok && status != "CANCELED" {
+		if err := rollbackScriptExecution(ctx, client, d, status, infos); err != nil {
+			diags = append(diags, diag.Errorf("error rolling back the execution of COC script (%s): %s",
+				ticketID, err)...)
+		}
+	}
+	return append(resourceScriptExecuteRead(ctx, d, meta), diags...)
+}
+
+func waitForScriptExecutionExited(ctx context.Context, client *golangsdk.ServiceClient, ticketID string,
+	timeout time.Duration) (string, error) {
 	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"pending"},
-		Target:       []string{"exited"},
+		Pending:      []string{"PROCESSING"},
+		Target:       scriptExecuteExitStatuses,
 		Refresh:      refreshGetExecutionTicketDetail(client, ticketID),
-		Timeout:      d.Timeout(schema.TimeoutCreate),
+		Timeout:      timeout,
 		Delay:        15 * time.Second,
 		PollInterval: 15 * time.Second,
 	}
-	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
-		// skip the timeouts error, and return error when the result is ABNORMAL
-		if _, ok := err.(*resource.UnexpectedStateError); ok {
-			return diag.Errorf("error executing COC script: %s", err)
-		}
+	ticketDetail, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return "", err
 	}
-
-	return resourceScriptExecuteRead(ctx, d, meta)
+	return utils.PathSearch("data.status", ticketDetail, "").(string), nil
 }
 
 func refreshGetExecutionTicketDetail(client *golangsdk.ServiceClient, ticketID string) resource.StateRefreshFunc {
@@ -232,16 +378,64 @@ func refreshGetExecutionTicketDetail(client *golangsdk.ServiceClient, ticketID s
 			return nil, "error", err
 		}
 
-		status := utils.PathSearch("data.status", ticketDetail, "")
-		ticketStatus := status.(string)
+		ticketStatus := utils.PathSearch("data.status", ticketDetail, "").(string)
+		if utils.StrSliceContains(scriptExecuteExitStatuses, ticketStatus) {
+			return ticketDetail, ticketStatus, nil
+		}
+		return ticketDetail, "PROCESSING", nil
+	}
+}
 
-		if ticketStatus == "PROCESSING" {
-			return ticketDetail, "pending", nil
-		} else if ticketStatus == "ABNORMAL" {
-			return ticketDetail, "error", nil
+// rollbackScriptExecution cancels the remaining batches of the failed execution, then executes the rollback script
+// on the instances which the script has been executed on.
+func rollbackScriptExecution(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData,
+	status string, infos []interface{}) error {
+	ticketID := d.Id()
+	if status == "PAUSED" {
+		if err := cancelScriptExecution(client, ticketID); err != nil {
+			return err
 		}
-		return ticketDetail, "exited", nil
 	}
+
+	executeInstances, err := getScriptExecuteInstances(client, ticketID)
+	if err != nil {
+		return err
+	}
+	executedInfos := make([]interface{}, 0, len(infos))
+	for _, info := range infos {
+		instanceID := utils.PathSearch("resource_id", info, "").(string)
+		instanceStatus := utils.PathSearch(fmt.Sprintf("[?target_instance.resource_id=='%s']|[0].status", instanceID),
+			executeInstances, "").(string)
+		if instanceStatus == "FINISHED" || instanceStatus == "ABNORMAL" {
+			executedInfos = append(executedInfos, info)
+		}
+	}
+	if len(executedInfos) == 0 {
+		log.Printf("[WARN] the COC script is not executed on any instance, skip the rollback of %s", ticketID)
+		return nil
+	}
+
+	rollbackScriptID := d.Get("rollback.0.script_id").(string)
+	bodyParams := map[string]interface{}{
+		"execute_param":   buildExecuteParamReqBody(d, 100, d.Get("rollback.0.parameters")),
+		"execute_batches": buildExecuteBatchesReqBody(executedInfos, 0, "PAUSE"),
+	}
+	rollbackID, err := executeScript(client, rollbackScriptID, bodyParams)
+	if err != nil {
+		return fmt.Errorf("error executing the rollback script (%s): %s", rollbackScriptID, err)
+	}
+	if err := d.Set("rollback_execution_id", rollbackID); err != nil {
+		return err
+	}
+
+	rollbackStatus, err := waitForScriptExecutionExited(ctx, client, rollbackID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("error waiting for the rollback execution (%s) to complete: %s", rollbackID, err)
+	}
+	if rollbackStatus != "FINISHED" {
+		return fmt.Errorf("the rollback execution (%s) is not finished, the status is %s", rollbackID, rollbackStatus)
+	}
+	return nil
 }
 
 func getExecutionTicketDetail(client *golangsdk.ServiceClient, id string) (interface{}, error) {
@@ -262,6 +456,108 @@ func getExecutionTicketDetail(client *golangsdk.ServiceClient, id string) (inter
 	return utils.FlattenResponse(getExecutionTicketResp)
 }
 
+// getScriptExecuteInstances returns the execution details of all instances in all batches of the ticket.
+func getScriptExecuteInstances(client *golangsdk.ServiceClient, id string) ([]interface{}, error) {
+	listBatchesPath := client.Endpoint + fmt.Sprintf("v1/job/script/orders/%s/batches", id)
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+	}
+
+	listBatchesResp, err := client.Request("GET", listBatchesPath, &opt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the batches of COC script execution: %s", err)
+	}
+	listBatchesRespBody, err := utils.FlattenResponse(listBatchesResp)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0)
+	batches := utils.PathSearch("data", listBatchesRespBody, make([]interface{}, 0)).([]interface{})
+	for _, batch := range batches {
+		batchIndex := int(utils.PathSearch("batch_index", batch, float64(0)).(float64))
+		listInstancesPath := client.Endpoint + fmt.Sprintf("v1/job/script/orders/%s/batches/%d?limit=100", id,
+			batchIndex)
+		marker := ""
+		for {
+			requestPath := listInstancesPath
+			if marker != "" {
+				requestPath += fmt.Sprintf("&marker=%s", marker)
+			}
+			listInstancesResp, err := client.Request("GET", requestPath, &opt)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving the instances of COC script execution batch %d: %s",
+					batchIndex, err)
+			}
+			listInstancesRespBody, err := utils.FlattenResponse(listInstancesResp)
+			if err != nil {
+				return nil, err
+			}
+
+			instances := utils.PathSearch("data.execute_instances", listInstancesRespBody,
+				make([]interface{}, 0)).([]interface{})
+			for _, instance := range instances {
+				if v, ok := instance.(map[string]interface{}); ok {
+					v["batch_index"] = batchIndex
+				}
+				result = append(result, instance)
+			}
+			if len(instances) < 100 {
+				break
+			}
+			marker = fmt.Sprintf("%d", int64(utils.PathSearch("[-1].id", instances, float64(0)).(float64)))
+		}
+	}
+	return result, nil
+}
+
+func flattenScriptExecuteInstances(instances []interface{}) ([]interface{}, map[string]interface{}) {
+	rst := make([]interface{}, len(instances))
+	outputs := make(map[string]interface{}, len(instances))
+	for i, v := range instances {
+		instanceID := utils.PathSearch("target_instance.resource_id", v, "").(string)
+		output := utils.PathSearch("message", v, "")
+		rst[i] = map[string]interface{}{
+			"instance_id": instanceID,
+			"batch_index": utils.PathSearch("batch_index", v, nil),
+			"status":      utils.PathSearch("status", v, nil),
+			"output":      output,
+			"created_at":  flattenScriptTimeStamp(v, "gmt_created"),
+			"finished_at": flattenScriptTimeStamp(v, "gmt_finished"),
+		}
+		outputs[instanceID] = output
+	}
+	return rst, outputs
+}
+
+// setScriptExecuteBatches sets the instances and the batch settings from the batches of the ticket.
+// The batch size is only set when the instances are executed in multiple batches, because a batch size which is not
+// less than the number of instances results in a single batch, the same as omitting it.
+func setScriptExecuteBatches(d *schema.ResourceData, ticketDetail interface{}) error {
+	batches := utils.PathSearch("data.properties.execute_batches", ticketDetail, make([]interface{}, 0)).([]interface{})
+	if len(batches) == 0 {
+		return nil
+	}
+
+	instanceIDs := utils.PathSearch("[*].target_instances[*].resource_id[]", batches, make([]interface{}, 0))
+	ids := instanceIDs.([]interface{})
+	mErr := &multierror.Error{}
+	if v := utils.PathSearch("[0].rotation_strategy", batches, "").(string); v != "" {
+		mErr = multierror.Append(mErr, d.Set("rotation_strategy", v))
+	}
+	if len(ids) == 1 && len(d.Get("instance_ids").([]interface{})) == 0 {
+		mErr = multierror.Append(mErr, d.Set("instance_id", ids[0]))
+	} else {
+		mErr = multierror.Append(mErr, d.Set("instance_ids", ids))
+	}
+	if len(batches) > 1 {
+		batchSize := utils.PathSearch("length([0].target_instances)", batches, float64(0)).(float64)
+		mErr = multierror.Append(mErr, d.Set("batch_size", int(batchSize)))
+	}
+	return mErr.ErrorOrNil()
+}
+
 func resourceScriptExecuteRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cfg := meta.(*config.Config)
 	product := "coc"
@@ -283,15 +579,36 @@ func resourceScriptExecuteRead(_ context.Context, d *schema.ResourceData, meta i
 		d.Set("script_name", utils.PathSearch("data.properties.script_name", ticketDetail, nil)),
 		d.Set("timeout", utils.PathSearch("data.properties.execute_param.timeout", ticketDetail, nil)),
 		d.Set("execute_user", utils.PathSearch("data.properties.execute_param.execute_user", ticketDetail, nil)),
+		d.Set("success_rate", utils.PathSearch("data.properties.execute_param.success_rate", ticketDetail, nil)),
 		d.Set("created_at", flattenScriptTimeStamp(ticketDetail, "data.gmt_created")),
 		d.Set("finished_at", flattenScriptTimeStamp(ticketDetail, "data.gmt_finished")),
+		setScriptExecuteBatches(d, ticketDetail),
 	)
 
+	// the ticket is still readable when the batches cannot be listed, so the last read outputs are kept
+	var diags diag.Diagnostics
+	executeInstances, err := getScriptExecuteInstances(client, ticketID)
+	if err != nil {
+		diags = diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unable to read the execution details of the instances",
+				Detail:   fmt.Sprintf("the instance outputs of COC script execution %s are not refreshed: %s", ticketID, err),
+			},
+		}
+	} else {
+		instances, outputs := flattenScriptExecuteInstances(executeInstances)
+		mErr = multierror.Append(mErr,
+			d.Set("execute_instances", instances),
+			d.Set("outputs", outputs),
+		)
+	}
+
 	if err := mErr.ErrorOrNil(); err != nil {
 		return diag.Errorf("error setting COC script execute fields: %s", err)
 	}
 
-	return nil
+	return diags
 }
 
 // now, the value of script_params in API response is the default value, not the value when executing
@@ -332,7 +649,14 @@ func resourceScriptExecuteDelete(_ context.Context, d *schema.ResourceData, meta
 	}
 
 	// cancel the ticket when it in PROCESSING and PAUSED status
-	operationExecuteHttpUrl := fmt.Sprintf("v1/job/script/orders/%s/operation", d.Id())
+	if err := cancelScriptExecution(client, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func cancelScriptExecution(client *golangsdk.ServiceClient, id string) error {
+	operationExecuteHttpUrl := fmt.Sprintf("v1/job/script/orders/%s/operation", id)
 	operationExecutePath := client.Endpoint + operationExecuteHttpUrl
 
 	operationExecuteOpt := golangsdk.RequestOpts{
@@ -341,28 +665,37 @@ func resourceScriptExecuteDelete(_ context.Context, d *schema.ResourceData, meta
 		JSONBody:         map[string]string{"operation_type": "CANCEL_ORDER"},
 	}
 
-	_, err = client.Request("PUT", operationExecutePath, &operationExecuteOpt)
+	_, err := client.Request("PUT", operationExecutePath, &operationExecuteOpt)
 	if err != nil {
-		return diag.Errorf("error canceling COC script execution: %s", err)
+		return fmt.Errorf("error canceling COC script execution: %s", err)
 	}
 	return nil
 }
 
-func syncResourceInfo(ctx context.Context, client *golangsdk.ServiceClient, timeout time.Duration, instanceID string) (interface{}, error) {
+func syncResourceInfo(ctx context.Context, client *golangsdk.ServiceClient, timeout time.Duration,
+	instanceIDs []string) ([]interface{}, error) {
 	if err := doSyncResources(client); err != nil {
 		return nil, err
 	}
 
-	log.Printf("[DEBUG] Waiting for ECS instance %s to be online in COC", instanceID)
-	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"pending"},
-		Target:       []string{"online"},
-		Refresh:      doGetResources(client, instanceID),
-		Timeout:      timeout,
-		Delay:        5 * time.Second,
-		PollInterval: 15 * time.Second,
+	infos := make([]interface{}, 0, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		log.Printf("[DEBUG] Waiting for ECS instance %s to be online in COC", instanceID)
+		stateConf := &resource.StateChangeConf{
+			Pending:      []string{"pending"},
+			Target:       []string{"online"},
+			Refresh:      doGetResources(client, instanceID),
+			Timeout:      timeout,
+			Delay:        5 * time.Second,
+			PollInterval: 15 * time.Second,
+		}
+		info, err := stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error waiting for ECS instance %s to be online: %s", instanceID, err)
+		}
+		infos = append(infos, info)
 	}
-	return stateConf.WaitForStateContext(ctx)
+	return infos, nil
 }
 
 func doSyncResources(client *golangsdk.ServiceClient) error {
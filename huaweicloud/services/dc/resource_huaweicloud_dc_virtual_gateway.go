@@ -27,6 +27,8 @@ func ResourceVirtualGateway() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: utils.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:        schema.TypeString,
@@ -89,7 +91,8 @@ func ResourceVirtualGateway() *schema.Resource {
 				Computed:    true,
 				Description: "The current status of the virtual gateway.",
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsComputedSchema(),
 		},
 	}
 }
@@ -120,7 +123,7 @@ func resourceVirtualGatewayCreate(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(resp.ID)
 
 	// create tags
-	if err := utils.CreateResourceTags(client, d, "dc-vgw", d.Id()); err != nil {
+	if err := utils.CreateResourceTags(client, d, cfg.ProviderTags, "dc-vgw", d.Id()); err != nil {
 		return diag.Errorf("error setting tags of DC virtual gateway %s: %s", d.Id(), err)
 	}
 
@@ -149,7 +152,7 @@ func resourceVirtualGatewayRead(_ context.Context, d *schema.ResourceData, meta
 		d.Set("asn", resp.BgpAsn),
 		d.Set("enterprise_project_id", resp.EnterpriseProjectId),
 		d.Set("status", resp.Status),
-		utils.SetResourceTagsToState(d, client, "dc-vgw", d.Id()),
+		utils.SetResourceTagsAllToState(d, cfg.ProviderTags, client, "dc-vgw", d.Id()),
 	)
 
 	if err = mErr.ErrorOrNil(); err != nil {
@@ -180,7 +183,7 @@ func resourceVirtualGatewayUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// update tags
-	tagErr := utils.UpdateResourceTags(client, d, "dc-vgw", d.Id())
+	tagErr := utils.UpdateResourceTagsAll(client, d, cfg.ProviderTags, "dc-vgw", d.Id())
 	if tagErr != nil {
 		return diag.Errorf("error updating tags of DC virtual gateway %s: %s", d.Id(), tagErr)
 	}
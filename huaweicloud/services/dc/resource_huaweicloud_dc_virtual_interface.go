@@ -53,6 +53,8 @@ func ResourceVirtualInterface() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: utils.SetTagsAllDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:        schema.TypeString,
@@ -239,7 +241,8 @@ func ResourceVirtualInterface() *schema.Resource {
 				Computed:    true,
 				Description: "The creation time of the virtual interface.",
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsComputedSchema(),
 		},
 	}
 }
@@ -285,7 +288,7 @@ func resourceVirtualInterfaceCreate(ctx context.Context, d *schema.ResourceData,
 	d.SetId(resp.ID)
 
 	// create tags
-	if err := utils.CreateResourceTags(client, d, "dc-vif", d.Id()); err != nil {
+	if err := utils.CreateResourceTags(client, d, cfg.ProviderTags, "dc-vif", d.Id()); err != nil {
 		return diag.Errorf("error setting tags of DC virtual interface %s: %s", d.Id(), err)
 	}
 
@@ -332,7 +335,7 @@ func resourceVirtualInterfaceRead(_ context.Context, d *schema.ResourceData, met
 		d.Set("device_id", resp.DeviceId),
 		d.Set("status", resp.Status),
 		d.Set("created_at", resp.CreatedAt),
-		utils.SetResourceTagsToState(d, client, "dc-vif", d.Id()),
+		utils.SetResourceTagsAllToState(d, cfg.ProviderTags, client, "dc-vif", d.Id()),
 	)
 
 	if err = mErr.ErrorOrNil(); err != nil {
@@ -430,7 +433,7 @@ func resourceVirtualInterfaceUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	// update tags
-	tagErr := utils.UpdateResourceTags(client, d, "dc-vif", d.Id())
+	tagErr := utils.UpdateResourceTagsAll(client, d, cfg.ProviderTags, "dc-vif", d.Id())
 	if tagErr != nil {
 		return diag.Errorf("error updating tags of DC virtual interface %s: %s", d.Id(), tagErr)
 	}
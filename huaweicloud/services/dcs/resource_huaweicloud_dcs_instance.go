@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -171,9 +172,22 @@ func ResourceDcsInstance() *schema.Resource {
 				ForceNew: true,
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Sensitive: true,
-				Optional:  true,
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				ConflictsWith: []string{"password_wo"},
+			},
+			"password_wo": {
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"password"},
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
 			},
 			"whitelist_enable": {
 				Type:     schema.TypeBool,
@@ -565,7 +579,7 @@ func resourceDcsInstancesCreate(ctx context.Context, d *schema.ResourceData, met
 
 	// noPasswordAccess
 	noPasswordAccess := true
-	if d.Get("access_user").(string) != "" || d.Get("password").(string) != "" {
+	if d.Get("access_user").(string) != "" || getDcsPassword(d) != "" {
 		noPasswordAccess = false
 	}
 	// resourceSpecCode
@@ -626,7 +640,7 @@ func resourceDcsInstancesCreate(ctx context.Context, d *schema.ResourceData, met
 	log.Printf("[DEBUG] Create DCS instance options(hide password) : %#v", createOpts)
 
 	// Add password here so it wouldn't go in the above log entry
-	createOpts.Password = d.Get("password").(string)
+	createOpts.Password = getDcsPassword(d)
 
 	// create instance
 	r, err := instances.Create(client, createOpts)
@@ -1007,6 +1021,58 @@ func generateParametersMap(configurations *instances.Configuration) map[string]i
 	return parametersMap
 }
 
+// getDcsPassword returns the password from the write-only argument password_wo or password.
+func getDcsPassword(d *schema.ResourceData) string {
+	if v := utils.GetWriteOnlyString(d, cty.GetAttrPath("password_wo")); v != "" {
+		return v
+	}
+	return d.Get("password").(string)
+}
+
+// resetDcsPassword resets the password with the write-only argument, the old password is not required as it is not
+// saved in the state.
+func resetDcsPassword(ctx context.Context, d *schema.ResourceData, cfg *config.Config) error {
+	var (
+		resetPasswordHttpUrl = "v2/{project_id}/instances/{instance_id}/password/reset"
+		resetPasswordProduct = "dcs"
+	)
+	client, err := cfg.NewServiceClient(resetPasswordProduct, cfg.GetRegion(d))
+	if err != nil {
+		return fmt.Errorf("error creating DCS Client: %s", err)
+	}
+
+	resetPasswordPath := client.Endpoint + resetPasswordHttpUrl
+	resetPasswordPath = strings.ReplaceAll(resetPasswordPath, "{project_id}", client.ProjectID)
+	resetPasswordPath = strings.ReplaceAll(resetPasswordPath, "{instance_id}", d.Id())
+
+	password := getDcsPassword(d)
+	resetPasswordOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes: []int{
+			200,
+		},
+		JSONBody: map[string]interface{}{
+			"new_password":       password,
+			"no_password_access": password == "",
+		},
+	}
+	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+		_, err = client.Request("PUT", resetPasswordPath, &resetPasswordOpt)
+		isRetry, err := handleOperationError(err)
+		if isRetry {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error resetting the password of DCS instance (%s): %s", d.Id(), err)
+	}
+	return nil
+}
+
 func resourceDcsInstancesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cfg := meta.(*config.Config)
 	client, err := cfg.DcsV2Client(cfg.GetRegion(d))
@@ -1067,6 +1133,12 @@ func resourceDcsInstancesUpdate(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
+	if d.HasChange("password_wo_version") {
+		if err = resetDcsPassword(ctx, d, cfg); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// resize instance
 	err = resizeDcsInstance(ctx, d, meta)
 	if err != nil {
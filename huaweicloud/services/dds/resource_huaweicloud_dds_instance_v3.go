@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -99,9 +100,21 @@ func ResourceDdsInstanceV3() *schema.Resource {
 				Computed: true,
 			},
 			"password": {
+				Type:         schema.TypeString,
+				Sensitive:    true,
+				Optional:     true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
 				Type:      schema.TypeString,
 				Sensitive: true,
-				Required:  true,
+				Optional:  true,
+				WriteOnly: true,
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
 			},
 			"disk_encryption_id": {
 				Type:     schema.TypeString,
@@ -354,6 +367,14 @@ func buildChargeInfoParams(d *schema.ResourceData) instances.ChargeInfo {
 	return chargeInfo
 }
 
+// getDdsPassword returns the administrator password from the write-only argument password_wo or password.
+func getDdsPassword(d *schema.ResourceData) string {
+	if v := utils.GetWriteOnlyString(d, cty.GetAttrPath("password_wo")); v != "" {
+		return v
+	}
+	return d.Get("password").(string)
+}
+
 func resourceDdsInstanceV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conf := meta.(*config.Config)
 	client, err := conf.DdsV3Client(conf.GetRegion(d))
@@ -387,7 +408,7 @@ func resourceDdsInstanceV3Create(ctx context.Context, d *schema.ResourceData, me
 	}
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	// Add password here so it wouldn't go in the above log entry
-	createOpts.Password = d.Get("password").(string)
+	createOpts.Password = getDdsPassword(d)
 
 	if val, ok := d.GetOk("port"); ok {
 		createOpts.Port = strconv.Itoa(val.(int))
@@ -522,7 +543,7 @@ func resourceDdsInstanceV3Read(_ context.Context, d *schema.ResourceData, meta i
 	// save tags
 	if resourceTags, err := tags.Get(client, "instances", d.Id()).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		mErr = multierror.Append(mErr, d.Set("tags", tagmap))
+		mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, conf.ProviderTags, tagmap)))
 	} else {
 		log.Printf("[WARN] Error fetching tags of DDS instance (%s): %s", d.Id(), err)
 	}
@@ -583,10 +604,10 @@ func resourceDdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 		opts = append(opts, opt)
 	}
 
-	if d.HasChange("password") {
+	if d.HasChanges("password", "password_wo_version") {
 		opt := instances.UpdateOpt{
 			Param:  "user_pwd",
-			Value:  d.Get("password").(string),
+			Value:  getDdsPassword(d),
 			Action: "reset-password",
 			Method: "put",
 		}
@@ -695,7 +716,7 @@ func resourceDdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "instances", d.Id())
+		tagErr := utils.UpdateResourceTags(client, d, conf.ProviderTags, "instances", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("Error updating tags of DDS instance:%s, err:%s", d.Id(), tagErr)
 		}
@@ -294,7 +294,7 @@ func resourceDmsInstancesV1Read(d *schema.ResourceData, meta interface{}) error
 	engine := d.Get("engine").(string)
 	if resourceTags, err := tags.Get(dmsV2Client, engine, d.Id()).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		if err := d.Set("tags", tagmap); err != nil {
+		if err := d.Set("tags", utils.FilterResourceTags(d, config.ProviderTags, tagmap)); err != nil {
 			return fmtp.Errorf("Error saving tags to state for dms instance (%s): %s", d.Id(), err)
 		}
 	} else {
@@ -348,7 +348,7 @@ func resourceDmsInstancesV1Update(d *schema.ResourceData, meta interface{}) erro
 		}
 		// update tags
 		engine := d.Get("engine").(string)
-		tagErr := utils.UpdateResourceTags(dmsV2Client, d, engine, d.Id())
+		tagErr := utils.UpdateResourceTags(dmsV2Client, d, config.ProviderTags, engine, d.Id())
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of dms instance:%s, err:%s", d.Id(), tagErr)
 		}
@@ -355,7 +355,7 @@ func resourceEcsInstanceV1Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("nics", nics)
 
 	// Set instance tags
-	d.Set("tags", flattenTagsToMap(server.Tags))
+	d.Set("tags", utils.FilterResourceTags(d, config.ProviderTags, flattenTagsToMap(server.Tags)))
 
 	ar, err := resourceECSAutoRecoveryV1Read(d, meta, d.Id())
 	if err != nil && !utils.IsResourceNotFound(err) {
@@ -480,7 +480,7 @@ func resourceEcsInstanceV1Update(d *schema.ResourceData, meta interface{}) error
 			return fmtp.Errorf("Error creating HuaweiCloud compute v1 client: %s", err)
 		}
 
-		tagErr := utils.UpdateResourceTags(ecsClient, d, "cloudservers", d.Id())
+		tagErr := utils.UpdateResourceTags(ecsClient, d, config.ProviderTags, "cloudservers", d.Id())
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of instance:%s, err:%s", d.Id(), err)
 		}
@@ -283,7 +283,7 @@ func resourceVpnSiteConnectionV2Read(d *schema.ResourceData, meta interface{}) e
 	}
 
 	tagmap := utils.TagsToMap(resourceTags.Tags)
-	if err := d.Set("tags", tagmap); err != nil {
+	if err := d.Set("tags", utils.FilterResourceTags(d, config.ProviderTags, tagmap)); err != nil {
 		return fmtp.Errorf("Error saving tags for VPN site connection %s: %s", d.Id(), err)
 	}
 
@@ -400,7 +400,7 @@ func resourceVpnSiteConnectionV2Update(d *schema.ResourceData, meta interface{})
 	}
 
 	// update tags
-	tagErr := utils.UpdateResourceTags(networkingClient, d, "ipsec-site-connections", d.Id())
+	tagErr := utils.UpdateResourceTags(networkingClient, d, config.ProviderTags, "ipsec-site-connections", d.Id())
 	if tagErr != nil {
 		return fmtp.Errorf("Error updating tags of VPN site connection %s: %s", d.Id(), tagErr)
 	}
@@ -0,0 +1,144 @@
+package dew
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/chnsz/golangsdk/openstack/csms/v1/secrets"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &csmsSecretVersionEphemeralResource{}
+
+type csmsSecretVersionEphemeralResource struct {
+	cfg *config.Config
+}
+
+type csmsSecretVersionModel struct {
+	Region     types.String `tfsdk:"region"`
+	SecretName types.String `tfsdk:"secret_name"`
+	Version    types.String `tfsdk:"version"`
+	SecretText types.String `tfsdk:"secret_text"`
+	KmsKeyID   types.String `tfsdk:"kms_key_id"`
+	Status     types.List   `tfsdk:"status"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+}
+
+// EphemeralCsmsSecretVersion returns the ephemeral resource of the CSMS secret version, the secret text is never
+// persisted in the plan or state.
+// API: DEW GET /v1/{project_id}/secrets/{secret_name}/versions
+// API: DEW GET /v1/{project_id}/secrets/{secret_name}/versions/{version_id}
+func EphemeralCsmsSecretVersion() ephemeral.EphemeralResource {
+	return &csmsSecretVersionEphemeralResource{}
+}
+
+func (*csmsSecretVersionEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_csms_secret_version"
+}
+
+func (*csmsSecretVersionEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"secret_name": schema.StringAttribute{
+				Required: true,
+			},
+			"version": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"secret_text": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"kms_key_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"status": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *csmsSecretVersionEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("expected *config.Config, but got %T", req.ProviderData))
+		return
+	}
+	r.cfg = cfg
+}
+
+func (r *csmsSecretVersionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse) {
+	if r.cfg == nil {
+		resp.Diagnostics.AddError("Provider not configured", "the provider is not configured before opening "+
+			"the CSMS secret version")
+		return
+	}
+
+	var model csmsSecretVersionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	region := r.cfg.Region
+	if v := model.Region.ValueString(); v != "" {
+		region = v
+	}
+
+	var (
+		version *secrets.Version
+		err     error
+	)
+	secretName := model.SecretName.ValueString()
+	if v := model.Version.ValueString(); v != "" {
+		version, err = queryVersion(r.cfg, region, secretName, v)
+	} else {
+		version, err = queryLatestVersion(r.cfg, region, secretName)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error querying the CSMS secret version", err.Error())
+		return
+	}
+
+	vMetadata := version.VersionMetadata
+	status, diags := types.ListValueFrom(ctx, types.StringType, vMetadata.VersionStages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.Region = types.StringValue(region)
+	model.SecretName = types.StringValue(vMetadata.SecretName)
+	model.Version = types.StringValue(vMetadata.ID)
+	model.SecretText = types.StringValue(version.SecretString)
+	model.KmsKeyID = types.StringValue(vMetadata.KmsKeyID)
+	model.Status = status
+	model.CreatedAt = types.StringValue(time.Unix(int64(vMetadata.CreateTime)/1000, 0).UTC().
+		Format("2006-01-02 15:04:05 MST"))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, model)...)
+}
@@ -0,0 +1,125 @@
+package dew
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/chnsz/golangsdk/openstack/kms/v1/keys"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &kmsDataKeyEphemeralResource{}
+
+type kmsDataKeyEphemeralResource struct {
+	cfg *config.Config
+}
+
+type kmsDataKeyModel struct {
+	Region            types.String `tfsdk:"region"`
+	KeyID             types.String `tfsdk:"key_id"`
+	EncryptionContext types.String `tfsdk:"encryption_context"`
+	DatakeyLength     types.String `tfsdk:"datakey_length"`
+	PlainText         types.String `tfsdk:"plain_text"`
+	CipherText        types.String `tfsdk:"cipher_text"`
+}
+
+// EphemeralKmsDataKey returns the ephemeral resource of the KMS data key, the plaintext of the data key is never
+// persisted in the plan or state.
+// API: DEW POST /v1.0/{project_id}/kms/create-datakey
+func EphemeralKmsDataKey() ephemeral.EphemeralResource {
+	return &kmsDataKeyEphemeralResource{}
+}
+
+func (*kmsDataKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kms_data_key"
+}
+
+func (*kmsDataKeyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"key_id": schema.StringAttribute{
+				Required: true,
+			},
+			"encryption_context": schema.StringAttribute{
+				Optional: true,
+			},
+			"datakey_length": schema.StringAttribute{
+				Required: true,
+			},
+			"plain_text": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"cipher_text": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *kmsDataKeyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("expected *config.Config, but got %T", req.ProviderData))
+		return
+	}
+	r.cfg = cfg
+}
+
+func (r *kmsDataKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse) {
+	if r.cfg == nil {
+		resp.Diagnostics.AddError("Provider not configured", "the provider is not configured before opening "+
+			"the KMS data key")
+		return
+	}
+
+	var model kmsDataKeyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	region := r.cfg.Region
+	if v := model.Region.ValueString(); v != "" {
+		region = v
+	}
+	client, err := r.cfg.KmsKeyV1Client(region)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating KMS client", err.Error())
+		return
+	}
+
+	opts := &keys.DataEncryptOpts{
+		KeyID:             model.KeyID.ValueString(),
+		EncryptionContext: model.EncryptionContext.ValueString(),
+		DatakeyLength:     model.DatakeyLength.ValueString(),
+	}
+	v, err := keys.DataEncryptGet(client, opts).ExtractDataKey()
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating the KMS data key", err.Error())
+		return
+	}
+
+	model.Region = types.StringValue(region)
+	model.PlainText = types.StringValue(v.PlainText)
+	model.CipherText = types.StringValue(v.CipherText)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, model)...)
+}
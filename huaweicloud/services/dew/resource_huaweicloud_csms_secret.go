@@ -183,7 +183,7 @@ func resourceCsmsSecretRead(_ context.Context, d *schema.ResourceData, meta inte
 		tagMap := utils.TagsToMap(resourceTags.Tags)
 		mErr = multierror.Append(
 			mErr,
-			d.Set("tags", tagMap),
+			d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagMap)),
 		)
 	} else {
 		log.Printf("[WARN] error querying CSMS secret tags (%s): %s", id, err)
@@ -269,7 +269,7 @@ func resourceCsmsSecretUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	// Update tags
 	if d.HasChange("tags") {
-		err = utils.UpdateResourceTags(client, d, serviceType, id)
+		err = utils.UpdateResourceTags(client, d, cfg.ProviderTags, serviceType, id)
 		if err != nil {
 			return diag.Errorf("failed to update CSMS secret tags: %s", err)
 		}
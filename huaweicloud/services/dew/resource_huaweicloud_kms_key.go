@@ -270,7 +270,7 @@ func ResourceKmsKeyRead(_ context.Context, d *schema.ResourceData, meta interfac
 		d.Set("default_key_flag", v.DefaultKeyFlag),
 		d.Set("expiration_time", v.ExpirationTime),
 		d.Set("enterprise_project_id", v.EnterpriseProjectID),
-		utils.SetResourceTagsToState(d, kmsKeyV1Client, "kms", d.Id()),
+		utils.SetResourceTagsToState(d, cfg.ProviderTags, kmsKeyV1Client, "kms", d.Id()),
 	)
 
 	// Set KMS rotation
@@ -334,7 +334,7 @@ func ResourceKmsKeyUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(kmsKeyV1Client, d, "kms", keyID)
+		tagErr := utils.UpdateResourceTags(kmsKeyV1Client, d, cfg.ProviderTags, "kms", keyID)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of kms: %s, err: %s", keyID, err)
 		}
@@ -253,7 +253,7 @@ func resourceStreamRead(_ context.Context, d *schema.ResourceData, meta interfac
 		d.Set("data_type", detail.DataType),
 		d.Set("retention_period", detail.RetentionPeriod),
 		d.Set("stream_type", detail.StreamType),
-		d.Set("tags", utils.TagsToMap(detail.Tags)),
+		d.Set("tags", utils.FilterResourceTags(d, conf.ProviderTags, utils.TagsToMap(detail.Tags))),
 		d.Set("created", detail.CreateTime),
 		d.Set("readable_partition_count", detail.ReadablePartitionCount),
 		d.Set("writable_partition_count", detail.WritablePartitionCount),
@@ -317,7 +317,7 @@ func resourceStreamUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	if d.HasChange("tags") {
 		streamId := d.Get("stream_id").(string)
-		tagErr := utils.UpdateResourceTags(client, d, "stream", streamId)
+		tagErr := utils.UpdateResourceTags(client, d, conf.ProviderTags, "stream", streamId)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of DIS stream:%s,streamId: %s, err: %s", name, streamId, tagErr)
 		}
@@ -39,6 +39,10 @@ func ResourceDliPackageV2() *schema.Resource {
 		UpdateContext: ResourceDliDependentPackageV2Update,
 		DeleteContext: ResourceDliDependentPackageV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDliDependentPackageImportState,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -172,9 +176,10 @@ func ResourceDliDependentPackageV2Create(ctx context.Context, d *schema.Resource
 	return ResourceDliDependentPackageV2Read(ctx, d, meta)
 }
 
+// getGroupNameAndPackageName parses the resource ID in the format of <group_name>/<object_name>.
 func getGroupNameAndPackageName(id string) (groupName, packageName string, err error) {
 	names := strings.Split(id, "/")
-	if len(names) < 2 {
+	if len(names) != 2 || names[0] == "" || names[1] == "" {
 		log.Printf("[DEBUG] The resource ID of the DLI package is: %s", id)
 		err = fmt.Errorf("ID is incomplete, missing key information")
 		return
@@ -265,3 +270,14 @@ func ResourceDliDependentPackageV2Delete(_ context.Context, d *schema.ResourceDa
 
 	return nil
 }
+
+func resourceDliDependentPackageImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	groupName, _, err := getGroupNameAndPackageName(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("invalid format specified for import ID, want '<group_name>/<object_name>', but got '%s'",
+			d.Id())
+	}
+
+	return []*schema.ResourceData{d}, d.Set("group_name", groupName)
+}
@@ -0,0 +1,38 @@
+package dli
+
+import (
+	"testing"
+)
+
+func TestGetGroupNameAndPackageName(t *testing.T) {
+	cases := []struct {
+		id          string
+		groupName   string
+		packageName string
+		isErr       bool
+	}{
+		{id: "test_group/test.jar", groupName: "test_group", packageName: "test.jar"},
+		{id: "test_group", isErr: true},
+		{id: "test_group/", isErr: true},
+		{id: "/test.jar", isErr: true},
+		{id: "test_group/test.jar/extra", isErr: true},
+	}
+
+	for _, tc := range cases {
+		groupName, packageName, err := getGroupNameAndPackageName(tc.id)
+		if tc.isErr {
+			if err == nil {
+				t.Errorf("expected an error when parsing '%s', but got nil", tc.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error when parsing '%s': %s", tc.id, err)
+			continue
+		}
+		if groupName != tc.groupName || packageName != tc.packageName {
+			t.Errorf("the result of parsing '%s' is not as expected, want (%s, %s), but got (%s, %s)",
+				tc.id, tc.groupName, tc.packageName, groupName, packageName)
+		}
+	}
+}
@@ -913,7 +913,7 @@ func resourceDmsKafkaInstanceRead(_ context.Context, d *schema.ResourceData, met
 	// set tags
 	if resourceTags, err := tags.Get(client, engineKafka, d.Id()).Extract(); err == nil {
 		tagMap := utils.TagsToMap(resourceTags.Tags)
-		if err = d.Set("tags", tagMap); err != nil {
+		if err = d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagMap)); err != nil {
 			mErr = multierror.Append(mErr,
 				fmt.Errorf("error saving tags to state for DMS kafka instance (%s): %s", d.Id(), err))
 		}
@@ -980,7 +980,7 @@ func resourceDmsKafkaInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 
 	if d.HasChange("tags") {
 		// update tags
-		if err = utils.UpdateResourceTags(client, d, engineKafka, d.Id()); err != nil {
+		if err = utils.UpdateResourceTags(client, d, cfg.ProviderTags, engineKafka, d.Id()); err != nil {
 			mErr = multierror.Append(mErr, fmt.Errorf("error updating tags of Kafka instance: %s, err: %s",
 				d.Id(), err))
 		}
@@ -594,7 +594,7 @@ func resourceDmsRabbitmqInstanceRead(_ context.Context, d *schema.ResourceData,
 	// set tags
 	if resourceTags, err := tags.Get(client, engineRabbitMQ, d.Id()).Extract(); err == nil {
 		tagMap := utils.TagsToMap(resourceTags.Tags)
-		err = d.Set("tags", tagMap)
+		err = d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagMap))
 		if err != nil {
 			mErr = multierror.Append(mErr,
 				fmt.Errorf("error saving tags to state for DMS RabbitMQ instance (%s): %s", d.Id(), err))
@@ -676,7 +676,7 @@ func resourceDmsRabbitmqInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 
 	if d.HasChange("tags") {
 		// update tags
-		tagErr := utils.UpdateResourceTags(client, d, engineRabbitMQ, d.Id())
+		tagErr := utils.UpdateResourceTags(client, d, cfg.ProviderTags, engineRabbitMQ, d.Id())
 		if tagErr != nil {
 			mErr = multierror.Append(mErr, fmt.Errorf("error updating tags of DMS RabbitMQ instance: %s, err: %s",
 				d.Id(), tagErr))
@@ -562,7 +562,7 @@ func resourceDmsRocketMQInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 		}
 		// update tags
 		if d.HasChange("tags") {
-			tagErr := utils.UpdateResourceTags(updateRocketmqInstanceClient, d, "rocketmq", d.Id())
+			tagErr := utils.UpdateResourceTags(updateRocketmqInstanceClient, d, cfg.ProviderTags, "rocketmq", d.Id())
 			if tagErr != nil {
 				return diag.Errorf("error updating tags of RocketMQ:%s, err:%s", d.Id(), tagErr)
 			}
@@ -696,7 +696,7 @@ func resourceDmsRocketMQInstanceRead(_ context.Context, d *schema.ResourceData,
 	// fetch tags
 	if resourceTags, err := tags.Get(getRocketmqInstanceClient, "rocketmq", d.Id()).Extract(); err == nil {
 		tagMap := utils.TagsToMap(resourceTags.Tags)
-		mErr = multierror.Append(mErr, d.Set("tags", tagMap))
+		mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagMap)))
 	} else {
 		fmt.Printf("[WARN] fetching tags of RocketMQ failed: %s", err)
 	}
@@ -175,7 +175,7 @@ func resourceDNSPtrRecordRead(_ context.Context, d *schema.ResourceData, meta in
 		return diag.Errorf("error setting resource: %s", mErr)
 	}
 
-	if err := utils.SetResourceTagsToState(d, dnsClient, "DNS-ptr_record", d.Id()); err != nil {
+	if err := utils.SetResourceTagsToState(d, conf.ProviderTags, dnsClient, "DNS-ptr_record", d.Id()); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -212,7 +212,7 @@ func resourceDNSPtrRecordUpdate(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	// update tags
-	tagErr := utils.UpdateResourceTags(dnsClient, d, "DNS-ptr_record", d.Id())
+	tagErr := utils.UpdateResourceTags(dnsClient, d, conf.ProviderTags, "DNS-ptr_record", d.Id())
 	if tagErr != nil {
 		return diag.Errorf("error updating tags of DNS PTR record %s: %s", d.Id(), tagErr)
 	}
@@ -308,18 +308,19 @@ func resourceDNSRecordsetRead(_ context.Context, d *schema.ResourceData, meta in
 	}
 
 	// set tags
-	if err := setDNSRecordsetTags(d, getDNSRecordsetClient, recordsetID, zoneType); err != nil {
+	if err := setDNSRecordsetTags(d, cfg.ProviderTags, getDNSRecordsetClient, recordsetID, zoneType); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func setDNSRecordsetTags(d *schema.ResourceData, client *golangsdk.ServiceClient, id, zoneType string) error {
+func setDNSRecordsetTags(d *schema.ResourceData, tagsConfig *utils.ProviderTagsConfig, client *golangsdk.ServiceClient,
+	id, zoneType string) error {
 	resourceType, err := utils.GetDNSRecordSetTagType(zoneType)
 	if err != nil {
 		return err
 	}
-	return utils.SetResourceTagsToState(d, client, resourceType, id)
+	return utils.SetResourceTagsToState(d, tagsConfig, client, resourceType, id)
 }
 
 func getDNSRecordsetStatus(getDNSRecordsetRespBody interface{}) string {
@@ -393,7 +394,7 @@ func resourceDNSRecordsetUpdate(ctx context.Context, d *schema.ResourceData, met
 			return diag.FromErr(err)
 		}
 
-		err = utils.UpdateResourceTags(recordsetClient, d, resourceType, recordsetID)
+		err = utils.UpdateResourceTags(recordsetClient, d, cfg.ProviderTags, resourceType, recordsetID)
 		if err != nil {
 			return diag.Errorf("error updating DNS recordset tags: %s", err)
 		}
@@ -192,7 +192,7 @@ func resourceDNSRecordSetV2Read(_ context.Context, d *schema.ResourceData, meta
 	}
 	if resourceTags, err := tags.Get(dnsClient, resourceType, recordsetID).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		if err := d.Set("tags", tagmap); err != nil {
+		if err := d.Set("tags", utils.FilterResourceTags(d, conf.ProviderTags, tagmap)); err != nil {
 			return diag.Errorf("error saving tags to state for DNS record set (%s): %s", recordsetID, err)
 		}
 	} else {
@@ -263,7 +263,7 @@ func resourceDNSRecordSetV2Update(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("error getting resource type of DNS record set %s: %s", d.Id(), err)
 	}
 
-	tagErr := utils.UpdateResourceTags(dnsClient, d, resourceType, recordsetID)
+	tagErr := utils.UpdateResourceTags(dnsClient, d, meta.(*config.Config).ProviderTags, resourceType, recordsetID)
 	if tagErr != nil {
 		return diag.Errorf("error updating tags of DNS record set %s: %s", d.Id(), tagErr)
 	}
@@ -284,7 +284,7 @@ func resourceDNSZoneRead(_ context.Context, d *schema.ResourceData, meta interfa
 		resourceTags, err := tags.Get(dnsClient, resourceType, d.Id()).Extract()
 		if err == nil {
 			tagmap := utils.TagsToMap(resourceTags.Tags)
-			mErr = multierror.Append(mErr, d.Set("tags", tagmap))
+			mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, conf.ProviderTags, tagmap)))
 		} else {
 			log.Printf("[WARN] Error fetching DNS zone tags: %s", err)
 		}
@@ -340,7 +340,7 @@ func resourceDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf("error getting resource type of DNS zone %s: %s", d.Id(), err)
 	}
 
-	tagErr := utils.UpdateResourceTags(dnsClient, d, resourceType, d.Id())
+	tagErr := utils.UpdateResourceTags(dnsClient, d, conf.ProviderTags, resourceType, d.Id())
 	if tagErr != nil {
 		return diag.Errorf("error updating tags of DNS zone %s: %s", d.Id(), tagErr)
 	}
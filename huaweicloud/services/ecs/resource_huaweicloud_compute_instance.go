@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -114,9 +115,22 @@ func ResourceComputeInstance() *schema.Resource {
 				Description: "schema: Computed",
 			},
 			"admin_pass": {
-				Type:      schema.TypeString,
-				Sensitive: true,
-				Optional:  true,
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				ConflictsWith: []string{"admin_pass_wo"},
+			},
+			"admin_pass_wo": {
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"admin_pass"},
+			},
+			"admin_pass_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"admin_pass_wo"},
 			},
 			"key_pair": {
 				Type:     schema.TypeString,
@@ -535,6 +549,14 @@ func getSpotDurationCount(d *schema.ResourceData) int {
 	return count
 }
 
+// getAdminPass returns the admin password from the write-only argument admin_pass_wo or admin_pass.
+func getAdminPass(d *schema.ResourceData) string {
+	if v := utils.GetWriteOnlyString(d, cty.GetAttrPath("admin_pass_wo")); v != "" {
+		return v
+	}
+	return d.Get("admin_pass").(string)
+}
+
 func resourceComputeInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cfg := meta.(*config.Config)
 	region := cfg.GetRegion(d)
@@ -664,7 +686,7 @@ func resourceComputeInstanceCreate(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[DEBUG] ECS create options: %#v", createOpts)
 	// Add password here so it wouldn't go in the above log entry
-	createOpts.AdminPass = d.Get("admin_pass").(string)
+	createOpts.AdminPass = getAdminPass(d)
 
 	if d.Get("charging_mode") == "prePaid" {
 		// prePaid.
@@ -691,7 +713,7 @@ func resourceComputeInstanceCreate(ctx context.Context, d *schema.ResourceData,
 		if err != nil {
 			return diag.Errorf("error creating server: %s", err)
 		}
-		if err := cloudservers.WaitForJobSuccess(ecsClient, int(d.Timeout(schema.TimeoutCreate)/time.Second), n.JobID); err != nil {
+		if err := waitForJobSuccess(ctx, ecsClient, n.JobID, d.Timeout(schema.TimeoutCreate)); err != nil {
 			return diag.FromErr(err)
 		}
 		serverId, err := cloudservers.GetJobEntity(ecsClient, n.JobID, "server_id")
@@ -935,7 +957,7 @@ func resourceComputeInstanceRead(_ context.Context, d *schema.ResourceData, meta
 	}
 
 	// Set instance tags
-	d.Set("tags", flattenTagsToMap(server.Tags))
+	d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, flattenTagsToMap(server.Tags)))
 
 	return nil
 }
@@ -1038,12 +1060,10 @@ func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	if d.HasChange("admin_pass") {
-		if newPwd, ok := d.Get("admin_pass").(string); ok {
-			err := cloudservers.ChangeAdminPassword(ecsClient, serverID, newPwd).ExtractErr()
-			if err != nil {
-				return diag.Errorf("error changing admin password of server (%s): %s", serverID, err)
-			}
+	if d.HasChanges("admin_pass", "admin_pass_wo_version") {
+		err := cloudservers.ChangeAdminPassword(ecsClient, serverID, getAdminPass(d)).ExtractErr()
+		if err != nil {
+			return diag.Errorf("error changing admin password of server (%s): %s", serverID, err)
 		}
 	}
 
@@ -1067,7 +1087,7 @@ func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 			return diag.Errorf("error resizing server: %s", err)
 		}
 
-		if err := cloudservers.WaitForJobSuccess(ecsClient, int(d.Timeout(schema.TimeoutUpdate)/time.Second), job.JobID); err != nil {
+		if err := waitForJobSuccess(ctx, ecsClient, job.JobID, d.Timeout(schema.TimeoutUpdate)); err != nil {
 			return diag.Errorf("error waiting for instance (%s) to be resized: %s", serverID, err)
 		}
 	}
@@ -1085,7 +1105,7 @@ func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(ecsClient, d, "cloudservers", serverID)
+		tagErr := utils.UpdateResourceTags(ecsClient, d, cfg.ProviderTags, "cloudservers", serverID)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of instance:%s, err:%s", serverID, err)
 		}
@@ -1173,7 +1193,7 @@ func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 			InUsedKeyPair:    o.(string),
 			NewKeyPair:       n.(string),
 			InUsedPrivateKey: d.Get("private_key").(string),
-			Password:         d.Get("admin_pass").(string),
+			Password:         getAdminPass(d),
 			Timeout:          d.Timeout(schema.TimeoutUpdate),
 		}
 		if err := common.UpdateEcsInstanceKeyPair(ctx, ecsClient, kmsClient, keyPairOpts); err != nil {
@@ -1327,7 +1347,7 @@ func resourceComputeInstanceDelete(ctx context.Context, d *schema.ResourceData,
 			return diag.Errorf("error deleting server: %s", err)
 		}
 
-		if err := cloudservers.WaitForJobSuccess(ecsClient, int(d.Timeout(schema.TimeoutCreate)/time.Second), n.JobID); err != nil {
+		if err := waitForJobSuccess(ctx, ecsClient, n.JobID, d.Timeout(schema.TimeoutCreate)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -1680,6 +1700,31 @@ func waitForServerTargetState(ctx context.Context, client *golangsdk.ServiceClie
 	return nil
 }
 
+// waitForJobSuccess waits for the job to succeed like cloudservers.WaitForJobSuccess, but the job is polled with the
+// context, so the waiting is canceled together with the context.
+func waitForJobSuccess(ctx context.Context, client *golangsdk.ServiceClient, jobID string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"INIT", "RUNNING", "PENDING"},
+		Target:  []string{"SUCCESS"},
+		Refresh: func() (interface{}, string, error) {
+			var job cloudservers.JobStatus
+			if _, err := client.Get(client.ServiceURL("jobs", jobID), &job, nil); err != nil {
+				return nil, "ERROR", err
+			}
+			if job.Status == "FAIL" {
+				return job, job.Status, fmt.Errorf("job failed with code %s: %s", job.ErrorCode, job.FailReason)
+			}
+			return job, job.Status, nil
+		},
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
 // doPowerAction is a method for instance power doing shutdown, startup and reboot actions.
 func doPowerAction(client *golangsdk.ServiceClient, d *schema.ResourceData, action string) error {
 	var jobResp *cloudservers.JobResponse
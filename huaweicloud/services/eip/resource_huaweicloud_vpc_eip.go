@@ -467,7 +467,7 @@ func resourceVpcEipRead(_ context.Context, d *schema.ResourceData, meta interfac
 	if vpcV2Client, err := cfg.NetworkingV2Client(region); err == nil {
 		if resourceTags, err := tags.Get(vpcV2Client, "publicips", resourceId).Extract(); err == nil {
 			tagmap := utils.TagsToMap(resourceTags.Tags)
-			if err := d.Set("tags", tagmap); err != nil {
+			if err := d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagmap)); err != nil {
 				mErr = multierror.Append(mErr, fmt.Errorf("error saving tags for EIP (%s): %s", resourceId, err))
 			}
 		} else {
@@ -622,7 +622,7 @@ func resourceVpcEipUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 			return diag.Errorf("error creating VPC v2 client: %s", err)
 		}
 
-		tagErr := utils.UpdateResourceTags(vpcV2Client, d, "publicips", d.Id())
+		tagErr := utils.UpdateResourceTags(vpcV2Client, d, cfg.ProviderTags, "publicips", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of VPC (%s): %s", d.Id(), tagErr)
 		}
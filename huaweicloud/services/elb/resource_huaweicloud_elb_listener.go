@@ -399,7 +399,7 @@ func resourceListenerV3Read(_ context.Context, d *schema.ResourceData, meta inte
 	// fetch tags
 	if resourceTags, err := tags.Get(elbV2Client, "listeners", d.Id()).Extract(); err == nil {
 		tagMap := utils.TagsToMap(resourceTags.Tags)
-		mErr = multierror.Append(mErr, d.Set("tags", tagMap))
+		mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagMap)))
 	} else {
 		log.Printf("[WARN] fetching tags of ELB listener failed: %s", err)
 	}
@@ -435,7 +435,7 @@ func resourceListenerV3Update(ctx context.Context, d *schema.ResourceData, meta
 		if err != nil {
 			return diag.Errorf("error creating ELB 2.0 client: %s", err)
 		}
-		tagErr := utils.UpdateResourceTags(elbV2Client, d, "listeners", d.Id())
+		tagErr := utils.UpdateResourceTags(elbV2Client, d, cfg.ProviderTags, "listeners", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of ELB listener:%s, err:%s", d.Id(), tagErr)
 		}
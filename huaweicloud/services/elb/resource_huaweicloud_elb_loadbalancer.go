@@ -451,7 +451,7 @@ func resourceLoadBalancerV3Read(_ context.Context, d *schema.ResourceData, meta
 	// fetch tags
 	if resourceTags, err := tags.Get(elbV2Client, "loadbalancers", d.Id()).Extract(); err == nil {
 		tagMap := utils.TagsToMap(resourceTags.Tags)
-		mErr = multierror.Append(mErr, d.Set("tags", tagMap))
+		mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagMap)))
 	} else {
 		log.Printf("[WARN] Fetching tags of ELB LoadBalancer failed: %s", err)
 	}
@@ -521,7 +521,7 @@ func resourceLoadBalancerV3Update(ctx context.Context, d *schema.ResourceData, m
 		if err != nil {
 			return diag.Errorf("error creating ELB 2.0 client: %s", err)
 		}
-		tagErr := utils.UpdateResourceTags(elbV2Client, d, "loadbalancers", d.Id())
+		tagErr := utils.UpdateResourceTags(elbV2Client, d, cfg.ProviderTags, "loadbalancers", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of LoadBalancer:%s, err:%s", d.Id(), tagErr)
 		}
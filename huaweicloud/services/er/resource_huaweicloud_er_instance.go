@@ -213,7 +213,7 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
-	err = utils.UpdateResourceTags(client, d, "instance", d.Id())
+	err = utils.UpdateResourceTags(client, d, cfg.ProviderTags, "instance", d.Id())
 	if err != nil {
 		return diag.Errorf("error creating instance tags: %s", err)
 	}
@@ -316,6 +316,16 @@ func instanceWaitingForStateCompleted(ctx context.Context, d *schema.ResourceDat
 	return err
 }
 
+// flattenInstanceTags returns the tags of the instance, the values are kept even if they are empty.
+func flattenInstanceTags(getInstanceRespBody interface{}) map[string]string {
+	tagMap := utils.FlattenTagsToMap(utils.PathSearch("instance.tags", getInstanceRespBody, nil))
+	result := make(map[string]string, len(tagMap))
+	for k, v := range tagMap {
+		result[k], _ = v.(string)
+	}
+	return result
+}
+
 func resourceInstanceRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cfg := meta.(*config.Config)
 	region := cfg.GetRegion(d)
@@ -369,7 +379,7 @@ func resourceInstanceRead(_ context.Context, d *schema.ResourceData, meta interf
 		d.Set("default_association_route_table_id", utils.PathSearch("instance.default_association_route_table_id", getInstanceRespBody, nil)),
 		d.Set("availability_zones", utils.PathSearch("instance.availability_zone_ids", getInstanceRespBody, nil)),
 		d.Set("auto_accept_shared_attachments", utils.PathSearch("instance.auto_accept_shared_attachments", getInstanceRespBody, nil)),
-		d.Set("tags", utils.FlattenTagsToMap(utils.PathSearch("instance.tags", getInstanceRespBody, nil))),
+		d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, flattenInstanceTags(getInstanceRespBody))),
 	)
 
 	return diag.FromErr(mErr.ErrorOrNil())
@@ -446,7 +456,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	if d.HasChange("tags") {
-		err = utils.UpdateResourceTags(client, d, "instance", d.Id())
+		err = utils.UpdateResourceTags(client, d, cfg.ProviderTags, "instance", d.Id())
 		if err != nil {
 			return diag.Errorf("error updating instance tags: %s", err)
 		}
@@ -419,7 +419,7 @@ func resourceEvsVolumeRead(_ context.Context, d *schema.ResourceData, meta inter
 		d.Set("region", cfg.GetRegion(d)),
 		d.Set("wwn", resp.WWN),
 		d.Set("multiattach", resp.Multiattach),
-		d.Set("tags", resp.Tags),
+		d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, resp.Tags)),
 		d.Set("dedicated_storage_id", resp.DedicatedStorageID),
 		d.Set("dedicated_storage_name", resp.DedicatedStorageName),
 		setEvsVolumeChargingInfo(d, resp),
@@ -515,7 +515,7 @@ func resourceEvsVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(evsV2Client, d, "cloudvolumes", d.Id())
+		tagErr := utils.UpdateResourceTags(evsV2Client, d, cfg.ProviderTags, "cloudvolumes", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of volume:%s, err:%s", d.Id(), tagErr)
 		}
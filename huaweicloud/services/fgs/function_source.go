@@ -0,0 +1,107 @@
+package fgs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"path"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk/openstack/fgs/v2/function"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	obsservice "github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/obs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func isFunctionSourceConfigured(d *schema.ResourceData) bool {
+	return d.Get("source_dir").(string) != "" || d.Get("source_file").(string) != ""
+}
+
+// computeFunctionSourceCodeHash returns the base64-encoded SHA256 hash of the source archive.
+func computeFunctionSourceCodeHash(sourceDir, sourceFile string) (string, error) {
+	hash := sha256.New()
+	if err := utils.WriteZipArchive(sourceDir, sourceFile, hash); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+func getFunctionSourceObjectKey(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("source_object_key"); ok {
+		return v.(string)
+	}
+	return d.Get("name").(string) + ".zip"
+}
+
+// uploadFunctionSource archives the source and uploads the archive to the OBS bucket, the code configuration of the
+// function is updated to use the uploaded object.
+func uploadFunctionSource(cfg *config.Config, d *schema.ResourceData) error {
+	region := cfg.GetRegion(d)
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return fmt.Errorf("error creating OBS client: %s", err)
+	}
+
+	bucket := d.Get("source_bucket").(string)
+	key, hash, err := utils.UploadZipArchive(obsClient, d.Get("source_dir").(string), d.Get("source_file").(string),
+		bucket, func([]byte) string { return getFunctionSourceObjectKey(d) })
+	if err != nil {
+		return fmt.Errorf("error uploading the function source: %s", err)
+	}
+
+	codeUrl := fmt.Sprintf("https://%s/%s", obsservice.BucketDomainNameWithCloud(bucket, region, cfg.Cloud), key)
+	mErr := multierror.Append(
+		d.Set("code_type", "obs"),
+		d.Set("code_url", codeUrl),
+		d.Set("code_filename", path.Base(key)),
+		d.Set("source_code_hash", base64.StdEncoding.EncodeToString(hash)),
+	)
+	return mErr.ErrorOrNil()
+}
+
+// publishFunctionVersion publishes a new version for the uploaded source code.
+func publishFunctionVersion(cfg *config.Config, d *schema.ResourceData) error {
+	fgsClient, err := cfg.FgsV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return fmt.Errorf("error creating FunctionGraph v2 client: %s", err)
+	}
+
+	opts := function.CreateVersionOpts{
+		Description: fmt.Sprintf("source_code_hash: %s", d.Get("source_code_hash")),
+	}
+	version, err := function.CreateVersion(fgsClient, opts, resourceFgsFunctionUrn(d.Id())).Extract()
+	if err != nil {
+		return fmt.Errorf("error publishing function version: %s", err)
+	}
+	return d.Set("published_version", version.Version)
+}
+
+// resourceFunctionSourceCodeHashDiff computes the hash of the source archive during plan, the code is uploaded again
+// and a new version is published when the hash is changed.
+func resourceFunctionSourceCodeHashDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source_dir") || !d.NewValueKnown("source_file") {
+		return d.SetNewComputed("source_code_hash")
+	}
+
+	sourceDir := d.Get("source_dir").(string)
+	sourceFile := d.Get("source_file").(string)
+	if sourceDir == "" && sourceFile == "" {
+		return nil
+	}
+
+	hash, err := computeFunctionSourceCodeHash(sourceDir, sourceFile)
+	if err != nil {
+		return err
+	}
+	if hash != d.Get("source_code_hash").(string) {
+		if err := d.SetNew("source_code_hash", hash); err != nil {
+			return err
+		}
+		return d.SetNewComputed("published_version")
+	}
+	return nil
+}
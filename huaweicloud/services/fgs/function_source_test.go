@@ -0,0 +1,64 @@
+package fgs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestFile(t *testing.T, filePath, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestComputeFunctionSourceCodeHash(t *testing.T) {
+	sourceDir := t.TempDir()
+	sourceFile := filepath.Join(sourceDir, "index.py")
+	writeTestFile(t, sourceFile, "def handler(event, context):\n    return 'ok'\n")
+
+	hash, err := computeFunctionSourceCodeHash(sourceDir, "")
+	if err != nil {
+		t.Fatalf("error computing the hash: %s", err)
+	}
+
+	// the hash does not depend on the modification time of the files
+	modified := time.Now().Add(time.Hour)
+	if err := os.Chtimes(sourceFile, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+	sameHash, err := computeFunctionSourceCodeHash(sourceDir, "")
+	if err != nil {
+		t.Fatalf("error computing the hash: %s", err)
+	}
+	if sameHash != hash {
+		t.Errorf("the hash should not be changed by the modification time, want %s, but got %s", hash, sameHash)
+	}
+
+	writeTestFile(t, sourceFile, "def handler(event, context):\n    return 'changed'\n")
+	newHash, err := computeFunctionSourceCodeHash(sourceDir, "")
+	if err != nil {
+		t.Fatalf("error computing the hash: %s", err)
+	}
+	if newHash == hash {
+		t.Errorf("the hash should be changed by the content, but got the same hash %s", hash)
+	}
+
+	fileHash, err := computeFunctionSourceCodeHash("", sourceFile)
+	if err != nil {
+		t.Fatalf("error computing the hash of the source file: %s", err)
+	}
+	if fileHash != newHash {
+		t.Errorf("the archive of the single file should be the same as the directory, want %s, but got %s",
+			newHash, fileHash)
+	}
+
+	if _, err := computeFunctionSourceCodeHash("", filepath.Join(sourceDir, "not-exist.py")); err == nil {
+		t.Errorf("expected an error of the nonexistent source file, but got nil")
+	}
+}
@@ -29,6 +29,7 @@ import (
 // API: FunctionGraph GET /v2/{project_id}/fgs/functions/{function_urn}/versions
 // API: FunctionGraph POST /v2/{project_id}/{resource_type}/{resource_id}/tags/create
 // API: FunctionGraph DELETE /v2/{project_id}/{resource_type}/{resource_id}/tags/delete
+// API: FunctionGraph GET /v2/{project_id}/{resource_type}/{resource_id}/tags
 // API: FunctionGraph PUT /v2/{project_id}/fgs/functions/{function_urn}/code
 // API: FunctionGraph PUT /v2/{project_id}/fgs/functions/{function_urn}/config
 // API: FunctionGraph PUT /v2/{project_id}/fgs/functions/{function_urn}/config-max-instance
@@ -51,6 +52,8 @@ func ResourceFgsFunctionV2() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
+		CustomizeDiff: resourceFunctionDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -115,6 +118,7 @@ func ResourceFgsFunctionV2() *schema.Resource {
 			"code_url": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 			"code_filename": {
 				Type:     schema.TypeString,
@@ -151,6 +155,34 @@ func ResourceFgsFunctionV2() *schema.Resource {
 				Optional:  true,
 				StateFunc: utils.DecodeHashAndHexEncode,
 			},
+			"source_dir": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"source_file", "func_code", "code_url"},
+				RequiredWith:  []string{"source_bucket"},
+			},
+			"source_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"func_code", "code_url"},
+				RequiredWith:  []string{"source_bucket"},
+			},
+			"source_bucket": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"source_object_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"source_code_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"published_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"depend_list": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -311,6 +343,12 @@ func ResourceFgsFunctionV2() *schema.Resource {
 										Optional:    true,
 										Description: "The description of the version alias.",
 									},
+									"additional_version_weights": {
+										Type:        schema.TypeMap,
+										Optional:    true,
+										Elem:        &schema.Schema{Type: schema.TypeInt},
+										Description: "The percentage of the traffic routed to the additional versions.",
+									},
 								},
 							},
 							Description: "The aliases management for specified version.",
@@ -319,7 +357,8 @@ func ResourceFgsFunctionV2() *schema.Resource {
 				},
 				Description: "The versions management of the function.",
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsComputedSchema(),
 			"version": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -332,6 +371,14 @@ func ResourceFgsFunctionV2() *schema.Resource {
 	}
 }
 
+// resourceFunctionDiff calculates the source code hash and the tags merged with the provider-level default tags.
+func resourceFunctionDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := resourceFunctionSourceCodeHashDiff(ctx, d, meta); err != nil {
+		return err
+	}
+	return utils.SetTagsAllDiff(ctx, d, meta)
+}
+
 func buildCustomImage(imageConfig []interface{}) *function.CustomImage {
 	if len(imageConfig) < 1 {
 		return nil
@@ -408,6 +455,12 @@ func resourceFgsFunctionCreate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error creating FunctionGraph v2 client: %s", err)
 	}
 
+	if isFunctionSourceConfigured(d) {
+		if err := uploadFunctionSource(cfg, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	createOpts, err := buildFgsFunctionParameters(d, cfg)
 	if err != nil {
 		return diag.FromErr(err)
@@ -445,23 +498,53 @@ func resourceFgsFunctionCreate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	if tagList, ok := d.GetOk("tags"); ok {
+	// the tags are managed by the FunctionGraph API instead of the common tags API, so utils.CreateResourceTags is
+	// not used, but the tags are built in the same way
+	tagsConfig := cfg.ProviderTags
+	tagList := tagsConfig.RemoveIgnoredTags(tagsConfig.MergeDefaultTags(d.Get("tags").(map[string]interface{})))
+	if len(tagList) > 0 {
 		opts := function.TagsActionOpts{
-			Tags: utils.ExpandResourceTags(tagList.(map[string]interface{})),
+			Tags: utils.ExpandResourceTags(tagList),
 		}
 		if err := function.CreateResourceTags(fgsClient, d.Id(), opts); err != nil {
 			return diag.Errorf("failed to add tags to FunctionGraph function (%s): %s", d.Id(), err)
 		}
 	}
 
-	if err = createFunctionVersions(fgsClient, urn, d.Get("versions").(*schema.Set)); err != nil {
+	if err = createFunctionVersions(fgsClient, urn, d.Get("versions").(*schema.Set), nil); err != nil {
 		return diag.Errorf("error creating function versions: %s", err)
 	}
 
+	if isFunctionSourceConfigured(d) {
+		if err := publishFunctionVersion(cfg, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceFgsFunctionRead(ctx, d, meta)
 }
 
-func createFunctionVersions(client *golangsdk.ServiceClient, functionUrn string, versionSet *schema.Set) error {
+// getFunctionVersionAliases returns the alias configurations with the version names, the keys are the alias names.
+func getFunctionVersionAliases(versionSet *schema.Set) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{})
+	for _, v := range versionSet.List() {
+		version := v.(map[string]interface{})
+		aliasCfg := version["aliases"].([]interface{})
+		if len(aliasCfg) < 1 {
+			continue
+		}
+		alias := aliasCfg[0].(map[string]interface{})
+		result[alias["name"].(string)] = map[string]interface{}{
+			"version":                    version["name"],
+			"description":                alias["description"],
+			"additional_version_weights": alias["additional_version_weights"],
+		}
+	}
+	return result
+}
+
+func createFunctionVersions(client *golangsdk.ServiceClient, functionUrn string, versionSet *schema.Set,
+	skippedAliases []string) error {
 	for _, v := range versionSet.List() {
 		version := v.(map[string]interface{})
 		versionNum := version["name"].(string) // The version name, also name as the version number.
@@ -472,11 +555,15 @@ func createFunctionVersions(client *golangsdk.ServiceClient, functionUrn string,
 			continue
 		}
 		alias := aliasCfg[0].(map[string]interface{})
+		if utils.StrSliceContains(skippedAliases, alias["name"].(string)) {
+			continue
+		}
 		opt := aliases.CreateOpts{
-			FunctionUrn: functionUrn,
-			Name:        alias["name"].(string),
-			Version:     versionNum,
-			Description: alias["description"].(string),
+			FunctionUrn:              functionUrn,
+			Name:                     alias["name"].(string),
+			Version:                  versionNum,
+			Description:              alias["description"].(string),
+			AdditionalVersionWeights: alias["additional_version_weights"].(map[string]interface{}),
 		}
 		_, err := aliases.Create(client, opt)
 		if err != nil {
@@ -575,13 +662,25 @@ func queryFunctionAliases(client *golangsdk.ServiceClient, functionUrn string) (
 	result := make(map[string][]interface{})
 	for _, v := range aliasList {
 		result[v.Version] = append(result[v.Version], map[string]interface{}{
-			"name":        v.Name,
-			"description": v.Description,
+			"name":                       v.Name,
+			"description":                v.Description,
+			"additional_version_weights": flattenAdditionalVersionWeights(v.AdditionalVersionWeights),
 		})
 	}
 	return result, nil
 }
 
+// flattenAdditionalVersionWeights converts the weights, which are decoded as float64 from the JSON response, to int.
+func flattenAdditionalVersionWeights(weights map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(weights))
+	for version, weight := range weights {
+		if v, ok := weight.(float64); ok {
+			result[version] = int(v)
+		}
+	}
+	return result
+}
+
 func parseFunctionVersions(client *golangsdk.ServiceClient, functionUrn string) ([]map[string]interface{}, error) {
 	versionList, err := queryFunctionVersions(client, functionUrn)
 	if err != nil {
@@ -655,6 +754,7 @@ func resourceFgsFunctionRead(_ context.Context, d *schema.ResourceData, meta int
 		setFgsFunctionVpcAccess(d, f.FuncVpc),
 		setFuncionMountConfig(d, f.MountConfig),
 		d.Set("versions", versionConfig),
+		utils.SetResourceTagsAllToState(d, cfg.ProviderTags, fgsClient, "functions", functionUrn),
 	)
 	if err := mErr.ErrorOrNil(); err != nil {
 		return diag.Errorf("error setting function fields: %s", err)
@@ -663,14 +763,23 @@ func resourceFgsFunctionRead(_ context.Context, d *schema.ResourceData, meta int
 	return nil
 }
 
-func updateFunctionTags(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
+// updateFunctionTags updates the function tags in the same way as utils.UpdateResourceTagsAll, the old tags are taken
+// from "tags_all" and the new tags are merged with the provider-level default tags.
+func updateFunctionTags(client *golangsdk.ServiceClient, d *schema.ResourceData,
+	tagsConfig *utils.ProviderTagsConfig) error {
 	var (
 		oRaw, nRaw  = d.GetChange("tags")
-		oMap        = oRaw.(map[string]interface{})
-		nMap        = nRaw.(map[string]interface{})
+		oRawAll, _  = d.GetChange("tags_all")
+		oMap        = tagsConfig.MergeDefaultTags(oRaw.(map[string]interface{}))
+		nMap        = tagsConfig.RemoveIgnoredTags(tagsConfig.MergeDefaultTags(nRaw.(map[string]interface{})))
 		functionUrn = d.Id()
 	)
 
+	if len(oRawAll.(map[string]interface{})) > 0 {
+		oMap = oRawAll.(map[string]interface{})
+	}
+	oMap = tagsConfig.RemoveIgnoredTags(oMap)
+
 	if len(oMap) > 0 {
 		opts := function.TagsActionOpts{
 			Tags: utils.ExpandResourceTags(oMap),
@@ -691,13 +800,17 @@ func updateFunctionTags(client *golangsdk.ServiceClient, d *schema.ResourceData)
 	return nil
 }
 
-func deleteFunctionVersions(client *golangsdk.ServiceClient, functionUrn string, versionSet *schema.Set) error {
+func deleteFunctionVersions(client *golangsdk.ServiceClient, functionUrn string, versionSet *schema.Set,
+	keptAliases []string) error {
 	// In the future, the function will support manage multiple versions.
 	for _, v := range versionSet.List() {
 		version := v.(map[string]interface{})
 		aliasCfg := version["aliases"].([]interface{})
 		if len(aliasCfg) > 0 {
 			alias := aliasCfg[0].(map[string]interface{})
+			if utils.StrSliceContains(keptAliases, alias["name"].(string)) {
+				continue
+			}
 			err := aliases.Delete(client, functionUrn, alias["name"].(string))
 			if err != nil {
 				return err
@@ -718,12 +831,34 @@ func updateFunctionVersions(client *golangsdk.ServiceClient, d *schema.ResourceD
 		increase       = newSet.(*schema.Set).Difference(oldSet.(*schema.Set))
 	)
 
-	err := deleteFunctionVersions(client, functionUrn, decrease)
+	// The aliases which exist in both old and new configurations are updated in place, so that the traffic routed
+	// by them is not interrupted, e.g. shifting the weights of the additional versions for the canary release.
+	oldAliases := getFunctionVersionAliases(decrease)
+	newAliases := getFunctionVersionAliases(increase)
+	updatedAliases := make([]string, 0)
+	for name, newAlias := range newAliases {
+		if _, ok := oldAliases[name]; !ok {
+			continue
+		}
+		opts := aliases.UpdateOpts{
+			FunctionUrn:              functionUrn,
+			Name:                     name,
+			Version:                  newAlias["version"].(string),
+			Description:              newAlias["description"].(string),
+			AdditionalVersionWeights: newAlias["additional_version_weights"].(map[string]interface{}),
+		}
+		if _, err := aliases.Update(client, opts); err != nil {
+			return fmt.Errorf("error updating function alias (%s): %s", name, err)
+		}
+		updatedAliases = append(updatedAliases, name)
+	}
+
+	err := deleteFunctionVersions(client, functionUrn, decrease, updatedAliases)
 	if err != nil {
 		return fmt.Errorf("error deleting function versions: %s", err)
 	}
 
-	err = createFunctionVersions(client, functionUrn, increase)
+	err = createFunctionVersions(client, functionUrn, increase, updatedAliases)
 	if err != nil {
 		return fmt.Errorf("error creating function versions: %s", err)
 	}
@@ -741,7 +876,15 @@ func resourceFgsFunctionUpdate(ctx context.Context, d *schema.ResourceData, meta
 	urn := resourceFgsFunctionUrn(d.Id())
 
 	// lintignore:R019
-	if d.HasChanges("code_type", "code_url", "code_filename", "depend_list", "func_code") {
+	sourceChanged := isFunctionSourceConfigured(d) &&
+		d.HasChanges("source_code_hash", "source_dir", "source_file", "source_bucket", "source_object_key")
+	if sourceChanged {
+		if err := uploadFunctionSource(cfg, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	// lintignore:R019
+	if sourceChanged || d.HasChanges("code_type", "code_url", "code_filename", "depend_list", "func_code") {
 		err := resourceFgsFunctionCodeUpdate(fgsClient, urn, d)
 		if err != nil {
 			return diag.FromErr(err)
@@ -766,8 +909,14 @@ func resourceFgsFunctionUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	if d.HasChange("tags") {
-		if err = updateFunctionTags(fgsClient, d); err != nil {
+	if sourceChanged {
+		if err := publishFunctionVersion(cfg, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChanges("tags", "tags_all") {
+		if err = updateFunctionTags(fgsClient, d, cfg.ProviderTags); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -0,0 +1,351 @@
+package fgs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// API: FunctionGraph PUT /v2/{project_id}/fgs/functions/{function_urn}/reservedinstances
+// API: FunctionGraph GET /v2/{project_id}/fgs/functions/reservedinstanceconfigs
+func ResourceFunctionReservedInstances() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFunctionReservedInstancesCreate,
+		ReadContext:   resourceFunctionReservedInstancesRead,
+		UpdateContext: resourceFunctionReservedInstancesUpdate,
+		DeleteContext: resourceFunctionReservedInstancesDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFunctionReservedInstancesImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The region where the function is located.",
+			},
+			"function_urn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The URN of the function (without the version) to which the reserved instances belong.",
+			},
+			"qualifier_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"version", "alias"}, false),
+				Description:  "The qualifier type of the reserved instances.",
+			},
+			"qualifier_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The version name or alias name of the reserved instances.",
+			},
+			"min_count": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "The number of the reserved instances, it can be zero only if the scheduled " +
+					"policies are configured.",
+			},
+			"idle_mode": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to enable the idle mode of the reserved instances.",
+			},
+			"tactics_config": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        reservedInstancesTacticsConfigSchema(),
+				Description: "The auto scaling policies of the reserved instances.",
+			},
+		},
+	}
+}
+
+func reservedInstancesTacticsConfigSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"cron_configs": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the scheduled policy.",
+						},
+						"cron": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The cron expression of the scheduled policy.",
+						},
+						"count": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The number of the reserved instances when the scheduled policy takes effect.",
+						},
+						"start_time": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The time when the scheduled policy takes effect, in UNIX timestamp (seconds).",
+						},
+						"expired_time": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The time when the scheduled policy expires, in UNIX timestamp (seconds).",
+						},
+					},
+				},
+				Description: "The scheduled policies of the reserved instances.",
+			},
+		},
+	}
+}
+
+// buildReservedInstancesFunctionUrn returns the function URN with the version or alias name, e.g.
+// urn:fss:cn-north-4:{project_id}:function:default:{function_name}:{qualifier_name}
+func buildReservedInstancesFunctionUrn(functionUrn, qualifierName string) string {
+	return fmt.Sprintf("%s:%s", functionUrn, qualifierName)
+}
+
+// buildReservedInstancesTacticsConfig returns the scheduled policies, which are empty rather than nil if they are not
+// configured, so that the policies are removed explicitly instead of being omitted from the request by RemoveNil.
+func buildReservedInstancesTacticsConfig(tacticsConfigs []interface{}) map[string]interface{} {
+	result := make([]interface{}, 0)
+	if len(tacticsConfigs) > 0 && tacticsConfigs[0] != nil {
+		tacticsConfig := tacticsConfigs[0].(map[string]interface{})
+		for _, v := range tacticsConfig["cron_configs"].([]interface{}) {
+			cronConfig := v.(map[string]interface{})
+			result = append(result, map[string]interface{}{
+				"name":         cronConfig["name"],
+				"cron":         cronConfig["cron"],
+				"count":        cronConfig["count"],
+				"start_time":   cronConfig["start_time"],
+				"expired_time": cronConfig["expired_time"],
+			})
+		}
+	}
+	return map[string]interface{}{
+		"cron_configs": result,
+	}
+}
+
+func buildReservedInstancesBodyParams(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"count":          d.Get("min_count"),
+		"idle_mode":      d.Get("idle_mode"),
+		"tactics_config": buildReservedInstancesTacticsConfig(d.Get("tactics_config").([]interface{})),
+	}
+}
+
+func updateFunctionReservedInstances(client *golangsdk.ServiceClient, functionUrn string,
+	bodyParams map[string]interface{}) error {
+	updateHttpUrl := "v2/{project_id}/fgs/functions/{function_urn}/reservedinstances"
+	updatePath := client.Endpoint + updateHttpUrl
+	updatePath = strings.ReplaceAll(updatePath, "{project_id}", client.ProjectID)
+	updatePath = strings.ReplaceAll(updatePath, "{function_urn}", functionUrn)
+	updateOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody:         utils.RemoveNil(bodyParams),
+	}
+	_, err := client.Request("PUT", updatePath, &updateOpt)
+	return err
+}
+
+func modifyFunctionReservedInstances(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
+	functionUrn := buildReservedInstancesFunctionUrn(d.Get("function_urn").(string), d.Get("qualifier_name").(string))
+	return updateFunctionReservedInstances(client, functionUrn, buildReservedInstancesBodyParams(d))
+}
+
+func resourceFunctionReservedInstancesCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.FgsV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating FunctionGraph v2 client: %s", err)
+	}
+
+	if err = modifyFunctionReservedInstances(client, d); err != nil {
+		return diag.Errorf("error configuring the reserved instances of the function: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s/%s", d.Get("function_urn"), d.Get("qualifier_type"), d.Get("qualifier_name")))
+
+	return resourceFunctionReservedInstancesRead(ctx, d, meta)
+}
+
+// GetFunctionReservedInstances returns the reserved instances configuration of the version or alias.
+func GetFunctionReservedInstances(client *golangsdk.ServiceClient, functionUrn, qualifierType,
+	qualifierName string) (interface{}, error) {
+	listHttpUrl := "v2/{project_id}/fgs/functions/reservedinstanceconfigs"
+	listPath := client.Endpoint + listHttpUrl
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+	listPath += "?function_urn=" + url.QueryEscape(functionUrn)
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	var marker float64
+	for {
+		listPathWithMarker := listPath
+		if marker != 0 {
+			listPathWithMarker += fmt.Sprintf("&marker=%d", int(marker))
+		}
+		listResp, err := client.Request("GET", listPathWithMarker, &listOpt)
+		if err != nil {
+			return nil, err
+		}
+		listRespBody, err := utils.FlattenResponse(listResp)
+		if err != nil {
+			return nil, err
+		}
+
+		configs := utils.PathSearch("reserved_instances", listRespBody, make([]interface{}, 0)).([]interface{})
+		if len(configs) < 1 {
+			break
+		}
+		expression := fmt.Sprintf("[?qualifier_type=='%s' && qualifier_name=='%s']|[0]", qualifierType, qualifierName)
+		if reservedInstances := utils.PathSearch(expression, configs, nil); reservedInstances != nil {
+			// the released configuration is still returned with the zero count and without any policies
+			minCount := utils.PathSearch("min_count", reservedInstances, float64(0)).(float64)
+			cronConfigs := utils.PathSearch("tactics_config.cron_configs", reservedInstances,
+				make([]interface{}, 0)).([]interface{})
+			if minCount == 0 && len(cronConfigs) == 0 {
+				break
+			}
+			return reservedInstances, nil
+		}
+
+		// the next marker is the offset of the next page, the pages are queried until the marker stops advancing
+		nextMarker := utils.PathSearch("page_info.next_marker", listRespBody, float64(0)).(float64)
+		if nextMarker <= marker {
+			break
+		}
+		marker = nextMarker
+	}
+	return nil, golangsdk.ErrDefault404{}
+}
+
+func flattenReservedInstancesTacticsConfig(tacticsConfig interface{}) []map[string]interface{} {
+	cronConfigs := utils.PathSearch("cron_configs", tacticsConfig, make([]interface{}, 0)).([]interface{})
+	if len(cronConfigs) < 1 {
+		return nil
+	}
+
+	result := make([]map[string]interface{}, 0, len(cronConfigs))
+	for _, v := range cronConfigs {
+		result = append(result, map[string]interface{}{
+			"name":         utils.PathSearch("name", v, nil),
+			"cron":         utils.PathSearch("cron", v, nil),
+			"count":        utils.PathSearch("count", v, nil),
+			"start_time":   utils.PathSearch("start_time", v, nil),
+			"expired_time": utils.PathSearch("expired_time", v, nil),
+		})
+	}
+	return []map[string]interface{}{
+		{
+			"cron_configs": result,
+		},
+	}
+}
+
+func resourceFunctionReservedInstancesRead(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.FgsV2Client(region)
+	if err != nil {
+		return diag.Errorf("error creating FunctionGraph v2 client: %s", err)
+	}
+
+	functionUrn := d.Get("function_urn").(string)
+	qualifierType := d.Get("qualifier_type").(string)
+	qualifierName := d.Get("qualifier_name").(string)
+	reservedInstances, err := GetFunctionReservedInstances(client, functionUrn, qualifierType, qualifierName)
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "reserved instances of the function")
+	}
+
+	mErr := multierror.Append(
+		d.Set("region", region),
+		d.Set("min_count", utils.PathSearch("min_count", reservedInstances, nil)),
+		d.Set("idle_mode", utils.PathSearch("idle_mode", reservedInstances, nil)),
+		d.Set("tactics_config", flattenReservedInstancesTacticsConfig(
+			utils.PathSearch("tactics_config", reservedInstances, nil))),
+	)
+	if err = mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting the reserved instances fields: %s", err)
+	}
+	return nil
+}
+
+func resourceFunctionReservedInstancesUpdate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.FgsV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating FunctionGraph v2 client: %s", err)
+	}
+
+	if err = modifyFunctionReservedInstances(client, d); err != nil {
+		return diag.Errorf("error updating the reserved instances of the function: %s", err)
+	}
+	return resourceFunctionReservedInstancesRead(ctx, d, meta)
+}
+
+func resourceFunctionReservedInstancesDelete(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.FgsV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating FunctionGraph v2 client: %s", err)
+	}
+
+	// the reserved instances are released by setting the count to zero without any policies
+	functionUrn := buildReservedInstancesFunctionUrn(d.Get("function_urn").(string), d.Get("qualifier_name").(string))
+	bodyParams := map[string]interface{}{
+		"count":          0,
+		"idle_mode":      false,
+		"tactics_config": buildReservedInstancesTacticsConfig(nil),
+	}
+	if err = updateFunctionReservedInstances(client, functionUrn, bodyParams); err != nil {
+		return common.CheckDeletedDiag(d, err, "reserved instances of the function")
+	}
+	return nil
+}
+
+func resourceFunctionReservedInstancesImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid format of import ID, want '<function_urn>/<qualifier_type>/<qualifier_name>', "+
+			"but got '%s'", d.Id())
+	}
+
+	mErr := multierror.Append(
+		d.Set("function_urn", parts[0]),
+		d.Set("qualifier_type", parts[1]),
+		d.Set("qualifier_name", parts[2]),
+	)
+	return []*schema.ResourceData{d}, mErr.ErrorOrNil()
+}
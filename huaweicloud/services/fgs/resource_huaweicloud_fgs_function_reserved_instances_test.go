@@ -0,0 +1,225 @@
+package fgs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func TestBuildReservedInstancesBodyParams(t *testing.T) {
+	cronConfig := map[string]interface{}{
+		"name":         "daytime",
+		"cron":         "0 0 8 * * ?",
+		"count":        2,
+		"start_time":   1700000000,
+		"expired_time": 1800000000,
+	}
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "with the scheduled policies",
+			raw: map[string]interface{}{
+				"min_count": 1,
+				"idle_mode": true,
+				"tactics_config": []interface{}{
+					map[string]interface{}{
+						"cron_configs": []interface{}{cronConfig},
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"count":     1,
+				"idle_mode": true,
+				"tactics_config": map[string]interface{}{
+					"cron_configs": []interface{}{cronConfig},
+				},
+			},
+		},
+		{
+			// the policies are removed by the empty list, as the omitted tactics_config keeps them unchanged
+			name: "without the scheduled policies",
+			raw: map[string]interface{}{
+				"min_count": 1,
+			},
+			expected: map[string]interface{}{
+				"count":     1,
+				"idle_mode": false,
+				"tactics_config": map[string]interface{}{
+					"cron_configs": []interface{}{},
+				},
+			},
+		},
+		{
+			name: "with the empty tactics_config",
+			raw: map[string]interface{}{
+				"min_count":      0,
+				"tactics_config": []interface{}{map[string]interface{}{}},
+			},
+			expected: map[string]interface{}{
+				"count":     0,
+				"idle_mode": false,
+				"tactics_config": map[string]interface{}{
+					"cron_configs": []interface{}{},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, ResourceFunctionReservedInstances().Schema, tc.raw)
+		// the request body is sent without the nil values
+		actual := utils.RemoveNil(buildReservedInstancesBodyParams(d))
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected the request body %v, but got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestFlattenReservedInstancesTacticsConfig(t *testing.T) {
+	cronConfig := map[string]interface{}{
+		"name":         "daytime",
+		"cron":         "0 0 8 * * ?",
+		"count":        float64(2),
+		"start_time":   float64(1700000000),
+		"expired_time": float64(1800000000),
+	}
+	cases := []struct {
+		name          string
+		tacticsConfig interface{}
+		expected      []map[string]interface{}
+	}{
+		{name: "nil", tacticsConfig: nil, expected: nil},
+		{
+			name:          "without the scheduled policies",
+			tacticsConfig: map[string]interface{}{"cron_configs": []interface{}{}},
+			expected:      nil,
+		},
+		{
+			name:          "with the scheduled policies",
+			tacticsConfig: map[string]interface{}{"cron_configs": []interface{}{cronConfig}},
+			expected: []map[string]interface{}{
+				{"cron_configs": []map[string]interface{}{cronConfig}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		actual := flattenReservedInstancesTacticsConfig(tc.tacticsConfig)
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected the tactics config %v, but got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestResourceFunctionReservedInstancesImportState(t *testing.T) {
+	functionUrn := "urn:fss:cn-north-4:0123456789abcdef:function:default:test"
+	cases := []struct {
+		importedID    string
+		qualifierType string
+		qualifierName string
+		isErr         bool
+	}{
+		{importedID: functionUrn + "/version/latest", qualifierType: "version", qualifierName: "latest"},
+		{importedID: functionUrn + "/alias/prod", qualifierType: "alias", qualifierName: "prod"},
+		{importedID: functionUrn + "/version", isErr: true},
+		{importedID: functionUrn + "/version/", isErr: true},
+		{importedID: "/version/latest", isErr: true},
+		{importedID: functionUrn + "/version/latest/extra", isErr: true},
+	}
+
+	for _, tc := range cases {
+		d := ResourceFunctionReservedInstances().Data(nil)
+		d.SetId(tc.importedID)
+		_, err := resourceFunctionReservedInstancesImportState(context.Background(), d, nil)
+		if tc.isErr {
+			if err == nil {
+				t.Errorf("expected an error when importing '%s', but got nil", tc.importedID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error when importing '%s': %s", tc.importedID, err)
+			continue
+		}
+		if d.Get("function_urn") != functionUrn || d.Get("qualifier_type") != tc.qualifierType ||
+			d.Get("qualifier_name") != tc.qualifierName {
+			t.Errorf("the result of importing '%s' is not as expected, want (%s, %s, %s), but got (%s, %s, %s)",
+				tc.importedID, functionUrn, tc.qualifierType, tc.qualifierName,
+				d.Get("function_urn"), d.Get("qualifier_type"), d.Get("qualifier_name"))
+		}
+	}
+}
+
+func TestGetFunctionReservedInstances(t *testing.T) {
+	functionUrn := "urn:fss:cn-north-4:0123456789abcdef:function:default:test"
+	// the page size is 2 and the count of each page is the number of the configurations in it
+	pages := map[string]string{
+		"": `{"reserved_instances": [{"qualifier_type": "version", "qualifier_name": "v1", "min_count": 1},
+{"qualifier_type": "version", "qualifier_name": "v2", "min_count": 1}], "count": 2, "page_info": {"next_marker": 2}}`,
+		"2": `{"reserved_instances": [{"qualifier_type": "version", "qualifier_name": "v3", "min_count": 1},
+{"qualifier_type": "alias", "qualifier_name": "dev", "min_count": 0}], "count": 2, "page_info": {"next_marker": 4}}`,
+		"4": `{"reserved_instances": [{"qualifier_type": "alias", "qualifier_name": "prod", "min_count": 3}],
+"count": 1, "page_info": {"next_marker": 4}}`,
+	}
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("function_urn") != functionUrn {
+			t.Errorf("expected the function URN %s in the query, but got %s", functionUrn, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, pages[r.URL.Query().Get("marker")])
+	}))
+	defer server.Close()
+
+	client := &golangsdk.ServiceClient{
+		ProviderClient: &golangsdk.ProviderClient{ProjectID: "project-id"},
+		Endpoint:       server.URL + "/",
+	}
+
+	cases := []struct {
+		qualifierType string
+		qualifierName string
+		minCount      float64
+		requests      int
+	}{
+		{qualifierType: "version", qualifierName: "v1", minCount: 1, requests: 1},
+		// the configuration in the last page is found even if the count is the page size
+		{qualifierType: "alias", qualifierName: "prod", minCount: 3, requests: 3},
+		// the released configuration is not found
+		{qualifierType: "alias", qualifierName: "dev", requests: 2},
+		// the pages are queried until the marker stops advancing
+		{qualifierType: "alias", qualifierName: "test", requests: 3},
+	}
+
+	for _, tc := range cases {
+		requests = 0
+		actual, err := GetFunctionReservedInstances(client, functionUrn, tc.qualifierType, tc.qualifierName)
+		if tc.minCount == 0 {
+			if _, ok := err.(golangsdk.ErrDefault404); !ok {
+				t.Errorf("expected the 404 error of %s %s, but got %v", tc.qualifierType, tc.qualifierName, err)
+			}
+		} else if err != nil {
+			t.Errorf("unexpected error getting %s %s: %s", tc.qualifierType, tc.qualifierName, err)
+		} else if minCount := utils.PathSearch("min_count", actual, float64(0)).(float64); minCount != tc.minCount {
+			t.Errorf("expected the min count %v of %s %s, but got %v", tc.minCount, tc.qualifierType,
+				tc.qualifierName, minCount)
+		}
+		if requests != tc.requests {
+			t.Errorf("expected %d requests for %s %s, but got %d", tc.requests, tc.qualifierType, tc.qualifierName,
+				requests)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -61,6 +62,10 @@ func ResourceFunctionGraphTrigger() *schema.Resource {
 		UpdateContext: resourceFunctionGraphTriggerUpdate,
 		DeleteContext: resourceFunctionGraphTriggerDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFunctionGraphTriggerImportState,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Update: schema.DefaultTimeout(2 * time.Minute),
 		},
@@ -744,7 +749,6 @@ func setLtsEventData(d *schema.ResourceData, eventData map[string]interface{}) e
 }
 
 func setApigEventData(d *schema.ResourceData, eventData map[string]interface{}) error {
-	result := make([]map[string]interface{}, 1)
 	funcInfo := eventData["func_info"].(map[string]interface{})
 	apigInfo := map[string]interface{}{
 		"group_id":                eventData["group_id"],
@@ -757,7 +761,7 @@ func setApigEventData(d *schema.ResourceData, eventData map[string]interface{})
 	if instanceId, ok := eventData["instance_id"]; ok {
 		apigInfo["instance_id"] = instanceId
 	}
-	return d.Set("apig", result)
+	return d.Set("apig", []map[string]interface{}{apigInfo})
 }
 
 func setTriggerEventData(d *schema.ResourceData, resp *trigger.Trigger) error {
@@ -920,3 +924,25 @@ func parseRequestError(respErr error) error {
 	}
 	return respErr
 }
+
+// parseTriggerImportID parses the import ID in the format of <function_urn>/<id>, the function URN does not contain
+// any slash.
+func parseTriggerImportID(importedId string) (functionUrn, triggerId string, err error) {
+	index := strings.LastIndex(importedId, "/")
+	if index <= 0 || index == len(importedId)-1 {
+		return "", "", fmt.Errorf("invalid format specified for import ID, want '<function_urn>/<id>', but got '%s'",
+			importedId)
+	}
+	return importedId[:index], importedId[index+1:], nil
+}
+
+func resourceFunctionGraphTriggerImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	functionUrn, triggerId, err := parseTriggerImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(triggerId)
+	return []*schema.ResourceData{d}, d.Set("function_urn", functionUrn)
+}
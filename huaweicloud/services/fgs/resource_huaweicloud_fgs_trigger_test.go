@@ -0,0 +1,41 @@
+package fgs
+
+import (
+	"testing"
+)
+
+func TestParseTriggerImportID(t *testing.T) {
+	cases := []struct {
+		importedID  string
+		functionUrn string
+		triggerID   string
+		isErr       bool
+	}{
+		{
+			importedID:  "urn:fss:cn-north-4:0123456789abcdef:function:default:test:latest/trigger-id",
+			functionUrn: "urn:fss:cn-north-4:0123456789abcdef:function:default:test:latest",
+			triggerID:   "trigger-id",
+		},
+		{importedID: "urn:fss:cn-north-4:0123456789abcdef:function:default:test:latest", isErr: true},
+		{importedID: "/trigger-id", isErr: true},
+		{importedID: "urn:fss:cn-north-4:0123456789abcdef:function:default:test:latest/", isErr: true},
+	}
+
+	for _, tc := range cases {
+		functionUrn, triggerID, err := parseTriggerImportID(tc.importedID)
+		if tc.isErr {
+			if err == nil {
+				t.Errorf("expected an error when parsing '%s', but got nil", tc.importedID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error when parsing '%s': %s", tc.importedID, err)
+			continue
+		}
+		if functionUrn != tc.functionUrn || triggerID != tc.triggerID {
+			t.Errorf("the result of parsing '%s' is not as expected, want (%s, %s), but got (%s, %s)",
+				tc.importedID, tc.functionUrn, tc.triggerID, functionUrn, triggerID)
+		}
+	}
+}
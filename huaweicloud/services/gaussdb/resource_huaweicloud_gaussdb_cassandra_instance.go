@@ -580,7 +580,7 @@ func resourceGeminiDBInstanceV3Read(d *schema.ResourceData, meta interface{}) er
 	//save geminidb tags
 	if resourceTags, err := tags.Get(client, "instances", d.Id()).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		if err := d.Set("tags", tagmap); err != nil {
+		if err := d.Set("tags", utils.FilterResourceTags(d, config.ProviderTags, tagmap)); err != nil {
 			return fmtp.Errorf("Error saving tags to state for geminidb (%s): %s", d.Id(), err)
 		}
 	} else {
@@ -655,7 +655,7 @@ func resourceGeminiDBInstanceV3Update(d *schema.ResourceData, meta interface{},
 	}
 	//update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "instances", d.Id())
+		tagErr := utils.UpdateResourceTags(client, d, config.ProviderTags, "instances", d.Id())
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of GeminiDB %q: %s", d.Id(), tagErr)
 		}
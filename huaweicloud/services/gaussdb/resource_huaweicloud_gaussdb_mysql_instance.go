@@ -762,7 +762,7 @@ func resourceGaussDBInstanceRead(d *schema.ResourceData, meta interface{}) error
 	// save tags
 	if resourceTags, err := tags.Get(client, "instances", d.Id()).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		if err := d.Set("tags", tagmap); err != nil {
+		if err := d.Set("tags", utils.FilterResourceTags(d, config.ProviderTags, tagmap)); err != nil {
 			return fmtp.Errorf("error saving tags to state for Gaussdb mysql instance (%s): %s", d.Id(), err)
 		}
 	} else {
@@ -1066,7 +1066,7 @@ func resourceGaussDBInstanceUpdate(d *schema.ResourceData, meta interface{}) err
 
 	// update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "instances", d.Id())
+		tagErr := utils.UpdateResourceTags(client, d, config.ProviderTags, "instances", d.Id())
 		if tagErr != nil {
 			return fmtp.Errorf("error updating tags of Gaussdb mysql instance %q: %s", d.Id(), tagErr)
 		}
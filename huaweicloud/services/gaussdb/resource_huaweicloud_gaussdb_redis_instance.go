@@ -533,7 +533,7 @@ func resourceGaussRedisInstanceV3Read(_ context.Context, d *schema.ResourceData,
 	// save geminidb tags
 	if resourceTags, err := tags.Get(client, "instances", d.Id()).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		if err = d.Set("tags", tagmap); err != nil {
+		if err = d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagmap)); err != nil {
 			return diag.Errorf("error saving tags to state for geminidb (%s): %s", d.Id(), err)
 		}
 	} else {
@@ -597,7 +597,7 @@ func resourceGaussRedisInstanceV3Update(ctx context.Context, d *schema.ResourceD
 	}
 	// update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "instances", d.Id())
+		tagErr := utils.UpdateResourceTags(client, d, cfg.ProviderTags, "instances", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of GaussDB for Redis %q: %s", d.Id(), tagErr)
 		}
@@ -27,6 +27,10 @@ func ResourceIdentityKey() *schema.Resource {
 		UpdateContext: resourceIdentityKeyUpdate,
 		DeleteContext: resourceIdentityKeyDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"user_id": {
 				Type:     schema.TypeString,
@@ -163,6 +167,8 @@ func resourceIdentityKeyRead(_ context.Context, d *schema.ResourceData, meta int
 	}
 
 	mErr := multierror.Append(nil,
+		d.Set("user_id", accessKey.UserID),
+		d.Set("description", accessKey.Description),
 		d.Set("status", accessKey.Status),
 		d.Set("create_time", accessKey.CreateTime),
 	)
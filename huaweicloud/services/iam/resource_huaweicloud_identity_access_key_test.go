@@ -0,0 +1,57 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestResourceIdentityKeyImport(t *testing.T) {
+	accessKey := "HSTAK1234567890ABCDEF"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3.0/OS-CREDENTIAL/credentials/"+accessKey, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"credential": {"access": "%s", "user_id": "user-id", "status": "active",
+"description": "used by terraform", "create_time": "2024-01-01T00:00:00.000000Z"}}`, accessKey)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cfg := &config.Config{
+		Region:       "cn-north-4",
+		DomainClient: &golangsdk.ProviderClient{},
+		Endpoints:    map[string]string{"iam": server.URL + "/"},
+	}
+
+	r := ResourceIdentityKey()
+	d := r.TestResourceData()
+	d.SetId(accessKey)
+	result, err := r.Importer.StateContext(context.Background(), d, cfg)
+	if err != nil {
+		t.Fatalf("error importing the access key: %s", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected one imported access key, but got %d", len(result))
+	}
+	if diags := r.ReadContext(context.Background(), result[0], cfg); diags.HasError() {
+		t.Fatalf("error reading the imported access key: %v", diags)
+	}
+
+	expected := map[string]string{
+		"user_id":     "user-id",
+		"description": "used by terraform",
+		"status":      "active",
+		"create_time": "2024-01-01T00:00:00.000000Z",
+	}
+	for key, value := range expected {
+		if got := result[0].Get(key).(string); got != value {
+			t.Errorf("expected %s of the imported access key is %s, but got %s", key, value, got)
+		}
+	}
+}
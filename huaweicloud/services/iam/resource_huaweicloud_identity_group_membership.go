@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -24,6 +26,10 @@ func ResourceIdentityGroupMembership() *schema.Resource {
 		UpdateContext: resourceIdentityGroupMembershipUpdate,
 		DeleteContext: resourceIdentityGroupMembershipDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIdentityGroupMembershipImportState,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"group": {
 				Type:     schema.TypeString,
@@ -159,3 +165,63 @@ func removeUsersFromGroup(identityClient *golangsdk.ServiceClient, groupID strin
 	}
 	return nil
 }
+
+// parseGroupMembershipImportID parses the import ID in the format of <group_id> or
+// <group_id>/<user_id>[,<user_id>...], the user IDs are empty if they are not specified.
+func parseGroupMembershipImportID(importedId string) (groupID string, userIDs []string, err error) {
+	parts := strings.SplitN(importedId, "/", 2)
+	groupID = parts[0]
+	if groupID == "" {
+		return "", nil, fmt.Errorf("invalid format specified for import ID, want '<group_id>' or "+
+			"'<group_id>/<user_id>[,<user_id>...]', but got '%s'", importedId)
+	}
+	if len(parts) == 1 {
+		return groupID, nil, nil
+	}
+
+	for _, userID := range strings.Split(parts[1], ",") {
+		if userID == "" {
+			return "", nil, fmt.Errorf("invalid format specified for import ID, want '<group_id>' or "+
+				"'<group_id>/<user_id>[,<user_id>...]', but got '%s'", importedId)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return groupID, userIDs, nil
+}
+
+// resourceIdentityGroupMembershipImportState imports the specified users of the group, all users of the group are
+// imported if no user is specified.
+func resourceIdentityGroupMembershipImportState(_ context.Context, d *schema.ResourceData,
+	meta interface{}) ([]*schema.ResourceData, error) {
+	groupID, userIDs, err := parseGroupMembershipImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(userIDs) == 0 {
+		cfg := meta.(*config.Config)
+		identityClient, err := cfg.IdentityV3Client(cfg.GetRegion(d))
+		if err != nil {
+			return nil, fmt.Errorf("error creating IAM client: %s", err)
+		}
+
+		allPages, err := users.ListInGroup(identityClient, groupID, nil).AllPages()
+		if err != nil {
+			return nil, fmt.Errorf("unable to query the users of group (%s): %s", groupID, err)
+		}
+		allUsers, err := users.ExtractUsers(allPages)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve users: %s", err)
+		}
+		for _, u := range allUsers {
+			userIDs = append(userIDs, u.ID)
+		}
+	}
+
+	d.SetId(groupID)
+	mErr := multierror.Append(nil,
+		d.Set("group", groupID),
+		d.Set("users", userIDs),
+	)
+	return []*schema.ResourceData{d}, mErr.ErrorOrNil()
+}
@@ -0,0 +1,41 @@
+package iam
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGroupMembershipImportID(t *testing.T) {
+	cases := []struct {
+		importedID string
+		groupID    string
+		userIDs    []string
+		isErr      bool
+	}{
+		{importedID: "group-id", groupID: "group-id"},
+		{importedID: "group-id/user-1", groupID: "group-id", userIDs: []string{"user-1"}},
+		{importedID: "group-id/user-1,user-2", groupID: "group-id", userIDs: []string{"user-1", "user-2"}},
+		{importedID: "", isErr: true},
+		{importedID: "/user-1", isErr: true},
+		{importedID: "group-id/", isErr: true},
+		{importedID: "group-id/user-1,,user-2", isErr: true},
+	}
+
+	for _, tc := range cases {
+		groupID, userIDs, err := parseGroupMembershipImportID(tc.importedID)
+		if tc.isErr {
+			if err == nil {
+				t.Errorf("expected an error when parsing '%s', but got nil", tc.importedID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error when parsing '%s': %s", tc.importedID, err)
+			continue
+		}
+		if groupID != tc.groupID || !reflect.DeepEqual(userIDs, tc.userIDs) {
+			t.Errorf("the result of parsing '%s' is not as expected, want (%s, %v), but got (%s, %v)",
+				tc.importedID, tc.groupID, tc.userIDs, groupID, userIDs)
+		}
+	}
+}
@@ -295,7 +295,7 @@ func resourceImsImageCopyUpdate(ctx context.Context, d *schema.ResourceData, met
 
 	// update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(imsClient, d, "images", d.Id())
+		tagErr := utils.UpdateResourceTags(imsClient, d, cfg.ProviderTags, "images", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of IMS image :%s, err:%s", d.Id(), tagErr)
 		}
@@ -342,7 +342,7 @@ func resourceImsImageCopyRead(_ context.Context, d *schema.ResourceData, meta in
 	// fetch tags
 	if resourceTags, err := tags.Get(imsClient, "image", d.Id()).Extract(); err == nil {
 		tagMap := utils.TagsToMap(resourceTags.Tags)
-		mErr = multierror.Append(mErr, d.Set("tags", tagMap))
+		mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagMap)))
 	} else {
 		log.Printf("[WARN] Fetching tags of IMS images failed: %s", err)
 	}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -29,6 +30,10 @@ func ResourceImsImageShare() *schema.Resource {
 		ReadContext:   resourceImsImageShareRead,
 		DeleteContext: resourceImsImageShareDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceImsImageShareImportState,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
@@ -221,3 +226,36 @@ func imsJobStatusRefreshFunc(jobId string, client *golangsdk.ServiceClient) reso
 		return getJobStatusRespBody, status.(string), nil
 	}
 }
+
+// parseImageShareImportID parses the import ID in the format of <source_image_id>/<target_project_id>[,...].
+func parseImageShareImportID(importedId string) (imageId string, projectIds []string, err error) {
+	parts := strings.Split(importedId, "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return "", nil, fmt.Errorf("invalid format specified for import ID, want "+
+			"'<source_image_id>/<target_project_id>[,<target_project_id>...]', but got '%s'", importedId)
+	}
+
+	for _, projectId := range strings.Split(parts[1], ",") {
+		if projectId == "" {
+			return "", nil, fmt.Errorf("invalid format specified for import ID, want "+
+				"'<source_image_id>/<target_project_id>[,<target_project_id>...]', but got '%s'", importedId)
+		}
+		projectIds = append(projectIds, projectId)
+	}
+	return parts[0], projectIds, nil
+}
+
+func resourceImsImageShareImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	imageId, projectIds, err := parseImageShareImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(imageId)
+	mErr := multierror.Append(nil,
+		d.Set("source_image_id", imageId),
+		d.Set("target_project_ids", projectIds),
+	)
+	return []*schema.ResourceData{d}, mErr.ErrorOrNil()
+}
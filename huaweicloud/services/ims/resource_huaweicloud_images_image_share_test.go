@@ -0,0 +1,41 @@
+package ims
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseImageShareImportID(t *testing.T) {
+	cases := []struct {
+		importedID string
+		imageID    string
+		projectIDs []string
+		isErr      bool
+	}{
+		{importedID: "image-id/project-1", imageID: "image-id", projectIDs: []string{"project-1"}},
+		{importedID: "image-id/project-1,project-2", imageID: "image-id", projectIDs: []string{"project-1", "project-2"}},
+		{importedID: "image-id", isErr: true},
+		{importedID: "image-id/", isErr: true},
+		{importedID: "/project-1", isErr: true},
+		{importedID: "image-id/project-1,", isErr: true},
+		{importedID: "image-id/project-1/project-2", isErr: true},
+	}
+
+	for _, tc := range cases {
+		imageID, projectIDs, err := parseImageShareImportID(tc.importedID)
+		if tc.isErr {
+			if err == nil {
+				t.Errorf("expected an error when parsing '%s', but got nil", tc.importedID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error when parsing '%s': %s", tc.importedID, err)
+			continue
+		}
+		if imageID != tc.imageID || !reflect.DeepEqual(projectIDs, tc.projectIDs) {
+			t.Errorf("the result of parsing '%s' is not as expected, want (%s, %v), but got (%s, %v)",
+				tc.importedID, tc.imageID, tc.projectIDs, imageID, projectIDs)
+		}
+	}
+}
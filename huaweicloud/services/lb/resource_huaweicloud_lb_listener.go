@@ -235,7 +235,7 @@ func resourceListenerV2Read(_ context.Context, d *schema.ResourceData, meta inte
 	// fetch tags
 	if resourceTags, err := tags.Get(lbv2Client, "listeners", d.Id()).Extract(); err == nil {
 		tagMap := utils.TagsToMap(resourceTags.Tags)
-		mErr = multierror.Append(mErr, d.Set("tags", tagMap))
+		mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagMap)))
 	} else {
 		log.Printf("[WARN] fetching tags of ELB listener failed: %s", err)
 	}
@@ -327,7 +327,7 @@ func resourceListenerV2Update(ctx context.Context, d *schema.ResourceData, meta
 
 	// update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(lbv2Client, d, "listeners", d.Id())
+		tagErr := utils.UpdateResourceTags(lbv2Client, d, cfg.ProviderTags, "listeners", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of ELB listener:%s, err:%s", d.Id(), tagErr)
 		}
@@ -215,7 +215,7 @@ func resourcePublicGatewayRead(_ context.Context, d *schema.ResourceData, meta i
 	if err != nil {
 		log.Printf("[WARN] Error getting gateway tags: %s", err)
 	} else {
-		mErr = multierror.Append(mErr, d.Set("tags", utils.TagsToMap(gatewayTags.Tags)))
+		mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, utils.TagsToMap(gatewayTags.Tags))))
 	}
 
 	if err = mErr.ErrorOrNil(); err != nil {
@@ -264,7 +264,7 @@ func resourcePublicGatewayUpdate(ctx context.Context, d *schema.ResourceData, me
 		if err != nil {
 			return diag.Errorf("error creating VPC v2.0 client: %s", err)
 		}
-		err = utils.UpdateResourceTags(networkClient, d, "nat_gateways", gatewayId)
+		err = utils.UpdateResourceTags(networkClient, d, cfg.ProviderTags, "nat_gateways", gatewayId)
 		if err != nil {
 			return diag.Errorf("error updating tags of the NAT gateway: %s", err)
 		}
@@ -166,7 +166,7 @@ func resourcePrivateGatewayRead(_ context.Context, d *schema.ResourceData, meta
 		d.Set("description", resp.Description),
 		d.Set("spec", resp.Spec),
 		d.Set("enterprise_project_id", resp.EnterpriseProjectId),
-		d.Set("tags", utils.TagsToMap(resp.Tags)),
+		d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, utils.TagsToMap(resp.Tags))),
 		d.Set("created_at", resp.CreatedAt),
 		d.Set("updated_at", resp.UpdatedAt),
 		d.Set("status", resp.Status),
@@ -204,7 +204,7 @@ func resourcePrivateGatewayUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if d.HasChange("tags") {
-		err = utils.UpdateResourceTags(natClient, d, "private-nat-gateways", gatewayId)
+		err = utils.UpdateResourceTags(natClient, d, cfg.ProviderTags, "private-nat-gateways", gatewayId)
 		if err != nil {
 			return diag.Errorf("error updating tags of the private NAT gateway (%s): %s", gatewayId, err)
 		}
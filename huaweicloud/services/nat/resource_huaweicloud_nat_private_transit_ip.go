@@ -120,7 +120,7 @@ func resourcePrivateTransitIpRead(_ context.Context, d *schema.ResourceData, met
 		d.Set("subnet_id", resp.SubnetId),
 		d.Set("ip_address", resp.IpAddress),
 		d.Set("enterprise_project_id", resp.EnterpriseProjectId),
-		d.Set("tags", utils.TagsToMap(resp.Tags)),
+		d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, utils.TagsToMap(resp.Tags))),
 		d.Set("gateway_id", resp.GatewayId),
 		d.Set("network_interface_id", resp.NetworkInterfaceId),
 		d.Set("created_at", resp.CreatedAt),
@@ -141,7 +141,7 @@ func resourcePrivateTransitIpUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	transitIpId := d.Id()
-	err = utils.UpdateResourceTags(natClient, d, "transit-ips", transitIpId)
+	err = utils.UpdateResourceTags(natClient, d, cfg.ProviderTags, "transit-ips", transitIpId)
 	if err != nil {
 		return diag.Errorf("error updating tags of the transit IP (Private NAT) (%s): %s", transitIpId, err)
 	}
@@ -513,7 +513,7 @@ func resourceObsBucketRead(_ context.Context, d *schema.ResourceData, meta inter
 
 	mErr = multierror.Append(mErr,
 		d.Set("region", region),
-		d.Set("bucket_domain_name", bucketDomainNameWithCloud(d.Get("bucket").(string), region, conf.Cloud)),
+		d.Set("bucket_domain_name", BucketDomainNameWithCloud(d.Get("bucket").(string), region, conf.Cloud)),
 	)
 	if mErr.ErrorOrNil() != nil {
 		return diag.Errorf("error setting OBS attributes: %s", mErr)
@@ -1728,7 +1728,8 @@ func normalizeWebsiteRoutingRules(w []obs.RoutingRule) (string, error) {
 	return string(withoutNulls), nil
 }
 
-func bucketDomainNameWithCloud(bucket, region, cloud string) string {
+// BucketDomainNameWithCloud returns the domain name of the OBS bucket in the format of {bucket}.obs.{region}.{cloud}.
+func BucketDomainNameWithCloud(bucket, region, cloud string) string {
 	return fmt.Sprintf("%s.obs.%s.%s", bucket, region, cloud)
 }
 
@@ -3,25 +3,40 @@ package obs
 import (
 	"bytes"
 	"context"
+	"crypto/md5" //nolint:gosec // the MD5 is the algorithm of the ETag calculated by OBS
+	"encoding/hex"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"log"
+	"net/url"
 	"os"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/chnsz/golangsdk/openstack/obs"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 )
 
+const (
+	bytesPerMB = 1024 * 1024
+	// the maximum number of parts in a multipart upload
+	maxUploadParts = 10000
+
+	// the header of the object tags in the format of URL query parameters, e.g. key1=value1&key2=value2
+	objectTaggingHeader = "x-obs-tagging"
+)
+
 func ResourceObsBucketObject() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceObsBucketObjectPut,
 		ReadContext:   resourceObsBucketObjectRead,
-		UpdateContext: resourceObsBucketObjectPut,
+		UpdateContext: resourceObsBucketObjectUpdate,
 		DeleteContext: resourceObsBucketObjectDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceObsBucketObjectImport,
@@ -51,12 +66,23 @@ func ResourceObsBucketObject() *schema.Resource {
 				Optional: true,
 			},
 
+			"source_hash": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"content": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ExactlyOneOf: []string{"source", "content"},
 			},
 
+			"content_md5": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsBase64,
+			},
+
 			"storage_class": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -84,10 +110,68 @@ func ResourceObsBucketObject() *schema.Resource {
 				Computed: true,
 			},
 
+			"cache_control": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"content_disposition": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// the object tags can only be set along with the upload and cannot be read back by the SDK
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"multipart_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"part_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      9,
+				ValidateFunc: validation.IntBetween(1, 5120),
+			},
+
+			"parallel_parts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+
+			"enable_checkpoint": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"checkpoint_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"etag": {
 				Type: schema.TypeString,
-				// This will conflict with server-side-encryption and multi-part upload
-				// if/when it's actually implemented. The Etag then won't match raw-file MD5.
+				// This will conflict with server-side-encryption and multi-part upload,
+				// the Etag then won't match raw-file MD5. Use source_hash instead in these cases.
 				Optional: true,
 				Computed: true,
 			},
@@ -106,9 +190,6 @@ func ResourceObsBucketObject() *schema.Resource {
 }
 
 func resourceObsBucketObjectPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var resp *obs.PutObjectOutput
-	var err error
-
 	conf := meta.(*config.Config)
 	obsClient, err := conf.ObjectStorageClient(conf.GetRegion(d))
 	if err != nil {
@@ -125,11 +206,12 @@ func resourceObsBucketObjectPut(ctx context.Context, d *schema.ResourceData, met
 		return diag.Errorf("error reading OBS bucket %s: %s", bucket, err)
 	}
 
+	var versionId string
 	source := d.Get("source").(string)
 	content := d.Get("content").(string)
 	if source != "" {
 		// check source file whether exist
-		_, err = os.Stat(source)
+		fileInfo, err := os.Stat(source)
 		if err != nil {
 			if os.IsNotExist(err) {
 				return diag.Errorf("source file %s is not exist", source)
@@ -137,99 +219,313 @@ func resourceObsBucketObjectPut(ctx context.Context, d *schema.ResourceData, met
 			return diag.FromErr(err)
 		}
 
-		// put source file
-		resp, err = putFileToObject(obsClient, d)
+		// upload the large file in multiple parts, the parts are uploaded in parallel
+		if isMultipartUpload(fileInfo.Size(), d.Get("multipart_threshold").(int)) {
+			// the ETag of the object uploaded in multiple parts is not the MD5 of the content, so it cannot be verified
+			if d.Get("content_md5").(string) != "" {
+				return diag.Errorf("content_md5 is not supported when the source file %s is uploaded in multiple "+
+					"parts, please increase multipart_threshold or use source_hash instead", source)
+			}
+			versionId, err = uploadFileToObject(obsClient, d)
+		} else {
+			versionId, err = putFileToObject(obsClient, d)
+		}
+		if err != nil {
+			return diag.FromErr(getObsError("Error putting object to OBS bucket", bucket, err))
+		}
 	}
 
 	if content != "" {
 		// put content
-		resp, err = putContentToObject(obsClient, d)
+		versionId, err = putContentToObject(obsClient, d)
+		if err != nil {
+			return diag.FromErr(getObsError("Error putting object to OBS bucket", bucket, err))
+		}
 	}
 
-	if err != nil {
-		return diag.FromErr(getObsError("Error putting object to OBS bucket", bucket, err))
+	log.Printf("[DEBUG] The version of %s in OBS Bucket %s: %s", key, bucket, versionId)
+	if versionId == "null" {
+		versionId = ""
 	}
-	if resp == nil {
-		return diag.Errorf("putting object to OBS bucket %s without null response", bucket)
+	if err := d.Set("version_id", versionId); err != nil {
+		return diag.Errorf("error saving versionId of OBS bucket %s: %s", bucket, err)
 	}
+	d.SetId(key)
 
-	log.Printf("[DEBUG] Response of putting %s to OBS Bucket %s: %#v", key, bucket, resp)
-	mErr := &multierror.Error{}
-	if resp.VersionId != "null" {
-		mErr = multierror.Append(mErr, d.Set("version_id", resp.VersionId))
-	} else {
-		mErr = multierror.Append(mErr, d.Set("version_id", ""))
+	return resourceObsBucketObjectRead(ctx, d, meta)
+}
+
+func resourceObsBucketObjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the object is uploaded again only if the content or the upload options are changed
+	uploadChanges := []string{
+		"source", "content", "source_hash", "content_md5", "etag", "acl", "encryption", "kms_key_id",
 	}
-	if mErr.ErrorOrNil() != nil {
-		return diag.Errorf("error saving versionId of OBS bucket %s: %s", bucket, mErr)
+	if d.HasChanges(uploadChanges...) {
+		return resourceObsBucketObjectPut(ctx, d, meta)
+	}
+
+	metadataChanges := []string{
+		"storage_class", "content_type", "cache_control", "content_disposition", "metadata",
+	}
+	if d.HasChanges(metadataChanges...) {
+		conf := meta.(*config.Config)
+		obsClient, err := conf.ObjectStorageClient(conf.GetRegion(d))
+		if err != nil {
+			return diag.Errorf("Error creating OBS client: %s", err)
+		}
+
+		bucket := d.Get("bucket").(string)
+		input := &obs.SetObjectMetadataInput{
+			Bucket:            bucket,
+			Key:               d.Get("key").(string),
+			MetadataDirective: obs.ReplaceMetadata,
+			StorageClass:      obs.StorageClassType(d.Get("storage_class").(string)),
+			Metadata:          buildObjectMetadata(d),
+			HttpHeader:        buildObjectHttpHeader(d),
+		}
+		log.Printf("[DEBUG] updating the metadata of %s in OBS Bucket %s, opts: %#v", input.Key, bucket, input)
+		if _, err := obsClient.SetObjectMetadata(input); err != nil {
+			return diag.FromErr(getObsError("Error updating object metadata of OBS bucket", bucket, err))
+		}
 	}
-	d.SetId(key)
 
 	return resourceObsBucketObjectRead(ctx, d, meta)
 }
 
-func putContentToObject(obsClient *obs.ObsClient, d *schema.ResourceData) (*obs.PutObjectOutput, error) {
-	bucket := d.Get("bucket").(string)
-	key := d.Get("key").(string)
-	content := d.Get("content").(string)
+// isMultipartUpload returns whether the source file of the size is uploaded in multiple parts, the threshold is in MB.
+func isMultipartUpload(size int64, threshold int) bool {
+	return size >= int64(threshold)*bytesPerMB
+}
 
-	putInput := &obs.PutObjectInput{}
-	putInput.Bucket = bucket
-	putInput.Key = key
+// buildObjectOperationInput builds the common options of the single and multipart uploads.
+func buildObjectOperationInput(d *schema.ResourceData) obs.ObjectOperationInput {
+	input := obs.ObjectOperationInput{
+		Bucket:     d.Get("bucket").(string),
+		Key:        d.Get("key").(string),
+		Metadata:   buildObjectMetadata(d),
+		HttpHeader: buildObjectHttpHeader(d),
+	}
 
 	if v, ok := d.GetOk("acl"); ok {
-		putInput.ACL = obs.AclType(v.(string))
+		input.ACL = obs.AclType(v.(string))
 	}
 	if v, ok := d.GetOk("storage_class"); ok {
-		putInput.StorageClass = obs.StorageClassType(v.(string))
-	}
-	if v, ok := d.GetOk("content_type"); ok {
-		putInput.ContentType = v.(string)
+		input.StorageClass = obs.StorageClassType(v.(string))
 	}
 
-	var sseKmsHeader = obs.SseKmsHeader{}
 	if d.Get("encryption").(bool) {
-		sseKmsHeader.Encryption = obs.DEFAULT_SSE_KMS_ENCRYPTION
-		sseKmsHeader.Key = d.Get("kms_key_id").(string)
-		putInput.SseHeader = sseKmsHeader
+		input.SseHeader = obs.SseKmsHeader{
+			Encryption: obs.DEFAULT_SSE_KMS_ENCRYPTION,
+			Key:        d.Get("kms_key_id").(string),
+		}
 	}
+	return input
+}
+
+func buildObjectMetadata(d *schema.ResourceData) map[string]string {
+	metadata := d.Get("metadata").(map[string]interface{})
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		result[k] = v.(string)
+	}
+	return result
+}
+
+func buildObjectHttpHeader(d *schema.ResourceData) obs.HttpHeader {
+	return obs.HttpHeader{
+		ContentType:        d.Get("content_type").(string),
+		CacheControl:       d.Get("cache_control").(string),
+		ContentDisposition: d.Get("content_disposition").(string),
+	}
+}
+
+// buildObjectTagging returns the URL-encoded object tags, the tags are set by the header along with the upload.
+func buildObjectTagging(d *schema.ResourceData) string {
+	tagging := url.Values{}
+	for k, v := range d.Get("tags").(map[string]interface{}) {
+		tagging.Set(k, v.(string))
+	}
+	return tagging.Encode()
+}
+
+func putContentToObject(obsClient *obs.ObsClient, d *schema.ResourceData) (string, error) {
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	content := d.Get("content").(string)
+
+	putInput := &obs.PutObjectInput{}
+	putInput.ObjectOperationInput = buildObjectOperationInput(d)
+	putInput.ContentMD5 = d.Get("content_md5").(string)
 
 	log.Printf("[DEBUG] putting %s to OBS Bucket %s, opts: %#v", key, bucket, putInput)
 	// do not log content
 	body := bytes.NewReader([]byte(content))
 	putInput.Body = body
 
-	return obsClient.PutObject(putInput)
+	var resp *obs.PutObjectOutput
+	var err error
+	if tagging := buildObjectTagging(d); tagging != "" {
+		resp, err = obsClient.PutObject(putInput, obs.WithCustomHeader(objectTaggingHeader, tagging))
+	} else {
+		resp, err = obsClient.PutObject(putInput)
+	}
+	if err != nil {
+		return "", err
+	}
+	return resp.VersionId, nil
 }
 
-func putFileToObject(obsClient *obs.ObsClient, d *schema.ResourceData) (*obs.PutObjectOutput, error) {
+func putFileToObject(obsClient *obs.ObsClient, d *schema.ResourceData) (string, error) {
 	bucket := d.Get("bucket").(string)
 	key := d.Get("key").(string)
 
 	putInput := &obs.PutFileInput{}
-	putInput.Bucket = bucket
-	putInput.Key = key
+	putInput.ObjectOperationInput = buildObjectOperationInput(d)
+	putInput.ContentMD5 = d.Get("content_md5").(string)
 	putInput.SourceFile = d.Get("source").(string)
 
-	if v, ok := d.GetOk("acl"); ok {
-		putInput.ACL = obs.AclType(v.(string))
+	log.Printf("[DEBUG] putting %s to OBS Bucket %s, opts: %#v", key, bucket, putInput)
+	var resp *obs.PutObjectOutput
+	var err error
+	if tagging := buildObjectTagging(d); tagging != "" {
+		resp, err = obsClient.PutFile(putInput, obs.WithCustomHeader(objectTaggingHeader, tagging))
+	} else {
+		resp, err = obsClient.PutFile(putInput)
 	}
-	if v, ok := d.GetOk("storage_class"); ok {
-		putInput.StorageClass = obs.StorageClassType(v.(string))
+	if err != nil {
+		return "", err
 	}
-	if v, ok := d.GetOk("content_type"); ok {
-		putInput.ContentType = v.(string)
+	return resp.VersionId, nil
+}
+
+// uploadFileToObject uploads the source file in multiple parts. If the checkpoint is enabled, the uploaded parts are
+// recorded in the checkpoint file and the upload is resumed from the checkpoint when it is applied again after a
+// failure.
+func uploadFileToObject(obsClient *obs.ObsClient, d *schema.ResourceData) (string, error) {
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+
+	uploadInput := &obs.UploadFileInput{
+		ObjectOperationInput: buildObjectOperationInput(d),
+		ContentType:          d.Get("content_type").(string),
+		UploadFile:           d.Get("source").(string),
+		PartSize:             int64(d.Get("part_size").(int)) * bytesPerMB,
+		TaskNum:              d.Get("parallel_parts").(int),
+		EnableCheckpoint:     d.Get("enable_checkpoint").(bool),
+		CheckpointFile:       d.Get("checkpoint_file").(string),
 	}
 
-	var sseKmsHeader = obs.SseKmsHeader{}
+	log.Printf("[DEBUG] uploading %s to OBS Bucket %s in multiple parts, opts: %#v", key, bucket, uploadInput)
+	// the parts recorded in the checkpoint file are resumed in the size of the previous upload
+	partSize, resumed := checkpointPartSize(uploadInput)
+	var resp *obs.CompleteMultipartUploadOutput
+	var err error
+	if tagging := buildObjectTagging(d); tagging != "" {
+		resp, err = obsClient.UploadFile(uploadInput, obs.WithCustomHeader(objectTaggingHeader, tagging))
+	} else {
+		resp, err = obsClient.UploadFile(uploadInput)
+	}
+	if err != nil {
+		if uploadInput.EnableCheckpoint {
+			log.Printf("[WARN] the upload of %s is failed, it will be resumed from the checkpoint file %s when "+
+				"applied again", key, uploadInput.CheckpointFile)
+		}
+		return "", err
+	}
+
+	if !resumed {
+		// the part size is adjusted by UploadFile
+		partSize = uploadInput.PartSize
+	}
+	if err := verifyMultipartETag(d, partSize, resp.ETag); err != nil {
+		return "", err
+	}
+	return resp.VersionId, nil
+}
+
+// checkpointPartSize returns the part size recorded in the checkpoint file if the upload is resumed from it.
+func checkpointPartSize(input *obs.UploadFileInput) (int64, bool) {
+	if !input.EnableCheckpoint {
+		return 0, false
+	}
+	checkpointFile := input.CheckpointFile
+	if checkpointFile == "" {
+		checkpointFile = input.UploadFile + ".uploadfile_record"
+	}
+	data, err := os.ReadFile(checkpointFile)
+	if err != nil || len(data) == 0 {
+		return 0, false
+	}
+
+	var checkpoint obs.UploadCheckpoint
+	if err := xml.Unmarshal(data, &checkpoint); err != nil || len(checkpoint.UploadParts) == 0 {
+		return 0, false
+	}
+	fileInfo, err := os.Stat(input.UploadFile)
+	if err != nil || checkpoint.Bucket != input.Bucket || checkpoint.Key != input.Key ||
+		checkpoint.UploadFile != input.UploadFile || checkpoint.UploadId == "" ||
+		checkpoint.FileInfo.Size != fileInfo.Size() || checkpoint.FileInfo.LastModified != fileInfo.ModTime().Unix() {
+		return 0, false
+	}
+	return checkpoint.UploadParts[0].PartSize, true
+}
+
+// verifyMultipartETag compares the ETag of the object uploaded in multiple parts with the one calculated from the
+// source file, as the checksum of the whole object is not supported by the multipart upload. The ETag of the
+// encrypted object is not calculated from the content, so it is not verified.
+func verifyMultipartETag(d *schema.ResourceData, partSize int64, etag string) error {
 	if d.Get("encryption").(bool) {
-		sseKmsHeader.Encryption = obs.DEFAULT_SSE_KMS_ENCRYPTION
-		sseKmsHeader.Key = d.Get("kms_key_id").(string)
-		putInput.SseHeader = sseKmsHeader
+		return nil
 	}
 
-	log.Printf("[DEBUG] putting %s to OBS Bucket %s, opts: %#v", key, bucket, putInput)
-	return obsClient.PutFile(putInput)
+	source := d.Get("source").(string)
+	expected, err := multipartETag(source, partSize)
+	if err != nil {
+		return fmt.Errorf("error calculating the ETag of the source file %s: %s", source, err)
+	}
+	// the ETag may be followed by the number of parts, e.g. "d41d8cd98f00b204e9800998ecf8427e-3"
+	actual := strings.Trim(etag, `"`)
+	if digest := strings.SplitN(actual, "-", 2)[0]; digest != expected {
+		return fmt.Errorf("the ETag (%s) of the uploaded object does not match the MD5 (%s) calculated from the "+
+			"parts of the source file %s, the object may be corrupted during the upload", actual, expected, source)
+	}
+	return nil
+}
+
+// multipartETag calculates the MD5 of the concatenated MD5 digests of the parts, which is the ETag of the object
+// uploaded in multiple parts. The file is sliced in the same way as UploadFile, which is limited to 10,000 parts.
+func multipartETag(source string, partSize int64) (string, error) {
+	file, err := os.Open(source)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := fileInfo.Size()
+	if partSize <= 0 {
+		partSize = obs.DEFAULT_PART_SIZE
+	}
+	if size/partSize >= maxUploadParts {
+		partSize = (size + maxUploadParts - 1) / maxUploadParts
+	}
+
+	digests := md5.New() //nolint:gosec // the MD5 is the algorithm of the ETag calculated by OBS
+	for offset := int64(0); offset == 0 || offset < size; offset += partSize {
+		part := md5.New() //nolint:gosec // the MD5 is the algorithm of the ETag calculated by OBS
+		if _, err := io.CopyN(part, file, partSize); err != nil && err != io.EOF {
+			return "", err
+		}
+		digests.Write(part.Sum(nil))
+	}
+	return hex.EncodeToString(digests.Sum(nil)), nil
 }
 
 func resourceObsBucketObjectRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -272,6 +568,9 @@ func resourceObsBucketObjectRead(_ context.Context, d *schema.ResourceData, meta
 		d.Set("region", region),
 		d.Set("storage_class", class),
 		d.Set("content_type", objectMeta.ContentType),
+		d.Set("cache_control", objectMeta.CacheControl),
+		d.Set("content_disposition", objectMeta.ContentDisposition),
+		d.Set("metadata", flattenObjectMetadata(d.Get("metadata").(map[string]interface{}), objectMeta.Metadata)),
 		d.Set("version_id", objectMeta.VersionId),
 		d.Set("size", objectMeta.ContentLength),
 		d.Set("etag", strings.Trim(objectMeta.ETag, `"`)),
@@ -284,6 +583,27 @@ func resourceObsBucketObjectRead(_ context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+// flattenObjectMetadata returns the metadata of the object with the keys in the same case as the configuration, as
+// the metadata keys are case-insensitive and returned in lowercase by OBS.
+func flattenObjectMetadata(configured map[string]interface{}, metadata map[string]string) map[string]interface{} {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(metadata))
+	for k, v := range metadata {
+		key := k
+		for configuredKey := range configured {
+			if strings.EqualFold(configuredKey, k) {
+				key = configuredKey
+				break
+			}
+		}
+		result[key] = v
+	}
+	return result
+}
+
 func resourceObsBucketObjectDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conf := meta.(*config.Config)
 	obsClient, err := conf.ObjectStorageClient(conf.GetRegion(d))
@@ -321,6 +641,11 @@ func resourceObsBucketObjectImport(_ context.Context, d *schema.ResourceData, _
 		d.Set("bucket", bucket),
 		d.Set("key", key),
 	)
+	// the upload options are not stored in OBS, the default values are set
+	objectSchema := ResourceObsBucketObject().Schema
+	for _, option := range []string{"multipart_threshold", "part_size", "parallel_parts", "enable_checkpoint"} {
+		mErr = multierror.Append(mErr, d.Set(option, objectSchema[option].Default))
+	}
 	if mErr.ErrorOrNil() != nil {
 		return nil, fmt.Errorf("error setting attributes of OBS bucket %s: %s", bucket, mErr)
 	}
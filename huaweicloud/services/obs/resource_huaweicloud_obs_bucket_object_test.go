@@ -0,0 +1,159 @@
+package obs
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIsMultipartUpload(t *testing.T) {
+	cases := []struct {
+		size      int64
+		threshold int
+		expected  bool
+	}{
+		{size: 0, threshold: 1, expected: false},
+		{size: bytesPerMB - 1, threshold: 1, expected: false},
+		{size: bytesPerMB, threshold: 1, expected: true},
+		{size: 3 * bytesPerMB, threshold: 1, expected: true},
+		{size: 99 * bytesPerMB, threshold: 100, expected: false},
+		{size: 100 * bytesPerMB, threshold: 100, expected: true},
+		// the threshold is converted to bytes in int64 without overflow
+		{size: 5 * 1024 * bytesPerMB, threshold: 4096, expected: true},
+	}
+
+	for _, tc := range cases {
+		if actual := isMultipartUpload(tc.size, tc.threshold); actual != tc.expected {
+			t.Errorf("expected the upload of %d bytes with the threshold %d MB to be multipart: %t, but got %t",
+				tc.size, tc.threshold, tc.expected, actual)
+		}
+	}
+}
+
+func TestFlattenObjectMetadata(t *testing.T) {
+	cases := []struct {
+		name       string
+		configured map[string]interface{}
+		metadata   map[string]string
+		expected   map[string]interface{}
+	}{
+		{
+			name:     "no metadata",
+			metadata: map[string]string{},
+			expected: nil,
+		},
+		{
+			name:       "same case",
+			configured: map[string]interface{}{"owner": "foo"},
+			metadata:   map[string]string{"owner": "foo"},
+			expected:   map[string]interface{}{"owner": "foo"},
+		},
+		{
+			name:       "keys in the case of the configuration",
+			configured: map[string]interface{}{"Owner": "foo", "CostCenter": "bar"},
+			metadata:   map[string]string{"owner": "foo", "costcenter": "bar"},
+			expected:   map[string]interface{}{"Owner": "foo", "CostCenter": "bar"},
+		},
+		{
+			name:       "changed value is kept",
+			configured: map[string]interface{}{"Owner": "foo"},
+			metadata:   map[string]string{"owner": "bar"},
+			expected:   map[string]interface{}{"Owner": "bar"},
+		},
+		{
+			name:       "keys added outside are kept in lowercase",
+			configured: map[string]interface{}{"Owner": "foo"},
+			metadata:   map[string]string{"owner": "foo", "team": "ops"},
+			expected:   map[string]interface{}{"Owner": "foo", "team": "ops"},
+		},
+		{
+			name:       "keys removed outside are dropped",
+			configured: map[string]interface{}{"Owner": "foo", "Team": "ops"},
+			metadata:   map[string]string{"owner": "foo"},
+			expected:   map[string]interface{}{"Owner": "foo"},
+		},
+	}
+
+	for _, tc := range cases {
+		actual := flattenObjectMetadata(tc.configured, tc.metadata)
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected the metadata %v, but got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestMultipartETag(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 2500)
+	source := filepath.Join(t.TempDir(), "source")
+	if err := os.WriteFile(source, content, 0600); err != nil {
+		t.Fatalf("error writing the source file: %s", err)
+	}
+
+	cases := []struct {
+		partSize int64
+		expected int64
+	}{
+		{partSize: 10000, expected: 10000},
+		{partSize: int64(len(content)), expected: int64(len(content))},
+		// the parts are limited to 10,000, so the size of each part is increased to 3 bytes
+		{partSize: 1, expected: 3},
+	}
+
+	for _, tc := range cases {
+		partSize := tc.expected
+		var digests []byte
+		for offset := int64(0); offset < int64(len(content)); offset += partSize {
+			end := offset + partSize
+			if end > int64(len(content)) {
+				end = int64(len(content))
+			}
+			digest := md5.Sum(content[offset:end])
+			digests = append(digests, digest[:]...)
+		}
+		expected := fmt.Sprintf("%x", md5.Sum(digests))
+
+		actual, err := multipartETag(source, tc.partSize)
+		if err != nil {
+			t.Fatalf("unexpected error calculating the ETag in %d bytes parts: %s", tc.partSize, err)
+		}
+		if actual != expected {
+			t.Errorf("expected the ETag %s in %d bytes parts, but got %s", expected, tc.partSize, actual)
+		}
+	}
+}
+
+func TestResourceObsBucketObjectImport(t *testing.T) {
+	r := ResourceObsBucketObject()
+	d := r.Data(nil)
+	d.SetId("bucket-demo/path/to/key")
+
+	results, err := resourceObsBucketObjectImport(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("unexpected error importing the object: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 imported object, but got %d", len(results))
+	}
+
+	imported := results[0]
+	if imported.Id() != "path/to/key" || imported.Get("bucket").(string) != "bucket-demo" {
+		t.Errorf("expected the object path/to/key in bucket-demo, but got %s in %s",
+			imported.Id(), imported.Get("bucket"))
+	}
+	for _, option := range []string{"multipart_threshold", "part_size", "parallel_parts", "enable_checkpoint"} {
+		if actual := imported.Get(option); actual != r.Schema[option].Default {
+			t.Errorf("expected the default value %v of %s, but got %v", r.Schema[option].Default, option, actual)
+		}
+	}
+
+	d = r.Data(nil)
+	d.SetId("key-without-bucket")
+	if _, err := resourceObsBucketObjectImport(context.Background(), d, nil); err == nil {
+		t.Errorf("expected an error importing the object without the bucket, but got nil")
+	}
+}
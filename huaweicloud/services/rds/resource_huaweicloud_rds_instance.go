@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -91,9 +92,22 @@ func ResourceRdsInstance() *schema.Resource {
 							ForceNew: true,
 						},
 						"password": {
-							Type:      schema.TypeString,
-							Sensitive: true,
-							Optional:  true,
+							Type:          schema.TypeString,
+							Sensitive:     true,
+							Optional:      true,
+							ConflictsWith: []string{"db.0.password_wo"},
+						},
+						"password_wo": {
+							Type:          schema.TypeString,
+							Sensitive:     true,
+							Optional:      true,
+							WriteOnly:     true,
+							ConflictsWith: []string{"db.0.password"},
+						},
+						"password_wo_version": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							RequiredWith: []string{"db.0.password_wo"},
 						},
 						"port": {
 							Type:     schema.TypeInt,
@@ -446,7 +460,7 @@ func resourceRdsInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	// Add password here so it wouldn't go in the above log entry
-	createOpts.Password = d.Get("db.0.password").(string)
+	createOpts.Password = getRdsRootPassword(d)
 
 	res, err := instances.Create(client, createOpts).Extract()
 	if err != nil {
@@ -561,7 +575,7 @@ func resourceRdsInstanceRead(ctx context.Context, d *schema.ResourceData, meta i
 	d.Set("enterprise_project_id", instance.EnterpriseProjectId)
 	d.Set("switch_strategy", instance.SwitchStrategy)
 	d.Set("charging_mode", instance.ChargeInfo.ChargeMode)
-	d.Set("tags", utils.TagsToMap(instance.Tags))
+	d.Set("tags", utils.FilterResourceTags(d, config.ProviderTags, utils.TagsToMap(instance.Tags)))
 
 	publicIps := make([]interface{}, len(instance.PublicIps))
 	for i, v := range instance.PublicIps {
@@ -612,6 +626,7 @@ func resourceRdsInstanceRead(ctx context.Context, d *schema.ResourceData, meta i
 	}
 	if len(d.Get("db").([]interface{})) > 0 {
 		database["password"] = d.Get("db.0.password")
+		database["password_wo_version"] = d.Get("db.0.password_wo_version")
 	}
 	dbList[0] = database
 	if err := d.Set("db", dbList); err != nil {
@@ -756,7 +771,7 @@ func resourceRdsInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "instances", instanceID)
+		tagErr := utils.UpdateResourceTags(client, d, config.ProviderTags, "instances", instanceID)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of RDS instance (%s): %s", instanceID, tagErr)
 		}
@@ -1535,14 +1550,22 @@ func rdsInstanceStateRefreshFunc(client *golangsdk.ServiceClient, instanceID str
 	}
 }
 
+// getRdsRootPassword returns the root password from the write-only argument db.0.password_wo or db.0.password.
+func getRdsRootPassword(d *schema.ResourceData) string {
+	if v := utils.GetWriteOnlyString(d, cty.GetAttrPath("db").IndexInt(0).GetAttr("password_wo")); v != "" {
+		return v
+	}
+	return d.Get("db.0.password").(string)
+}
+
 func updateRdsRootPassword(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient,
 	instanceID string) error {
-	if !d.HasChange("db.0.password") {
+	if !d.HasChanges("db.0.password", "db.0.password_wo_version") {
 		return nil
 	}
 
 	updateOpts := instances.RestRootPasswordOpts{
-		DbUserPwd: d.Get("db.0.password").(string),
+		DbUserPwd: getRdsRootPassword(d),
 	}
 
 	retryFunc := func() (interface{}, bool, error) {
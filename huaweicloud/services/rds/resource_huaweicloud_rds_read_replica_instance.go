@@ -394,7 +394,7 @@ func resourceRdsReadReplicaInstanceRead(ctx context.Context, d *schema.ResourceD
 	d.Set("type", instance.Type)
 	d.Set("status", instance.Status)
 	d.Set("enterprise_project_id", instance.EnterpriseProjectId)
-	d.Set("tags", utils.TagsToMap(instance.Tags))
+	d.Set("tags", utils.FilterResourceTags(d, config.ProviderTags, utils.TagsToMap(instance.Tags)))
 
 	if len(instance.PrivateIps) > 0 {
 		d.Set("fixed_ip", instance.PrivateIps[0])
@@ -498,7 +498,7 @@ func resourceRdsReadReplicaInstanceUpdate(ctx context.Context, d *schema.Resourc
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "instances", instanceID)
+		tagErr := utils.UpdateResourceTags(client, d, config.ProviderTags, "instances", instanceID)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of RDS read replica instance: %s, err: %s", instanceID, tagErr)
 		}
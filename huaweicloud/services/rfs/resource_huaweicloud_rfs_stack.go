@@ -2,6 +2,7 @@ package rfs
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
@@ -28,13 +29,15 @@ func ResourceStack() *schema.Resource {
 		UpdateContext: resourceStackUpdate,
 		DeleteContext: resourceStackDelete,
 
+		CustomizeDiff: resourceStackTemplateDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
-			Update: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(defaultStackUpdateTimeout),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
@@ -92,7 +95,7 @@ func ResourceStack() *schema.Resource {
 			"template_body": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"template_uri"},
+				ConflictsWith: []string{"template_uri", "template_dir"},
 				Description:   "The HCL/JSON template content for deployment resources.",
 			},
 			"vars_body": {
@@ -103,8 +106,10 @@ func ResourceStack() *schema.Resource {
 				Description:   "The variable content for deployment resources.",
 			},
 			"template_uri": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"template_dir"},
 				Description: "The OBS address where the HCL/JSON template archive (**.zip** file, which contains all " +
 					"resource **.tf.json** script files to be deployed) or **.tf.json** file is located, which " +
 					"describes the target status of the deployment resources.",
@@ -116,6 +121,29 @@ func ResourceStack() *schema.Resource {
 				Description: "The OBS address where the variable (**.tfvars**) file corresponding to the HCL/JSON " +
 					"template located, which describes the target status of the deployment resources.",
 			},
+			"template_dir": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"template_bucket"},
+				Description: "The local directory of the template files, which is archived and uploaded to the OBS " +
+					"bucket for deployment.",
+			},
+			"template_bucket": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"template_dir"},
+				Description:  "The name of the OBS bucket to which the template archive is uploaded.",
+			},
+			"template_object_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The object key of the uploaded template archive.",
+			},
+			"preview_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to preview the changes of the resources by an execution plan before deployment.",
+			},
 			"enable_auto_rollback": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -143,6 +171,94 @@ func ResourceStack() *schema.Resource {
 				Computed:    true,
 				Description: `The latest update time.`,
 			},
+			"template_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The SHA256 hash of the template archive of the local directory.`,
+			},
+			"execution_plan_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The name of the execution plan which previews the changes of the stack.`,
+			},
+			"planned_changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        plannedChangeSchema(),
+				Description: `The predicted changes of the resources in the execution plan.`,
+			},
+			"outputs": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The outputs of the resource stack.`,
+			},
+		},
+	}
+}
+
+func plannedChangeSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The type of the resource.`,
+			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The name of the resource.`,
+			},
+			"index": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The index of the resource created by count or for_each.`,
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The change action of the resource.`,
+			},
+			"action_reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The reason of the change action.`,
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The name of the provider of the resource.`,
+			},
+			"resource_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The ID of the resource.`,
+			},
+			"attributes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: `The changes of the resource attributes.`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The name of the attribute.`,
+						},
+						"previous_value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The value of the attribute before the change.`,
+						},
+						"target_value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The value of the attribute after the change.`,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -202,6 +318,11 @@ func resourceStackCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.FromErr(err)
 	}
 
+	if d.Get("template_dir") != "" {
+		if err = uploadStackTemplateDir(config, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	if d.Get("template_body") != "" || d.Get("template_uri") != "" {
 		if err = deployStack(ctx, client, d, d.Timeout(schema.TimeoutCreate)); err != nil {
 			return diag.FromErr(err)
@@ -230,6 +351,16 @@ func deployStack(ctx context.Context, client *golangsdk.ServiceClient, d *schema
 	if err != nil {
 		return fmt.Errorf("error deploying stack resources: %s", err)
 	}
+	return waitForStackDeployed(ctx, client, d, deploymentId, timeout)
+}
+
+func waitForStackDeployed(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData,
+	deploymentId string, timeout time.Duration) error {
+	var (
+		stackId   = d.Id()
+		stackName = d.Get("name").(string)
+	)
+
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"PENDING"},
 		Target:  []string{"COMPLETED"},
@@ -249,6 +380,109 @@ func deployStack(ctx context.Context, client *golangsdk.ServiceClient, d *schema
 	return err
 }
 
+func uploadStackTemplateDir(cfg *config.Config, d *schema.ResourceData) error {
+	templateUri, templateHash, err := uploadStackTemplate(cfg, cfg.GetRegion(d), d.Get("template_dir").(string),
+		d.Get("template_bucket").(string), d.Get("name").(string), d.Get("template_object_key").(string))
+	if err != nil {
+		return err
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("template_uri", templateUri),
+		d.Set("template_hash", templateHash),
+	)
+	return mErr.ErrorOrNil()
+}
+
+// applyStackChanges applies the execution plan which previews the changes, the template is deployed directly if the
+// preview is disabled or the execution plan is not available. The execution plans created by the provider are deleted
+// after the deployment.
+func applyStackChanges(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData,
+	timeout time.Duration) error {
+	var (
+		stackName = d.Get("name").(string)
+		preview   = d.Get("preview_changes").(bool)
+	)
+	if preview || d.HasChange("preview_changes") {
+		defer deleteExecutionPlans(client, stackName, d.Id())
+	}
+	if !preview {
+		mErr := multierror.Append(nil,
+			d.Set("execution_plan_name", ""),
+			d.Set("planned_changes", nil),
+		)
+		if err := mErr.ErrorOrNil(); err != nil {
+			return err
+		}
+		return deployStack(ctx, client, d, timeout)
+	}
+
+	planName := d.Get("execution_plan_name").(string)
+	if d.Get("template_dir").(string) != "" {
+		// the execution plan of the uploaded archive is created during apply, as the archive is not uploaded during plan
+		planName = buildExecutionPlanName(d)
+		items, err := prepareExecutionPlanItems(ctx, client, stackName, d.Id(), planName, d, timeout)
+		if err != nil {
+			return err
+		}
+		mErr := multierror.Append(nil,
+			d.Set("execution_plan_name", planName),
+			d.Set("planned_changes", flattenExecutionPlanItems(items)),
+		)
+		if err := mErr.ErrorOrNil(); err != nil {
+			return err
+		}
+	} else if planName == "" || !d.HasChange("execution_plan_name") {
+		return deployStack(ctx, client, d, timeout)
+	}
+
+	deploymentId, err := applyExecutionPlan(client, stackName, d.Id(), planName)
+	if err != nil {
+		return err
+	}
+	if deploymentId == "" {
+		return deployStack(ctx, client, d, timeout)
+	}
+	return waitForStackDeployed(ctx, client, d, deploymentId, timeout)
+}
+
+// getStackOutputs returns the outputs of the stack, the values which are not strings are JSON-encoded.
+func getStackOutputs(client *golangsdk.ServiceClient, stackName, stackId string) (map[string]interface{}, error) {
+	getPath := fmt.Sprintf("%s?stack_id=%s", client.ServiceURL("stacks", stackName, "outputs"), stackId)
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	resp, err := client.Request("GET", getPath, &opt)
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			// the stack has not been deployed
+			return nil, nil
+		}
+		return nil, err
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := utils.PathSearch("outputs", respBody, make([]interface{}, 0)).([]interface{})
+	result := make(map[string]interface{}, len(outputs))
+	for _, output := range outputs {
+		name := utils.PathSearch("name", output, "").(string)
+		value := utils.PathSearch("value", output, nil)
+		if v, ok := value.(string); ok {
+			result[name] = v
+			continue
+		}
+		jsonValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling the output %s: %s", name, err)
+		}
+		result[name] = string(jsonValue)
+	}
+	return result, nil
+}
+
 func queryAllFailedEvents(client *golangsdk.ServiceClient, stackId, stackName,
 	deploymentId string) error {
 	opts := stacks.ListEventsOpts{
@@ -338,6 +572,11 @@ func resourceStackRead(_ context.Context, d *schema.ResourceData, meta interface
 		return common.CheckDeletedDiag(d, err, "RFS resource stack")
 	}
 
+	outputs, err := getStackOutputs(client, resp.Name, stackId)
+	if err != nil {
+		return diag.Errorf("error retrieving the outputs of stack (%s): %s", stackId, err)
+	}
+
 	mErr := multierror.Append(nil,
 		d.Set("region", region),
 		d.Set("name", resp.Name),
@@ -345,6 +584,7 @@ func resourceStackRead(_ context.Context, d *schema.ResourceData, meta interface
 		d.Set("status", resp.Status),
 		d.Set("created_at", resp.CreatedAt),
 		d.Set("updated_at", resp.UpdatedAt),
+		d.Set("outputs", outputs),
 	)
 
 	if mErr.ErrorOrNil() != nil {
@@ -360,8 +600,15 @@ func resourceStackUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.Errorf("error creating AOS v1 client: %s", err)
 	}
 
-	if err = deployStack(ctx, client, d, d.Timeout(schema.TimeoutUpdate)); err != nil {
-		return diag.FromErr(err)
+	if d.Get("template_dir") != "" && d.HasChange("template_hash") {
+		if err = uploadStackTemplateDir(config, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChanges(stackTemplateKeys...) {
+		if err = applyStackChanges(ctx, client, d, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	return resourceStackRead(ctx, d, meta)
 }
@@ -0,0 +1,468 @@
+package rfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const (
+	defaultStackUpdateTimeout = 20 * time.Minute
+
+	// the prefix of the names of the execution plans which are created by the provider
+	executionPlanNamePrefix = "tf-plan-"
+)
+
+// the arguments which change the deployed resources of the stack
+var stackTemplateKeys = []string{"template_body", "vars_body", "template_uri", "vars_uri", "template_hash"}
+
+// stackTemplateGetter gets the template arguments from the schema.ResourceData during apply or from the
+// schema.ResourceDiff during plan.
+type stackTemplateGetter interface {
+	Get(key string) interface{}
+}
+
+func executionPlansURL(client *golangsdk.ServiceClient, stackName string) string {
+	return client.ServiceURL("stacks", stackName, "execution-plans")
+}
+
+func executionPlanURL(client *golangsdk.ServiceClient, stackName, planName string) string {
+	return client.ServiceURL("stacks", stackName, "execution-plans", planName)
+}
+
+// buildExecutionPlanName returns the name of the execution plan for the planned template. The name is derived from the
+// template and the latest update time of the stack, so the same execution plan is found when the plan is applied.
+func buildExecutionPlanName(d stackTemplateGetter) string {
+	values := make([]string, 0, len(stackTemplateKeys)+1)
+	for _, key := range stackTemplateKeys {
+		values = append(values, d.Get(key).(string))
+	}
+	values = append(values, d.Get("updated_at").(string))
+
+	hash := sha256.Sum256([]byte(strings.Join(values, "\n")))
+	return executionPlanNamePrefix + hex.EncodeToString(hash[:])[:24]
+}
+
+func createExecutionPlan(client *golangsdk.ServiceClient, stackName, stackId, planName string,
+	d stackTemplateGetter) (string, error) {
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody: utils.RemoveNil(map[string]interface{}{
+			"execution_plan_name": planName,
+			"stack_id":            stackId,
+			"template_body":       utils.ValueIngoreEmpty(d.Get("template_body")),
+			"template_uri":        utils.ValueIngoreEmpty(d.Get("template_uri")),
+			"vars_body":           utils.ValueIngoreEmpty(d.Get("vars_body")),
+			"vars_uri":            utils.ValueIngoreEmpty(d.Get("vars_uri")),
+			"description":         "Created by Terraform to preview the changes of the stack.",
+		}),
+	}
+	resp, err := client.Request("POST", executionPlansURL(client, stackName), &opt)
+	if err != nil {
+		return "", fmt.Errorf("error creating execution plan (%s): %s", planName, err)
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	planId := utils.PathSearch("execution_plan_id", respBody, "").(string)
+	if planId == "" {
+		return "", fmt.Errorf("unable to find the execution plan ID from the API response")
+	}
+	return planId, nil
+}
+
+// getExecutionPlanMetadata queries the execution plan by name, the execution plan ID is optional.
+func getExecutionPlanMetadata(client *golangsdk.ServiceClient, stackName, stackId, planName string) (interface{},
+	error) {
+	getPath := fmt.Sprintf("%s/metadata?stack_id=%s", executionPlanURL(client, stackName, planName), stackId)
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	resp, err := client.Request("GET", getPath, &opt)
+	if err != nil {
+		return nil, err
+	}
+	return utils.FlattenResponse(resp)
+}
+
+func executionPlanStatusRefreshFunc(client *golangsdk.ServiceClient, stackName, stackId,
+	planName string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := getExecutionPlanMetadata(client, stackName, stackId, planName)
+		if err != nil {
+			return nil, "", err
+		}
+
+		status := utils.PathSearch("status", resp, "").(string)
+		if status == "CREATION_FAILED" {
+			return resp, "", fmt.Errorf("unexpected status '%s': %v", status,
+				utils.PathSearch("status_message", resp, ""))
+		}
+		if status == "CREATION_IN_PROGRESS" {
+			return resp, "PENDING", nil
+		}
+		return resp, status, nil
+	}
+}
+
+// prepareExecutionPlan returns the available execution plan with the name, the execution plan is created if it does
+// not exist.
+func prepareExecutionPlan(ctx context.Context, client *golangsdk.ServiceClient, stackName, stackId, planName string,
+	d stackTemplateGetter, timeout time.Duration) (interface{}, error) {
+	_, err := getExecutionPlanMetadata(client, stackName, stackId, planName)
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); !ok {
+			return nil, fmt.Errorf("error retrieving execution plan (%s): %s", planName, err)
+		}
+		if _, err := createExecutionPlan(client, stackName, stackId, planName, d); err != nil {
+			return nil, err
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"PENDING"},
+		Target:       []string{"AVAILABLE", "APPLY_IN_PROGRESS", "APPLIED"},
+		Refresh:      executionPlanStatusRefreshFunc(client, stackName, stackId, planName),
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	return stateConf.WaitForStateContext(ctx)
+}
+
+func listExecutionPlanItems(client *golangsdk.ServiceClient, stackName, stackId, planName,
+	planId string) ([]interface{}, error) {
+	getPath := fmt.Sprintf("%s?stack_id=%s&execution_plan_id=%s", executionPlanURL(client, stackName, planName),
+		stackId, planId)
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	resp, err := client.Request("GET", getPath, &opt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the items of execution plan (%s): %s", planName, err)
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	return utils.PathSearch("execution_plan_items", respBody, make([]interface{}, 0)).([]interface{}), nil
+}
+
+// applyExecutionPlan applies the available execution plan with the name, the deployment ID is returned.
+// An empty ID is returned if the execution plan is not available, e.g. it has been deleted.
+func applyExecutionPlan(client *golangsdk.ServiceClient, stackName, stackId, planName string) (string, error) {
+	metadata, err := getExecutionPlanMetadata(client, stackName, stackId, planName)
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return "", nil
+		}
+		return "", fmt.Errorf("error retrieving execution plan (%s): %s", planName, err)
+	}
+	if status := utils.PathSearch("status", metadata, "").(string); status != "AVAILABLE" {
+		log.Printf("[WARN] the execution plan (%s) is not available, the status is %s", planName, status)
+		return "", nil
+	}
+
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody: map[string]interface{}{
+			"stack_id":          stackId,
+			"execution_plan_id": utils.PathSearch("execution_plan_id", metadata, ""),
+		},
+	}
+	resp, err := client.Request("POST", executionPlanURL(client, stackName, planName), &opt)
+	if err != nil {
+		return "", fmt.Errorf("error applying execution plan (%s): %s", planName, err)
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return "", err
+	}
+	return utils.PathSearch("deployment_id", respBody, "").(string), nil
+}
+
+func listExecutionPlans(client *golangsdk.ServiceClient, stackName, stackId string) ([]interface{}, error) {
+	listPath := fmt.Sprintf("%s?stack_id=%s", executionPlansURL(client, stackName), stackId)
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	result := make([]interface{}, 0)
+	marker := ""
+	for {
+		listPathWithMarker := listPath
+		if marker != "" {
+			listPathWithMarker += "&marker=" + marker
+		}
+		resp, err := client.Request("GET", listPathWithMarker, &opt)
+		if err != nil {
+			return nil, err
+		}
+		respBody, err := utils.FlattenResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, utils.PathSearch("execution_plans", respBody, make([]interface{}, 0)).([]interface{})...)
+		marker = utils.PathSearch("page_info.next_marker", respBody, "").(string)
+		if marker == "" {
+			return result, nil
+		}
+	}
+}
+
+// deleteExecutionPlans deletes the execution plans which are created by the provider, including the applied one and
+// the ones which were planned but never applied. The failures are only logged, as they do not affect the stack.
+func deleteExecutionPlans(client *golangsdk.ServiceClient, stackName, stackId string) {
+	plans, err := listExecutionPlans(client, stackName, stackId)
+	if err != nil {
+		log.Printf("[WARN] error retrieving the execution plans of stack (%s): %s", stackId, err)
+		return
+	}
+
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	for _, plan := range plans {
+		planName := utils.PathSearch("execution_plan_name", plan, "").(string)
+		if !strings.HasPrefix(planName, executionPlanNamePrefix) {
+			continue
+		}
+		deletePath := fmt.Sprintf("%s?stack_id=%s&execution_plan_id=%s", executionPlanURL(client, stackName, planName),
+			stackId, utils.PathSearch("execution_plan_id", plan, ""))
+		if _, err := client.Request("DELETE", deletePath, &opt); err != nil {
+			log.Printf("[WARN] error deleting execution plan (%s) of stack (%s): %s", planName, stackId, err)
+		}
+	}
+}
+
+func flattenExecutionPlanItems(items []interface{}) []interface{} {
+	rst := make([]interface{}, len(items))
+	for i, item := range items {
+		attributes := utils.PathSearch("attributes", item, make([]interface{}, 0)).([]interface{})
+		attributeChanges := make([]interface{}, len(attributes))
+		for j, attribute := range attributes {
+			attributeChanges[j] = map[string]interface{}{
+				"name":           utils.PathSearch("name", attribute, nil),
+				"previous_value": utils.PathSearch("previous_value", attribute, nil),
+				"target_value":   utils.PathSearch("target_value", attribute, nil),
+			}
+		}
+
+		rst[i] = map[string]interface{}{
+			"resource_type": utils.PathSearch("resource_type", item, nil),
+			"resource_name": utils.PathSearch("resource_name", item, nil),
+			"index":         utils.PathSearch("index", item, nil),
+			"action":        utils.PathSearch("action", item, nil),
+			"action_reason": utils.PathSearch("action_reason", item, nil),
+			"provider_name": utils.PathSearch("provider_name", item, nil),
+			"resource_id":   utils.PathSearch("resource_id", item, nil),
+			"attributes":    attributeChanges,
+		}
+	}
+	return rst
+}
+
+// isStackTemplateKnown checks whether the template arguments are known in the configuration, the computed template
+// URI and hash are unknown in the plan until they are computed by resourceStackTemplateDirDiff.
+func isStackTemplateKnown(d *schema.ResourceDiff) bool {
+	rawConfig := d.GetRawConfig()
+	for _, key := range []string{"template_body", "vars_body", "template_uri", "vars_uri", "template_dir",
+		"template_bucket", "template_object_key"} {
+		if rawConfig.IsNull() || !rawConfig.IsKnown() {
+			if !d.NewValueKnown(key) {
+				return false
+			}
+			continue
+		}
+		if !rawConfig.GetAttr(key).IsKnown() {
+			return false
+		}
+	}
+	return true
+}
+
+// getStackUpdateTimeout returns the update timeout in the configuration, which is also the maximum time to wait for
+// the execution plan to be available during plan.
+func getStackUpdateTimeout(d *schema.ResourceDiff) time.Duration {
+	rawConfig := d.GetRawConfig()
+	if !rawConfig.IsKnown() || rawConfig.IsNull() {
+		return defaultStackUpdateTimeout
+	}
+	timeouts := rawConfig.GetAttr(schema.TimeoutsConfigKey)
+	if !timeouts.IsKnown() || timeouts.IsNull() {
+		return defaultStackUpdateTimeout
+	}
+	update := timeouts.GetAttr(schema.TimeoutUpdate)
+	if !update.IsKnown() || update.IsNull() {
+		return defaultStackUpdateTimeout
+	}
+	timeout, err := time.ParseDuration(update.AsString())
+	if err != nil {
+		return defaultStackUpdateTimeout
+	}
+	return timeout
+}
+
+// resourceStackTemplateDiff computes the hash and the URI of the template archive of the local directory. The plan
+// does not change anything in the cloud unless the preview is enabled, then an execution plan is created to preview
+// the changes of the resources when the inline or OBS template of the existing stack is changed.
+func resourceStackTemplateDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !isStackTemplateKnown(d) {
+		return setStackTemplateComputed(d)
+	}
+
+	cfg := meta.(*config.Config)
+	region := d.Get("region").(string)
+	if region == "" {
+		region = cfg.Region
+	}
+	if err := resourceStackTemplateDirDiff(cfg, d, region); err != nil {
+		return err
+	}
+
+	if d.Id() == "" || !d.HasChanges(stackTemplateKeys...) {
+		return nil
+	}
+	if !d.Get("preview_changes").(bool) {
+		return clearExecutionPlan(d)
+	}
+	if d.Get("template_dir").(string) != "" {
+		// the archive is not uploaded during plan, so the execution plan is created after it is uploaded
+		return setExecutionPlanComputed(d)
+	}
+
+	client, err := cfg.AosV1Client(region)
+	if err != nil {
+		return fmt.Errorf("error creating AOS v1 client: %s", err)
+	}
+	planName := buildExecutionPlanName(d)
+	items, err := prepareExecutionPlanItems(ctx, client, d.Get("name").(string), d.Id(), planName, d,
+		getStackUpdateTimeout(d))
+	if err != nil {
+		return err
+	}
+
+	if err := d.SetNew("execution_plan_name", planName); err != nil {
+		return err
+	}
+	if err := d.SetNew("planned_changes", flattenExecutionPlanItems(items)); err != nil {
+		return err
+	}
+	return d.SetNewComputed("outputs")
+}
+
+// prepareExecutionPlanItems creates the execution plan if it does not exist, and returns its items after it is
+// available.
+func prepareExecutionPlanItems(ctx context.Context, client *golangsdk.ServiceClient, stackName, stackId,
+	planName string, d stackTemplateGetter, timeout time.Duration) ([]interface{}, error) {
+	metadata, err := prepareExecutionPlan(ctx, client, stackName, stackId, planName, d, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("error preparing the execution plan of stack (%s): %s", stackId, err)
+	}
+	return listExecutionPlanItems(client, stackName, stackId, planName,
+		utils.PathSearch("execution_plan_id", metadata, "").(string))
+}
+
+// clearExecutionPlan clears the execution plan of the last preview, as the template is deployed directly.
+func clearExecutionPlan(d *schema.ResourceDiff) error {
+	if d.Get("execution_plan_name").(string) != "" {
+		if err := d.SetNew("execution_plan_name", ""); err != nil {
+			return err
+		}
+	}
+	if len(d.Get("planned_changes").([]interface{})) > 0 {
+		if err := d.SetNew("planned_changes", nil); err != nil {
+			return err
+		}
+	}
+	return d.SetNewComputed("outputs")
+}
+
+func setExecutionPlanComputed(d *schema.ResourceDiff) error {
+	for _, key := range []string{"execution_plan_name", "planned_changes", "outputs"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceStackTemplateDirDiff computes the hash and the URI of the template archive of the local directory.
+func resourceStackTemplateDirDiff(cfg *config.Config, d *schema.ResourceDiff, region string) error {
+	templateDir := d.Get("template_dir").(string)
+	if templateDir == "" {
+		return clearStackTemplateDir(d)
+	}
+
+	templateHash, err := computeStackTemplateHash(templateDir)
+	if err != nil {
+		return err
+	}
+	templateUri := buildStackTemplateUri(cfg, region, d.Get("template_bucket").(string),
+		getStackTemplateObjectKey(d.Get("name").(string), d.Get("template_object_key").(string), templateHash))
+	if templateHash != d.Get("template_hash").(string) {
+		if err := d.SetNew("template_hash", templateHash); err != nil {
+			return err
+		}
+	}
+	if templateUri != d.Get("template_uri").(string) {
+		return d.SetNew("template_uri", templateUri)
+	}
+	return nil
+}
+
+// clearStackTemplateDir clears the hash and the URI of the uploaded archive after the template directory is removed,
+// because the template_uri is computed and its value is kept in the state if it is not configured.
+func clearStackTemplateDir(d *schema.ResourceDiff) error {
+	if d.Get("template_hash").(string) != "" {
+		if err := d.SetNew("template_hash", ""); err != nil {
+			return err
+		}
+	}
+
+	rawConfig := d.GetRawConfig()
+	if !rawConfig.IsKnown() || rawConfig.IsNull() || !rawConfig.GetAttr("template_uri").IsNull() {
+		return nil
+	}
+	if d.Get("template_uri").(string) != "" {
+		return d.SetNew("template_uri", "")
+	}
+	return nil
+}
+
+// setStackTemplateComputed marks the attributes which depend on the template as unknown, because the template is only
+// known after apply.
+func setStackTemplateComputed(d *schema.ResourceDiff) error {
+	var keys []string
+	if !d.NewValueKnown("template_dir") || d.Get("template_dir").(string) != "" {
+		keys = append(keys, "template_hash", "template_uri")
+	}
+	if d.Id() != "" {
+		keys = append(keys, "outputs")
+		if d.Get("preview_changes").(bool) {
+			keys = append(keys, "execution_plan_name", "planned_changes")
+		}
+	}
+
+	for _, key := range keys {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package rfs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+type testStackTemplateGetter map[string]interface{}
+
+func (g testStackTemplateGetter) Get(key string) interface{} {
+	if v, ok := g[key]; ok {
+		return v
+	}
+	return ""
+}
+
+func TestBuildExecutionPlanName(t *testing.T) {
+	template := testStackTemplateGetter{
+		"template_body": `{"resource": {}}`,
+		"vars_body":     `name = "demo"`,
+		"updated_at":    "2024-01-01T00:00:00Z",
+	}
+
+	name := buildExecutionPlanName(template)
+	if !strings.HasPrefix(name, executionPlanNamePrefix) {
+		t.Errorf("the name of the execution plan should start with %s, but got %s", executionPlanNamePrefix, name)
+	}
+	if sameName := buildExecutionPlanName(template); sameName != name {
+		t.Errorf("the same template should have the same execution plan, want %s, but got %s", name, sameName)
+	}
+
+	for _, key := range []string{"template_body", "vars_body", "template_uri", "vars_uri", "template_hash",
+		"updated_at"} {
+		changed := testStackTemplateGetter{}
+		for k, v := range template {
+			changed[k] = v
+		}
+		changed[key] = "changed"
+		if buildExecutionPlanName(changed) == name {
+			t.Errorf("the execution plan should be changed with %s, but got the same name %s", key, name)
+		}
+	}
+}
+
+func TestFlattenExecutionPlanItems(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{
+			"resource_type": "huaweicloud_vpc_subnet",
+			"resource_name": "test",
+			"action":        "UPDATE",
+			"action_reason": "the name is changed",
+			"provider_name": "huaweicloud",
+			"resource_id":   "subnet-id",
+			"attributes": []interface{}{
+				map[string]interface{}{
+					"name":           "name",
+					"previous_value": "subnet-old",
+					"target_value":   "subnet-new",
+				},
+			},
+		},
+		map[string]interface{}{
+			"resource_type": "huaweicloud_vpc",
+			"resource_name": "test",
+			"index":         "0",
+			"action":        "ADD",
+		},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"resource_type": "huaweicloud_vpc_subnet",
+			"resource_name": "test",
+			"index":         nil,
+			"action":        "UPDATE",
+			"action_reason": "the name is changed",
+			"provider_name": "huaweicloud",
+			"resource_id":   "subnet-id",
+			"attributes": []interface{}{
+				map[string]interface{}{
+					"name":           "name",
+					"previous_value": "subnet-old",
+					"target_value":   "subnet-new",
+				},
+			},
+		},
+		map[string]interface{}{
+			"resource_type": "huaweicloud_vpc",
+			"resource_name": "test",
+			"index":         "0",
+			"action":        "ADD",
+			"action_reason": nil,
+			"provider_name": nil,
+			"resource_id":   nil,
+			"attributes":    []interface{}{},
+		},
+	}
+	if actual := flattenExecutionPlanItems(items); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected the planned changes %v, but got %v", expected, actual)
+	}
+}
+
+// the plan only computes the hash and the URI of the template archive unless the preview is enabled, so it succeeds
+// without any client.
+func TestResourceStackTemplateDiff_withoutPreview(t *testing.T) {
+	templateDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(templateDir, "main.tf"), []byte("locals {}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{Region: "cn-north-4", Cloud: "myhuaweicloud.com"}
+	state := &terraform.InstanceState{
+		ID: "stack-id",
+		Attributes: map[string]string{
+			"name":                "demo",
+			"region":              "cn-north-4",
+			"template_dir":        templateDir,
+			"template_bucket":     "demo-bucket",
+			"template_hash":       "old-hash",
+			"template_uri":        "https://demo-bucket.obs.cn-north-4.myhuaweicloud.com/demo-old.zip",
+			"execution_plan_name": "tf-plan-old",
+			"planned_changes.#":   "0",
+		},
+	}
+	raw := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":            "demo",
+		"template_dir":    templateDir,
+		"template_bucket": "demo-bucket",
+	})
+
+	diff, err := ResourceStack().Diff(context.Background(), state, raw, cfg)
+	if err != nil {
+		t.Fatalf("error planning the stack: %s", err)
+	}
+
+	templateHash, err := computeStackTemplateHash(templateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"template_hash": templateHash,
+		"template_uri": fmt.Sprintf("https://demo-bucket.obs.cn-north-4.myhuaweicloud.com/demo-%s.zip",
+			templateHash[:16]),
+	}
+	for key, value := range expected {
+		attr, ok := diff.Attributes[key]
+		if !ok || attr.NewComputed || attr.New != value {
+			t.Errorf("expected %s to be planned as %q, but got %#v", key, value, attr)
+		}
+	}
+	// the empty string of the computed attribute is planned as unknown, and it is cleared during apply
+	if attr, ok := diff.Attributes["execution_plan_name"]; !ok || (!attr.NewComputed && attr.New != "") {
+		t.Errorf("expected the execution plan of the last preview to be cleared, but got %#v", attr)
+	}
+}
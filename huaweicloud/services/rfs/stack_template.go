@@ -0,0 +1,51 @@
+package rfs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	obsservice "github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/obs"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// computeStackTemplateHash returns the hex-encoded SHA256 hash of the template archive.
+func computeStackTemplateHash(templateDir string) (string, error) {
+	hash := sha256.New()
+	if err := utils.WriteZipArchive(templateDir, "", hash); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// getStackTemplateObjectKey returns the object key of the template archive, the default key contains the template
+// hash, so the archive of the different contents are uploaded to the different objects.
+func getStackTemplateObjectKey(stackName, objectKey, templateHash string) string {
+	if objectKey != "" {
+		return objectKey
+	}
+	return fmt.Sprintf("%s-%s.zip", stackName, templateHash[:16])
+}
+
+func buildStackTemplateUri(cfg *config.Config, region, bucket, objectKey string) string {
+	return fmt.Sprintf("https://%s/%s", obsservice.BucketDomainNameWithCloud(bucket, region, cfg.Cloud), objectKey)
+}
+
+// uploadStackTemplate archives the template directory and uploads the archive to the OBS bucket, the URI and the hash
+// of the uploaded archive are returned.
+func uploadStackTemplate(cfg *config.Config, region, templateDir, bucket, stackName, objectKey string) (string, string,
+	error) {
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return "", "", fmt.Errorf("error creating OBS client: %s", err)
+	}
+
+	key, hash, err := utils.UploadZipArchive(obsClient, templateDir, "", bucket, func(hash []byte) string {
+		return getStackTemplateObjectKey(stackName, objectKey, hex.EncodeToString(hash))
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("error uploading the stack template: %s", err)
+	}
+	return buildStackTemplateUri(cfg, region, bucket, key), hex.EncodeToString(hash), nil
+}
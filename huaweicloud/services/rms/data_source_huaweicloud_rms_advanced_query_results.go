@@ -0,0 +1,310 @@
+package rms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// DataSourceAdvancedQueryResults runs the ResourceQL expression during plan.
+// API: RMS POST /v1/resource-manager/domains/{domain_id}/run-query
+// API: RMS GET /v1/resource-manager/domains/{domain_id}/stored-queries/{query_id}
+func DataSourceAdvancedQueryResults() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAdvancedQueryResultsRead,
+
+		Schema: map[string]*schema.Schema{
+			"expression": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"expression", "query_id"},
+				Description:  `Specifies the ResourceQL expression to run.`,
+			},
+			"query_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `Specifies the ID of the advanced query whose expression will be run.`,
+			},
+			"select_fields": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `Indicates the fields selected by the ResourceQL expression.`,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+				Description: `Indicates the rows of the query results, the values which are not strings are in JSON format.`,
+			},
+			"rows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        advancedQueryRowSchema(),
+				Description: `Indicates the rows of the query results, the columns keep the types of the values.`,
+			},
+			"results_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the rows of the query results in JSON format, the values keep their types.`,
+			},
+		},
+	}
+}
+
+func advancedQueryRowSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"columns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `Indicates the name of the selected field.`,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `Indicates the type of the value.`,
+						},
+						"string_value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `Indicates the value if the type is string.`,
+						},
+						"number_value": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: `Indicates the value if the type is number.`,
+						},
+						"bool_value": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: `Indicates the value if the type is bool.`,
+						},
+						"json_value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `Indicates the value in JSON format if the type is object or array.`,
+						},
+					},
+				},
+				Description: `Indicates the columns of the row in the order of the selected fields.`,
+			},
+		},
+	}
+}
+
+func dataSourceAdvancedQueryResultsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("rms", region)
+	if err != nil {
+		return diag.Errorf("error creating RMS client: %s", err)
+	}
+
+	expression := d.Get("expression").(string)
+	if queryId, ok := d.GetOk("query_id"); ok {
+		expression, err = getAdvancedQueryExpression(client, cfg.DomainID, queryId.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	runQueryHttpUrl := "v1/resource-manager/domains/{domain_id}/run-query"
+	runQueryPath := client.Endpoint + runQueryHttpUrl
+	runQueryPath = strings.ReplaceAll(runQueryPath, "{domain_id}", cfg.DomainID)
+	runQueryOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody: map[string]interface{}{
+			"expression": expression,
+		},
+	}
+	runQueryResp, err := client.Request("POST", runQueryPath, &runQueryOpt)
+	if err != nil {
+		return diag.Errorf("error running RMS advanced query: %s", err)
+	}
+	runQueryRespBody, err := utils.FlattenResponse(runQueryResp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rows := utils.PathSearch("results", runQueryRespBody, make([]interface{}, 0)).([]interface{})
+	results, err := flattenAdvancedQueryResults(rows)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	selectFields := utils.PathSearch("query_info.select_fields", runQueryRespBody, make([]interface{}, 0)).([]interface{})
+	typedRows, err := flattenAdvancedQueryRows(rows, selectFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resultsJson, err := json.Marshal(rows)
+	if err != nil {
+		return diag.Errorf("error marshaling the results of RMS advanced query: %s", err)
+	}
+
+	dataSourceId, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(dataSourceId)
+
+	mErr := multierror.Append(
+		d.Set("select_fields", selectFields),
+		d.Set("results", results),
+		d.Set("rows", typedRows),
+		d.Set("results_json", string(resultsJson)),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func getAdvancedQueryExpression(client *golangsdk.ServiceClient, domainId, queryId string) (string, error) {
+	getAdvancedQueryHttpUrl := "v1/resource-manager/domains/{domain_id}/stored-queries/{query_id}"
+	getAdvancedQueryPath := client.Endpoint + getAdvancedQueryHttpUrl
+	getAdvancedQueryPath = strings.ReplaceAll(getAdvancedQueryPath, "{domain_id}", domainId)
+	getAdvancedQueryPath = strings.ReplaceAll(getAdvancedQueryPath, "{query_id}", queryId)
+	getAdvancedQueryOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	getAdvancedQueryResp, err := client.Request("GET", getAdvancedQueryPath, &getAdvancedQueryOpt)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving RMS advanced query (%s): %s", queryId, err)
+	}
+	getAdvancedQueryRespBody, err := utils.FlattenResponse(getAdvancedQueryResp)
+	if err != nil {
+		return "", err
+	}
+
+	expression := utils.PathSearch("expression", getAdvancedQueryRespBody, "").(string)
+	if expression == "" {
+		return "", fmt.Errorf("the expression of RMS advanced query (%s) is empty", queryId)
+	}
+	return expression, nil
+}
+
+// flattenAdvancedQueryResults converts the rows to the maps of strings, the values which are not strings are
+// converted to JSON, because the types of the selected fields are unknown before the query is run.
+func flattenAdvancedQueryResults(rows []interface{}) ([]map[string]interface{}, error) {
+	results := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		rowMap, ok := row.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the row of RMS advanced query results is not an object: %v", row)
+		}
+
+		result := make(map[string]interface{}, len(rowMap))
+		for k, v := range rowMap {
+			switch value := v.(type) {
+			case nil:
+				continue
+			case string:
+				result[k] = value
+			default:
+				b, err := json.Marshal(value)
+				if err != nil {
+					return nil, fmt.Errorf("error marshaling the field (%s) of RMS advanced query results: %s", k, err)
+				}
+				result[k] = string(b)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// flattenAdvancedQueryRows converts the rows to the typed columns, the columns are sorted by the selected fields and
+// the fields which are not selected explicitly (e.g. by "select *") are sorted by name.
+func flattenAdvancedQueryRows(rows, selectFields []interface{}) ([]map[string]interface{}, error) {
+	fieldOrders := make(map[string]int, len(selectFields))
+	for i, field := range selectFields {
+		if name, ok := field.(string); ok {
+			fieldOrders[name] = i
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		rowMap, ok := row.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the row of RMS advanced query results is not an object: %v", row)
+		}
+
+		names := make([]string, 0, len(rowMap))
+		for name := range rowMap {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			orderI, selectedI := fieldOrders[names[i]]
+			orderJ, selectedJ := fieldOrders[names[j]]
+			if selectedI != selectedJ {
+				return selectedI
+			}
+			if selectedI && orderI != orderJ {
+				return orderI < orderJ
+			}
+			return names[i] < names[j]
+		})
+
+		columns := make([]map[string]interface{}, 0, len(names))
+		for _, name := range names {
+			column, err := flattenAdvancedQueryColumn(name, rowMap[name])
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, column)
+		}
+		result = append(result, map[string]interface{}{
+			"columns": columns,
+		})
+	}
+	return result, nil
+}
+
+func flattenAdvancedQueryColumn(name string, value interface{}) (map[string]interface{}, error) {
+	column := map[string]interface{}{
+		"name": name,
+	}
+	switch v := value.(type) {
+	case nil:
+		column["type"] = "null"
+	case string:
+		column["type"] = "string"
+		column["string_value"] = v
+	case float64:
+		column["type"] = "number"
+		column["number_value"] = v
+	case bool:
+		column["type"] = "bool"
+		column["bool_value"] = v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling the field (%s) of RMS advanced query results: %s", name, err)
+		}
+		column["type"] = "object"
+		if _, ok := v.([]interface{}); ok {
+			column["type"] = "array"
+		}
+		column["json_value"] = string(b)
+	}
+	return column, nil
+}
@@ -0,0 +1,70 @@
+package rms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlattenAdvancedQueryRows(t *testing.T) {
+	rows := []interface{}{
+		map[string]interface{}{
+			"tags":    map[string]interface{}{"foo": "bar"},
+			"name":    "ecs-test",
+			"id":      "ecs-id",
+			"vcpus":   float64(2),
+			"enabled": true,
+			"ips":     []interface{}{"192.168.0.2"},
+			"deleted": nil,
+		},
+	}
+
+	result, err := flattenAdvancedQueryRows(rows, []interface{}{"id", "name", "tags"})
+	if err != nil {
+		t.Fatalf("error flattening the rows: %s", err)
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"columns": []map[string]interface{}{
+				{"name": "id", "type": "string", "string_value": "ecs-id"},
+				{"name": "name", "type": "string", "string_value": "ecs-test"},
+				{"name": "tags", "type": "object", "json_value": `{"foo":"bar"}`},
+				// the fields which are not selected explicitly are sorted by name
+				{"name": "deleted", "type": "null"},
+				{"name": "enabled", "type": "bool", "bool_value": true},
+				{"name": "ips", "type": "array", "json_value": `["192.168.0.2"]`},
+				{"name": "vcpus", "type": "number", "number_value": float64(2)},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected the rows %v, but got %v", expected, result)
+	}
+
+	if _, err := flattenAdvancedQueryRows([]interface{}{"ecs-id"}, nil); err == nil {
+		t.Errorf("expected an error for the row which is not an object")
+	}
+}
+
+func TestFlattenAdvancedQueryResults(t *testing.T) {
+	rows := []interface{}{
+		map[string]interface{}{
+			"id":      "ecs-id",
+			"vcpus":   float64(2),
+			"tags":    map[string]interface{}{"foo": "bar"},
+			"deleted": nil,
+		},
+	}
+
+	result, err := flattenAdvancedQueryResults(rows)
+	if err != nil {
+		t.Fatalf("error flattening the results: %s", err)
+	}
+
+	expected := []map[string]interface{}{
+		{"id": "ecs-id", "vcpus": "2", "tags": `{"foo":"bar"}`},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected the results %v, but got %v", expected, result)
+	}
+}
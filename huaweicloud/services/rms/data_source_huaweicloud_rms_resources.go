@@ -0,0 +1,295 @@
+package rms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// DataSourceResources lists the resources recorded by RMS, which includes the resources created outside Terraform.
+// API: RMS GET /v1/resource-manager/domains/{domain_id}/all-resources
+func DataSourceResources() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceResourcesRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `Specifies the region where the resources are located.`,
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `Specifies the cloud service name of the resources, e.g. ecs.`,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"provider_name"},
+				Description:  `Specifies the resource type of the cloud service, e.g. cloudservers.`,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `Specifies the name of the resources.`,
+			},
+			"resource_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `Specifies the ID of the resource.`,
+			},
+			"enterprise_project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `Specifies the enterprise project ID of the resources.`,
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `Specifies the tags of the resources, an empty value matches all values of the key.`,
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `Indicates the IDs of the resources.`,
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        resourcesResourceSchema(),
+				Description: `Indicates the list of the resources.`,
+			},
+		},
+	}
+}
+
+func resourcesResourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the ID of the resource.`,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the name of the resource.`,
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the cloud service name of the resource.`,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the resource type of the cloud service.`,
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the region where the resource is located.`,
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the project ID of the resource.`,
+			},
+			"enterprise_project_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the enterprise project ID of the resource.`,
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `Indicates the tags of the resource.`,
+			},
+			"properties": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the properties of the resource in JSON format.`,
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the state of the resource.`,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the time when the resource was created.`,
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Indicates the time when the resource was updated.`,
+			},
+		},
+	}
+}
+
+func dataSourceResourcesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("rms", region)
+	if err != nil {
+		return diag.Errorf("error creating RMS client: %s", err)
+	}
+
+	listResourcesHttpUrl := "v1/resource-manager/domains/{domain_id}/all-resources"
+	listResourcesBasePath := client.Endpoint + listResourcesHttpUrl
+	listResourcesBasePath = strings.ReplaceAll(listResourcesBasePath, "{domain_id}", cfg.DomainID)
+	listResourcesOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	var resources []interface{}
+	var marker string
+	for {
+		listResourcesPath := listResourcesBasePath + buildListResourcesQueryParams(d, region, marker)
+		listResourcesResp, err := client.Request("GET", listResourcesPath, &listResourcesOpt)
+		if err != nil {
+			return diag.Errorf("error retrieving RMS resources: %s", err)
+		}
+		listResourcesRespBody, err := utils.FlattenResponse(listResourcesResp)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		resources = append(resources,
+			utils.PathSearch("resources", listResourcesRespBody, make([]interface{}, 0)).([]interface{})...)
+		marker = utils.PathSearch("page_info.next_marker", listResourcesRespBody, "").(string)
+		if marker == "" {
+			break
+		}
+	}
+
+	ids, result, err := flattenListResourcesResponseBody(d, resources)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dataSourceId, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(dataSourceId)
+
+	mErr := multierror.Append(
+		d.Set("region", region),
+		d.Set("ids", ids),
+		d.Set("resources", result),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func buildListResourcesQueryParams(d *schema.ResourceData, region, marker string) string {
+	params := url.Values{}
+	params.Set("limit", "200")
+	params.Set("region_id", region)
+	if v, ok := d.GetOk("type"); ok {
+		params.Set("type", fmt.Sprintf("%s.%s", d.Get("provider_name"), v))
+	}
+	if v, ok := d.GetOk("name"); ok {
+		params.Set("name", v.(string))
+	}
+	if v, ok := d.GetOk("resource_id"); ok {
+		params.Set("id", v.(string))
+	}
+	if v, ok := d.GetOk("enterprise_project_id"); ok {
+		params.Set("ep_id", v.(string))
+	}
+
+	tagKeys := make([]string, 0)
+	for k := range d.Get("tags").(map[string]interface{}) {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	for _, k := range tagKeys {
+		if v := d.Get("tags").(map[string]interface{})[k].(string); v != "" {
+			params.Add("tags", fmt.Sprintf("%s=%s", k, v))
+		} else {
+			params.Add("tags", k)
+		}
+	}
+
+	if marker != "" {
+		params.Set("marker", marker)
+	}
+	return "?" + params.Encode()
+}
+
+// flattenListResourcesResponseBody filters the resources by the provider and tags again, because the API can only
+// filter the provider with the type.
+func flattenListResourcesResponseBody(d *schema.ResourceData, resources []interface{}) ([]string, []interface{}, error) {
+	providerName := d.Get("provider_name").(string)
+	tagsFilter := d.Get("tags").(map[string]interface{})
+
+	ids := make([]string, 0, len(resources))
+	result := make([]interface{}, 0, len(resources))
+	for _, v := range resources {
+		if providerName != "" && utils.PathSearch("provider", v, "").(string) != providerName {
+			continue
+		}
+		tags := utils.PathSearch("tags", v, make(map[string]interface{})).(map[string]interface{})
+		if !matchResourceTags(tags, tagsFilter) {
+			continue
+		}
+
+		properties, err := json.Marshal(utils.PathSearch("properties", v, make(map[string]interface{})))
+		if err != nil {
+			return nil, nil, fmt.Errorf("error marshaling the properties of RMS resource: %s", err)
+		}
+
+		id := utils.PathSearch("id", v, "").(string)
+		ids = append(ids, id)
+		result = append(result, map[string]interface{}{
+			"id":                    id,
+			"name":                  utils.PathSearch("name", v, nil),
+			"provider_name":         utils.PathSearch("provider", v, nil),
+			"type":                  utils.PathSearch("type", v, nil),
+			"region":                utils.PathSearch("region_id", v, nil),
+			"project_id":            utils.PathSearch("project_id", v, nil),
+			"enterprise_project_id": utils.PathSearch("ep_id", v, nil),
+			"tags":                  tags,
+			"properties":            string(properties),
+			"state":                 utils.PathSearch("state", v, nil),
+			"created_at":            utils.PathSearch("created", v, nil),
+			"updated_at":            utils.PathSearch("updated", v, nil),
+		})
+	}
+	return ids, result, nil
+}
+
+func matchResourceTags(tags, filter map[string]interface{}) bool {
+	for k, v := range filter {
+		value, ok := tags[k]
+		if !ok {
+			return false
+		}
+		if v.(string) != "" && v != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package rms
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMatchResourceTags(t *testing.T) {
+	tags := map[string]interface{}{
+		"env":   "prod",
+		"owner": "",
+	}
+	cases := []struct {
+		name     string
+		filter   map[string]interface{}
+		expected bool
+	}{
+		{"empty filter", map[string]interface{}{}, true},
+		{"key and value", map[string]interface{}{"env": "prod"}, true},
+		{"key only", map[string]interface{}{"env": ""}, true},
+		{"key with empty value", map[string]interface{}{"owner": ""}, true},
+		{"multiple tags", map[string]interface{}{"env": "prod", "owner": ""}, true},
+		{"different value", map[string]interface{}{"env": "test"}, false},
+		{"missing key", map[string]interface{}{"team": ""}, false},
+		{"one of the tags is missing", map[string]interface{}{"env": "prod", "team": "ops"}, false},
+	}
+
+	for _, c := range cases {
+		if got := matchResourceTags(tags, c.filter); got != c.expected {
+			t.Errorf("%s: expected %t, but got %t", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestBuildListResourcesQueryParams(t *testing.T) {
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		marker   string
+		expected url.Values
+	}{
+		{
+			name: "region only",
+			raw:  map[string]interface{}{},
+			expected: url.Values{
+				"limit":     {"200"},
+				"region_id": {"cn-north-4"},
+			},
+		},
+		{
+			name: "all filters",
+			raw: map[string]interface{}{
+				"provider_name":         "ecs",
+				"type":                  "cloudservers",
+				"name":                  "ecs-test",
+				"resource_id":           "ecs-id",
+				"enterprise_project_id": "0",
+				"tags":                  map[string]interface{}{"owner": "", "env": "prod"},
+			},
+			marker: "next-marker",
+			expected: url.Values{
+				"limit":     {"200"},
+				"region_id": {"cn-north-4"},
+				"type":      {"ecs.cloudservers"},
+				"name":      {"ecs-test"},
+				"id":        {"ecs-id"},
+				"ep_id":     {"0"},
+				// the tags are sorted by key, and the tags with empty values only filter the keys
+				"tags":   {"env=prod", "owner"},
+				"marker": {"next-marker"},
+			},
+		},
+		{
+			// the API can only filter the provider with the type, so the provider is filtered after listing
+			name: "provider only",
+			raw: map[string]interface{}{
+				"provider_name": "ecs",
+			},
+			expected: url.Values{
+				"limit":     {"200"},
+				"region_id": {"cn-north-4"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, DataSourceResources().Schema, c.raw)
+		query := buildListResourcesQueryParams(d, "cn-north-4", c.marker)
+		got, err := url.ParseQuery(query[1:])
+		if err != nil {
+			t.Fatalf("%s: error parsing the query %s: %s", c.name, query, err)
+		}
+		if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected the query %v, but got %v", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestFlattenListResourcesResponseBody(t *testing.T) {
+	resources := []interface{}{
+		map[string]interface{}{
+			"id":       "ecs-id",
+			"name":     "ecs-test",
+			"provider": "ecs",
+			"type":     "cloudservers",
+			"tags":     map[string]interface{}{"env": "prod"},
+		},
+		map[string]interface{}{
+			"id":       "evs-id",
+			"name":     "evs-test",
+			"provider": "evs",
+			"type":     "volumes",
+			"tags":     map[string]interface{}{"env": "prod"},
+		},
+		map[string]interface{}{
+			"id":       "ecs-id-2",
+			"name":     "ecs-test-2",
+			"provider": "ecs",
+			"type":     "cloudservers",
+			"tags":     map[string]interface{}{"env": "test"},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, DataSourceResources().Schema, map[string]interface{}{
+		"provider_name": "ecs",
+		"tags":          map[string]interface{}{"env": "prod"},
+	})
+	ids, result, err := flattenListResourcesResponseBody(d, resources)
+	if err != nil {
+		t.Fatalf("error flattening the resources: %s", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"ecs-id"}) {
+		t.Errorf("expected the IDs [ecs-id], but got %v", ids)
+	}
+	if len(result) != 1 || result[0].(map[string]interface{})["properties"] != "{}" {
+		t.Errorf("expected one resource with empty properties, but got %v", result)
+	}
+}
@@ -0,0 +1,336 @@
+package rms
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// preflightRule evaluates the planned attributes of a resource type, it returns the reasons of the violations.
+type preflightRule struct {
+	ResourceType string
+	// the names of the supported parameters, the parameter values are validated by Check
+	Parameters []string
+	Check      func(d *schema.ResourceDiff, parameters map[string]interface{}) ([]string, error)
+}
+
+// preflightRules are the local rules of the preflight policies, the keys are the policy names.
+var preflightRules = map[string]preflightRule{
+	"obs-bucket-encryption": {
+		ResourceType: "huaweicloud_obs_bucket",
+		Parameters:   []string{"sseAlgorithms"},
+		Check:        checkObsBucketEncryption,
+	},
+	"rds-instance-ssl-enable": {
+		ResourceType: "huaweicloud_rds_instance",
+		Check:        checkRdsInstanceSSLEnable,
+	},
+	"ecs-instance-no-public-ip": {
+		ResourceType: "huaweicloud_compute_instance",
+		Check:        checkEcsInstanceNoPublicIP,
+	},
+	"vpc-sg-restricted-ingress": {
+		ResourceType: "huaweicloud_networking_secgroup_rule",
+		Parameters:   []string{"allowedPorts"},
+		Check:        checkSecgroupRuleRestrictedIngress,
+	},
+}
+
+func supportedPreflightPolicies() string {
+	names := make([]string, 0, len(preflightRules))
+	for k := range preflightRules {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// ValidatePreflightPolicy checks whether the policy is supported and the parameters are expected.
+func ValidatePreflightPolicy(policy config.PreflightPolicy) error {
+	rule, ok := preflightRules[policy.Name]
+	if !ok {
+		return fmt.Errorf("unsupported preflight policy %q, the supported policies are: %s", policy.Name,
+			supportedPreflightPolicies())
+	}
+	for k := range policy.Parameters {
+		if !utils.StrSliceContains(rule.Parameters, k) {
+			return fmt.Errorf("unsupported parameter %q of the preflight policy %q", k, policy.Name)
+		}
+	}
+	return nil
+}
+
+// PreflightPolicyResourceTypes returns the resource types which are evaluated by the preflight policies.
+func PreflightPolicyResourceTypes() []string {
+	resourceTypes := make([]string, 0, len(preflightRules))
+	for _, v := range preflightRules {
+		if !utils.StrSliceContains(resourceTypes, v.ResourceType) {
+			resourceTypes = append(resourceTypes, v.ResourceType)
+		}
+	}
+	return resourceTypes
+}
+
+// PreflightPolicyDiff returns the CustomizeDiff function which evaluates the provider-level preflight policies against
+// the planned attributes of the resource, the plan fails if any policy is violated.
+func PreflightPolicyDiff(resourceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		cfg, ok := meta.(*config.Config)
+		if !ok || len(cfg.PreflightPolicies) == 0 {
+			return nil
+		}
+
+		var mErr *multierror.Error
+		for _, policy := range cfg.PreflightPolicies {
+			rule, ok := preflightRules[policy.Name]
+			if !ok || rule.ResourceType != resourceType {
+				continue
+			}
+
+			reasons, err := rule.Check(d, policy.Parameters)
+			if err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("error evaluating the preflight policy %q: %s",
+					policy.Name, err))
+				continue
+			}
+			for _, reason := range reasons {
+				mErr = multierror.Append(mErr, fmt.Errorf("the preflight policy %q is violated: %s", policy.Name,
+					reason))
+			}
+		}
+		return mErr.ErrorOrNil()
+	}
+}
+
+// getKnownValue returns the planned value of the key, the values which are unknown in the configuration are not
+// evaluated because they are only known after apply. The configuration is checked instead of the plan, because the
+// optional and computed arguments are unknown in the plan if they are omitted, and the zero values are returned.
+func getKnownValue(d *schema.ResourceDiff, key string) (interface{}, bool) {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().HasAttribute(key) {
+		if !d.NewValueKnown(key) {
+			return nil, false
+		}
+		return d.Get(key), true
+	}
+
+	if !rawConfig.GetAttr(key).IsKnown() {
+		return nil, false
+	}
+	return d.Get(key), true
+}
+
+func checkObsBucketEncryption(d *schema.ResourceDiff, parameters map[string]interface{}) ([]string, error) {
+	allowedAlgorithms, err := getStringListParameter(parameters, "sseAlgorithms")
+	if err != nil {
+		return nil, err
+	}
+
+	encryption, ok := getKnownValue(d, "encryption")
+	if !ok {
+		return nil, nil
+	}
+	if !encryption.(bool) {
+		return []string{"the server-side encryption of the OBS bucket is not enabled, please set encryption to true"},
+			nil
+	}
+
+	algorithm, ok := getKnownValue(d, "sse_algorithm")
+	if !ok || len(allowedAlgorithms) == 0 || algorithm.(string) == "" {
+		return nil, nil
+	}
+	if !utils.StrSliceContains(allowedAlgorithms, algorithm.(string)) {
+		return []string{fmt.Sprintf("the sse_algorithm %q is not in the allowed algorithms %v", algorithm,
+			allowedAlgorithms)}, nil
+	}
+	return nil, nil
+}
+
+func checkRdsInstanceSSLEnable(d *schema.ResourceDiff, _ map[string]interface{}) ([]string, error) {
+	sslEnable, ok := getKnownValue(d, "ssl_enable")
+	if !ok || sslEnable.(bool) {
+		return nil, nil
+	}
+	return []string{"the SSL of the RDS instance is not enabled, please set ssl_enable to true"}, nil
+}
+
+func checkEcsInstanceNoPublicIP(d *schema.ResourceDiff, _ map[string]interface{}) ([]string, error) {
+	var reasons []string
+	for _, key := range []string{"eip_id", "eip_type"} {
+		if v, ok := getKnownValue(d, key); ok && v.(string) != "" {
+			reasons = append(reasons, fmt.Sprintf("the ECS instance is bound with a public IP by %s", key))
+		}
+	}
+	return reasons, nil
+}
+
+// portRange is an inclusive range of ports.
+type portRange struct {
+	Min int
+	Max int
+}
+
+func (r portRange) String() string {
+	if r.Min == r.Max {
+		return strconv.Itoa(r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+// parsePortRanges parses the ports in the format of the ports argument of the security group rule,
+// e.g. 80,443,8000-9000.
+func parsePortRanges(ports []string) ([]portRange, error) {
+	result := make([]portRange, 0, len(ports))
+	for _, port := range ports {
+		port = strings.TrimSpace(port)
+		if port == "" {
+			continue
+		}
+
+		bounds := strings.SplitN(port, "-", 2)
+		minPort, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", port)
+		}
+		maxPort := minPort
+		if len(bounds) == 2 {
+			maxPort, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid port range %q", port)
+			}
+		}
+		if minPort < 1 || maxPort > 65535 || minPort > maxPort {
+			return nil, fmt.Errorf("invalid port range %q", port)
+		}
+		result = append(result, portRange{Min: minPort, Max: maxPort})
+	}
+	return result, nil
+}
+
+// getSecgroupRulePortRanges returns the port ranges opened by the security group rule, all ports are opened if
+// neither the ports nor the port range is specified.
+func getSecgroupRulePortRanges(d *schema.ResourceDiff) ([]portRange, bool, error) {
+	allPorts := []portRange{{Min: 1, Max: 65535}}
+	protocol, ok := getKnownValue(d, "protocol")
+	if !ok {
+		return nil, false, nil
+	}
+	if protocol.(string) == "" {
+		return allPorts, true, nil
+	}
+	if protocol.(string) != "tcp" && protocol.(string) != "udp" {
+		// the other protocols, e.g. icmp, do not have ports
+		return nil, true, nil
+	}
+
+	ports, ok := getKnownValue(d, "ports")
+	if !ok {
+		return nil, false, nil
+	}
+	if ports.(string) != "" {
+		ranges, err := parsePortRanges(strings.Split(ports.(string), ","))
+		return ranges, true, err
+	}
+
+	minPort, minKnown := getKnownValue(d, "port_range_min")
+	maxPort, maxKnown := getKnownValue(d, "port_range_max")
+	if !minKnown || !maxKnown {
+		return nil, false, nil
+	}
+	if minPort.(int) == 0 && maxPort.(int) == 0 {
+		return allPorts, true, nil
+	}
+	return []portRange{{Min: minPort.(int), Max: maxPort.(int)}}, true, nil
+}
+
+func checkSecgroupRuleRestrictedIngress(d *schema.ResourceDiff, parameters map[string]interface{}) ([]string, error) {
+	allowedPorts, err := getStringListParameter(parameters, "allowedPorts")
+	if err != nil {
+		return nil, err
+	}
+	allowedRanges, err := parsePortRanges(allowedPorts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameter allowedPorts: %s", err)
+	}
+
+	direction, ok := getKnownValue(d, "direction")
+	if !ok || direction.(string) != "ingress" {
+		return nil, nil
+	}
+	if action, ok := getKnownValue(d, "action"); !ok || action.(string) == "deny" {
+		return nil, nil
+	}
+
+	remoteIPPrefix, ok := getKnownValue(d, "remote_ip_prefix")
+	if !ok {
+		return nil, nil
+	}
+	switch remoteIPPrefix.(string) {
+	case "0.0.0.0/0", "::/0":
+	case "":
+		// the rule is opened to all addresses if none of the remotes is specified
+		for _, key := range []string{"remote_group_id", "remote_address_group_id"} {
+			if v, ok := getKnownValue(d, key); !ok || v.(string) != "" {
+				return nil, nil
+			}
+		}
+	default:
+		return nil, nil
+	}
+
+	ruleRanges, ok, err := getSecgroupRulePortRanges(d)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var reasons []string
+	for _, ruleRange := range ruleRanges {
+		if !isPortRangeAllowed(ruleRange, allowedRanges) {
+			reasons = append(reasons, fmt.Sprintf("the port %s is opened to all addresses by the ingress rule, "+
+				"the allowed ports are %v", ruleRange, allowedPorts))
+		}
+	}
+	return reasons, nil
+}
+
+func isPortRangeAllowed(target portRange, allowedRanges []portRange) bool {
+	for _, v := range allowedRanges {
+		if target.Min >= v.Min && target.Max <= v.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// getStringListParameter returns the parameter value which is a list of strings, the numbers are converted to strings.
+func getStringListParameter(parameters map[string]interface{}, name string) ([]string, error) {
+	v, ok := parameters[name]
+	if !ok || v == nil {
+		return nil, nil
+	}
+
+	values, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the parameter %s should be a list, but got %v", name, v)
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		switch value := value.(type) {
+		case string:
+			result = append(result, value)
+		case float64:
+			result = append(result, strconv.FormatFloat(value, 'f', -1, 64))
+		default:
+			return nil, fmt.Errorf("the element of the parameter %s should be a string or number, but got %v",
+				name, value)
+		}
+	}
+	return result, nil
+}
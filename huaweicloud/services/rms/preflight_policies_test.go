@@ -0,0 +1,198 @@
+package rms
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/rds"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/vpc"
+)
+
+func planWithPreflightPolicies(resourceType string, r *schema.Resource, raw map[string]interface{},
+	policies ...config.PreflightPolicy) error {
+	r.CustomizeDiff = PreflightPolicyDiff(resourceType)
+	cfg := &config.Config{
+		PreflightPolicies: policies,
+	}
+	// the raw configuration is set to the state like the gRPC provider server does when planning
+	rawConfig, err := r.CoreConfigSchema().CoerceValue(toCtyValue(raw))
+	if err != nil {
+		return err
+	}
+	state := &terraform.InstanceState{
+		RawConfig: rawConfig,
+	}
+	_, err = r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(raw), cfg)
+	return err
+}
+
+func toCtyValue(raw interface{}) cty.Value {
+	switch v := raw.(type) {
+	case string:
+		return cty.StringVal(v)
+	case bool:
+		return cty.BoolVal(v)
+	case int:
+		return cty.NumberIntVal(int64(v))
+	case []interface{}:
+		values := make([]cty.Value, 0, len(v))
+		for _, elem := range v {
+			values = append(values, toCtyValue(elem))
+		}
+		return cty.TupleVal(values)
+	case map[string]interface{}:
+		values := make(map[string]cty.Value, len(v))
+		for key, elem := range v {
+			values[key] = toCtyValue(elem)
+		}
+		return cty.ObjectVal(values)
+	}
+	return cty.NilVal
+}
+
+func TestPreflightPolicyDiff_secgroupRule(t *testing.T) {
+	policy := config.PreflightPolicy{
+		Name: "vpc-sg-restricted-ingress",
+		Parameters: map[string]interface{}{
+			"allowedPorts": []interface{}{"80", "443", float64(8080)},
+		},
+	}
+	baseRule := map[string]interface{}{
+		"security_group_id": "secgroup-id",
+		"direction":         "ingress",
+		"ethertype":         "IPv4",
+		"protocol":          "tcp",
+		"remote_ip_prefix":  "0.0.0.0/0",
+	}
+	cases := []struct {
+		name      string
+		arguments map[string]interface{}
+		violated  bool
+	}{
+		{
+			name:      "allowed ports",
+			arguments: map[string]interface{}{"ports": "80,443,8080"},
+		},
+		{
+			name:      "ssh port",
+			arguments: map[string]interface{}{"ports": "22,443"},
+			violated:  true,
+		},
+		{
+			name:      "all ports",
+			arguments: map[string]interface{}{},
+			violated:  true,
+		},
+		{
+			name:      "restricted remote",
+			arguments: map[string]interface{}{"ports": "22", "remote_ip_prefix": "192.168.0.0/16"},
+		},
+		{
+			name:      "denied rule",
+			arguments: map[string]interface{}{"ports": "22", "action": "deny"},
+		},
+		{
+			name:      "egress rule",
+			arguments: map[string]interface{}{"ports": "22", "direction": "egress"},
+		},
+	}
+
+	for _, tc := range cases {
+		raw := make(map[string]interface{})
+		for k, v := range baseRule {
+			raw[k] = v
+		}
+		for k, v := range tc.arguments {
+			raw[k] = v
+		}
+
+		err := planWithPreflightPolicies("huaweicloud_networking_secgroup_rule", vpc.ResourceNetworkingSecGroupRule(),
+			raw, policy)
+		if tc.violated {
+			if err == nil || !strings.Contains(err.Error(), "vpc-sg-restricted-ingress") {
+				t.Errorf("[%s] expected the preflight policy to be violated, but got: %v", tc.name, err)
+			}
+		} else if err != nil {
+			t.Errorf("[%s] unexpected error: %s", tc.name, err)
+		}
+	}
+}
+
+func TestPreflightPolicyDiff_rdsInstance(t *testing.T) {
+	policy := config.PreflightPolicy{Name: "rds-instance-ssl-enable"}
+	raw := map[string]interface{}{
+		"name":              "rds-test",
+		"flavor":            "rds.mysql.n1.large.2",
+		"vpc_id":            "vpc-id",
+		"subnet_id":         "subnet-id",
+		"security_group_id": "secgroup-id",
+		"availability_zone": []interface{}{"cn-north-4a"},
+		"db": []interface{}{
+			map[string]interface{}{"type": "MySQL", "version": "8.0"},
+		},
+		"volume": []interface{}{
+			map[string]interface{}{"type": "CLOUDSSD", "size": 40},
+		},
+	}
+
+	err := planWithPreflightPolicies("huaweicloud_rds_instance", rds.ResourceRdsInstance(), raw, policy)
+	if err == nil || !strings.Contains(err.Error(), "ssl_enable") {
+		t.Errorf("expected the preflight policy to be violated, but got: %v", err)
+	}
+
+	raw["ssl_enable"] = true
+	if err := planWithPreflightPolicies("huaweicloud_rds_instance", rds.ResourceRdsInstance(), raw,
+		policy); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// the policies of the other resource types are not evaluated
+	if err := planWithPreflightPolicies("huaweicloud_rds_instance", rds.ResourceRdsInstance(), raw,
+		config.PreflightPolicy{Name: "ecs-instance-no-public-ip"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestValidatePreflightPolicy(t *testing.T) {
+	cases := []struct {
+		policy config.PreflightPolicy
+		valid  bool
+	}{
+		{
+			policy: config.PreflightPolicy{Name: "obs-bucket-encryption"},
+			valid:  true,
+		},
+		{
+			policy: config.PreflightPolicy{
+				Name:       "obs-bucket-encryption",
+				Parameters: map[string]interface{}{"sseAlgorithms": []interface{}{"kms"}},
+			},
+			valid: true,
+		},
+		{
+			policy: config.PreflightPolicy{
+				Name:       "obs-bucket-encryption",
+				Parameters: map[string]interface{}{"allowedPorts": []interface{}{"80"}},
+			},
+		},
+		{
+			policy: config.PreflightPolicy{Name: "unknown-policy"},
+		},
+	}
+
+	for _, tc := range cases {
+		err := ValidatePreflightPolicy(tc.policy)
+		if tc.valid && err != nil {
+			t.Errorf("unexpected error of the policy %v: %s", tc.policy, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("expected an error of the policy %v, but got nil", tc.policy)
+		}
+	}
+}
@@ -26,6 +26,8 @@ func ResourceProtectedInstance() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: utils.SetTagsAllDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -81,7 +83,8 @@ func ResourceProtectedInstance() *schema.Resource {
 				ForceNew: true,
 				Computed: true,
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsComputedSchema(),
 			"target_server": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -127,7 +130,8 @@ func resourceProtectedInstanceCreate(ctx context.Context, d *schema.ResourceData
 	d.SetId(instanceID.(string))
 
 	// add tags
-	if err := utils.CreateResourceTags(client, d, "protected-instances", d.Id()); err != nil {
+	err = utils.CreateResourceTags(client, d, cfg.ProviderTags, "protected-instances", d.Id())
+	if err != nil {
 		return diag.Errorf("error setting tags of SDRS protected instance %s: %s", d.Id(), err)
 	}
 
@@ -167,7 +171,8 @@ func resourceProtectedInstanceRead(_ context.Context, d *schema.ResourceData, me
 		return diag.Errorf("error setting resource: %s", mErr)
 	}
 
-	if err := utils.SetResourceTagsToState(d, client, "protected-instances", d.Id()); err != nil {
+	err = utils.SetResourceTagsAllToState(d, cfg.ProviderTags, client, "protected-instances", d.Id())
+	if err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
@@ -192,8 +197,9 @@ func resourceProtectedInstanceUpdate(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
-	if d.HasChange("tags") {
-		if err := utils.UpdateResourceTags(client, d, "protected-instances", d.Id()); err != nil {
+	if d.HasChanges("tags", "tags_all") {
+		err = utils.UpdateResourceTagsAll(client, d, cfg.ProviderTags, "protected-instances", d.Id())
+		if err != nil {
 			return diag.Errorf("error updating tags of SDRS protected instance %s: %s", d.Id(), err)
 		}
 	}
@@ -319,7 +319,7 @@ func resourceSFSFileSystemV2Read(_ context.Context, d *schema.ResourceData, meta
 	// set tags
 	if resourceTags, err := tags.Get(sfsClient, "sfs", d.Id()).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		if err := d.Set("tags", tagmap); err != nil {
+		if err := d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagmap)); err != nil {
 			return diag.Errorf("error saving tags to state for SFS file system (%s): %s", d.Id(), err)
 		}
 	} else {
@@ -402,7 +402,7 @@ func resourceSFSFileSystemV2Update(ctx context.Context, d *schema.ResourceData,
 
 	// update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(sfsClient, d, "sfs", d.Id())
+		tagErr := utils.UpdateResourceTags(sfsClient, d, cfg.ProviderTags, "sfs", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of sfs:%s, err:%s", d.Id(), tagErr)
 		}
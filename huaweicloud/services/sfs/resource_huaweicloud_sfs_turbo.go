@@ -46,6 +46,8 @@ func ResourceSFSTurbo() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: utils.SetTagsAllDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -144,6 +146,7 @@ func ResourceSFSTurbo() *schema.Resource {
 				ForceNew: true,
 			},
 			"tags":          common.TagsSchema(),
+			"tags_all":      common.TagsComputedSchema(),
 			"charging_mode": common.SchemaChargingMode(nil),
 			"period_unit":   common.SchemaPeriodUnit(nil),
 			"period":        common.SchemaPeriod(nil),
@@ -319,7 +322,7 @@ func resourceSFSTurboCreate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	// add tags
-	if err := utils.CreateResourceTags(sfsClient, d, "sfs-turbo", d.Id()); err != nil {
+	if err := utils.CreateResourceTags(sfsClient, d, cfg.ProviderTags, "sfs-turbo", d.Id()); err != nil {
 		return diag.Errorf("error setting tags of SFS Turbo %s: %s", d.Id(), err)
 	}
 
@@ -400,7 +403,7 @@ func resourceSFSTurboRead(_ context.Context, d *schema.ResourceData, meta interf
 	}
 
 	// set tags
-	err = utils.SetResourceTagsToState(d, sfsClient, "sfs-turbo", d.Id())
+	err = utils.SetResourceTagsAllToState(d, cfg.ProviderTags, sfsClient, "sfs-turbo", d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -503,8 +506,8 @@ func resourceSFSTurboUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	// update tags
-	if d.HasChange("tags") {
-		if err := updateSFSTurboTags(sfsClient, d); err != nil {
+	if d.HasChanges("tags", "tags_all") {
+		if err := updateSFSTurboTags(sfsClient, d, cfg.ProviderTags); err != nil {
 			return diag.Errorf("error updating tags of SFS Turbo %s: %s", resourceId, err)
 		}
 	}
@@ -553,7 +556,8 @@ func resourceSFSTurboUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	return resourceSFSTurboRead(ctx, d, meta)
 }
 
-func updateSFSTurboTags(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
+func updateSFSTurboTags(client *golangsdk.ServiceClient, d *schema.ResourceData,
+	tagsConfig *utils.ProviderTagsConfig) error {
 	// remove old tags
 	oldKeys := getOldTagKeys(d)
 	if err := utils.DeleteResourceTagsWithKeys(client, oldKeys, "sfs-turbo", d.Id()); err != nil {
@@ -561,11 +565,15 @@ func updateSFSTurboTags(client *golangsdk.ServiceClient, d *schema.ResourceData)
 	}
 
 	// set new tags
-	return utils.CreateResourceTags(client, d, "sfs-turbo", d.Id())
+	return utils.CreateResourceTags(client, d, tagsConfig, "sfs-turbo", d.Id())
 }
 
+// getOldTagKeys returns the keys of the old tags, including the provider-level default tags saved in "tags_all".
 func getOldTagKeys(d *schema.ResourceData) []string {
-	oRaw, _ := d.GetChange("tags")
+	oRaw, _ := d.GetChange("tags_all")
+	if len(oRaw.(map[string]interface{})) == 0 {
+		oRaw, _ = d.GetChange("tags")
+	}
 	var tagKeys []string
 	if oMap := oRaw.(map[string]interface{}); len(oMap) > 0 {
 		for k := range oMap {
@@ -25,6 +25,10 @@ func ResourceSfsTurboDir() *schema.Resource {
 		ReadContext:   resourceSfsTurboDirRead,
 		DeleteContext: resourceSfsTurboDirDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSfsTurboDirImportState,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -184,3 +188,25 @@ func resourceSfsTurboDirDelete(_ context.Context, d *schema.ResourceData, meta i
 
 	return nil
 }
+
+// parseShareIDAndPath parses the import ID in the format of <share_id>/<path>, the path is the full path of the
+// directory and may contain slashes, e.g. <share_id>/dir/subdir means the directory /dir/subdir.
+func parseShareIDAndPath(importedId string) (shareId, path string, err error) {
+	parts := strings.SplitN(importedId, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || strings.Trim(parts[1], "/") == "" {
+		return "", "", fmt.Errorf("invalid format specified for import ID, want '<share_id>/<path>', but got '%s'",
+			importedId)
+	}
+	return parts[0], "/" + strings.TrimPrefix(parts[1], "/"), nil
+}
+
+func resourceSfsTurboDirImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	shareId, path, err := parseShareIDAndPath(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(path)
+	return []*schema.ResourceData{d}, d.Set("share_id", shareId)
+}
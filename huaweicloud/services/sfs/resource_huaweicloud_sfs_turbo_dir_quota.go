@@ -27,6 +27,10 @@ func ResourceSfsTurboDirQuota() *schema.Resource {
 		UpdateContext: resourceSfsTurboDirQuotaUpdate,
 		DeleteContext: resourceSfsTurboDirQuotaDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSfsTurboDirQuotaImportState,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -215,3 +219,14 @@ func resourceSfsTurboDirQuotaDelete(_ context.Context, d *schema.ResourceData, m
 
 	return nil
 }
+
+func resourceSfsTurboDirQuotaImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	shareId, path, err := parseShareIDAndPath(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(path)
+	return []*schema.ResourceData{d}, d.Set("share_id", shareId)
+}
@@ -0,0 +1,24 @@
+package sfs
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResourceSfsTurboDirQuotaImportState(t *testing.T) {
+	r := ResourceSfsTurboDirQuota()
+	d := r.TestResourceData()
+	d.SetId("share-id/temp/subdir")
+
+	result, err := r.Importer.StateContext(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("error importing the directory quota: %s", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 imported resource, but got %d", len(result))
+	}
+	if result[0].Id() != "/temp/subdir" || result[0].Get("share_id") != "share-id" {
+		t.Errorf("the imported directory quota is not as expected, want (/temp/subdir, share-id), but got (%s, %v)",
+			result[0].Id(), result[0].Get("share_id"))
+	}
+}
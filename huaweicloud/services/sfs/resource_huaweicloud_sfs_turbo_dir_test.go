@@ -0,0 +1,40 @@
+package sfs
+
+import (
+	"testing"
+)
+
+func TestParseShareIDAndPath(t *testing.T) {
+	cases := []struct {
+		importedID string
+		shareID    string
+		path       string
+		isErr      bool
+	}{
+		{importedID: "share-id/temp", shareID: "share-id", path: "/temp"},
+		{importedID: "share-id//temp", shareID: "share-id", path: "/temp"},
+		{importedID: "share-id/temp/subdir", shareID: "share-id", path: "/temp/subdir"},
+		{importedID: "share-id", isErr: true},
+		{importedID: "share-id/", isErr: true},
+		{importedID: "share-id//", isErr: true},
+		{importedID: "/temp", isErr: true},
+	}
+
+	for _, tc := range cases {
+		shareID, path, err := parseShareIDAndPath(tc.importedID)
+		if tc.isErr {
+			if err == nil {
+				t.Errorf("expected an error when parsing '%s', but got nil", tc.importedID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error when parsing '%s': %s", tc.importedID, err)
+			continue
+		}
+		if shareID != tc.shareID || path != tc.path {
+			t.Errorf("the result of parsing '%s' is not as expected, want (%s, %s), but got (%s, %s)",
+				tc.importedID, tc.shareID, tc.path, shareID, path)
+		}
+	}
+}
@@ -29,6 +29,10 @@ func ResourceSFSTurboPermRule() *schema.Resource {
 		UpdateContext: resourceSFSTurboPermRuleUpdate,
 		DeleteContext: resourceSFSTurboPermRuleDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSFSTurboPermRuleImportState,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -243,3 +247,24 @@ func resourceSFSTurboPermRuleDelete(_ context.Context, d *schema.ResourceData, m
 
 	return nil
 }
+
+// parsePermRuleImportID parses the import ID in the format of <share_id>/<id>.
+func parsePermRuleImportID(importedId string) (shareId, ruleId string, err error) {
+	parts := strings.Split(importedId, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid format specified for import ID, want '<share_id>/<id>', but got '%s'",
+			importedId)
+	}
+	return parts[0], parts[1], nil
+}
+
+func resourceSFSTurboPermRuleImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	shareId, ruleId, err := parsePermRuleImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(ruleId)
+	return []*schema.ResourceData{d}, d.Set("share_id", shareId)
+}
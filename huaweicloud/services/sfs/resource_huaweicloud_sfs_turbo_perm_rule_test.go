@@ -0,0 +1,38 @@
+package sfs
+
+import (
+	"testing"
+)
+
+func TestParsePermRuleImportID(t *testing.T) {
+	cases := []struct {
+		importedID string
+		shareID    string
+		ruleID     string
+		isErr      bool
+	}{
+		{importedID: "share-id/rule-id", shareID: "share-id", ruleID: "rule-id"},
+		{importedID: "share-id", isErr: true},
+		{importedID: "share-id/", isErr: true},
+		{importedID: "/rule-id", isErr: true},
+		{importedID: "share-id/rule-id/extra", isErr: true},
+	}
+
+	for _, tc := range cases {
+		shareID, ruleID, err := parsePermRuleImportID(tc.importedID)
+		if tc.isErr {
+			if err == nil {
+				t.Errorf("expected an error when parsing '%s', but got nil", tc.importedID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error when parsing '%s': %s", tc.importedID, err)
+			continue
+		}
+		if shareID != tc.shareID || ruleID != tc.ruleID {
+			t.Errorf("the result of parsing '%s' is not as expected, want (%s, %s), but got (%s, %s)",
+				tc.importedID, tc.shareID, tc.ruleID, shareID, ruleID)
+		}
+	}
+}
@@ -233,7 +233,7 @@ func resourceTopicRead(_ context.Context, d *schema.ResourceData, meta interface
 	}
 	if resourceTags, err := tags.Get(tagClient, "smn_topic", d.Get("name").(string)).Extract(); err == nil {
 		tagmap := utils.TagsToMap(resourceTags.Tags)
-		mErr = multierror.Append(mErr, d.Set("tags", tagmap))
+		mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, tagmap)))
 	} else {
 		log.Printf("[WARN] fetching tags of SMN topic failed: %s", err)
 	}
@@ -279,7 +279,7 @@ func resourceTopicUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		tagClient.MoreHeaders = map[string]string{
 			"X-SMN-RESOURCEID-TYPE": "name",
 		}
-		tagErr := utils.UpdateResourceTags(tagClient, d, "smn_topic", d.Get("name").(string))
+		tagErr := utils.UpdateResourceTags(tagClient, d, cfg.ProviderTags, "smn_topic", d.Get("name").(string))
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of SMN topic %s: %s", id, tagErr)
 		}
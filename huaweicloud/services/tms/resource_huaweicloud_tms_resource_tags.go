@@ -2,7 +2,9 @@ package tms
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-uuid"
@@ -24,6 +26,10 @@ func ResourceResourceTags() *schema.Resource {
 		UpdateContext: resourceResourceTagsUpdate,
 		DeleteContext: resourceResourceTagsDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceResourceTagsImportState,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:        schema.TypeString,
@@ -280,3 +286,85 @@ func resourceResourceTagsDelete(_ context.Context, d *schema.ResourceData, meta
 
 	return nil
 }
+
+// parseResourceTagsImportID parses the import ID in the format of
+// [<project_id>/]<resource_type>:<resource_id>[,<resource_type>:<resource_id>...].
+func parseResourceTagsImportID(importedId string) (projectId string, resources []interface{}, err error) {
+	formatErr := fmt.Errorf("invalid format specified for import ID, want '[<project_id>/]<resource_type>:<resource_id>"+
+		"[,<resource_type>:<resource_id>...]', but got '%s'", importedId)
+
+	resourcesPart := importedId
+	if parts := strings.Split(importedId, "/"); len(parts) == 2 {
+		if parts[0] == "" {
+			return "", nil, formatErr
+		}
+		projectId, resourcesPart = parts[0], parts[1]
+	} else if len(parts) > 2 {
+		return "", nil, formatErr
+	}
+
+	for _, item := range strings.Split(resourcesPart, ",") {
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return "", nil, formatErr
+		}
+		resources = append(resources, map[string]interface{}{
+			"resource_type": parts[0],
+			"resource_id":   parts[1],
+		})
+	}
+	return projectId, resources, nil
+}
+
+// resourceResourceTagsImportState imports the tags which are shared by all specified resources, that is, the tags
+// with the same key and value.
+func resourceResourceTagsImportState(_ context.Context, d *schema.ResourceData,
+	meta interface{}) ([]*schema.ResourceData, error) {
+	projectId, resources, err := parseResourceTagsImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := meta.(*config.Config)
+	client, err := cfg.TmsV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return nil, fmt.Errorf("error creating TMS v2 client: %s", err)
+	}
+
+	var sharedTags map[string]interface{}
+	for i, val := range resources {
+		resource := val.(map[string]interface{})
+		opts := tags.QueryOpts{
+			ResourceId:   resource["resource_id"].(string),
+			ResourceType: resource["resource_type"].(string),
+			ProjectId:    projectId,
+		}
+		resp, err := tags.Get(client, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error query resource (%s) tags: %s", opts.ResourceId, err)
+		}
+
+		actualTags := FlattenTagsToMap(resp)
+		if i == 0 {
+			sharedTags = actualTags
+			continue
+		}
+		sharedTags, _ = compareTwoTags(sharedTags, actualTags)
+	}
+	if len(sharedTags) < 1 {
+		return nil, fmt.Errorf("no tags are shared by all resources: %s", d.Id())
+	}
+
+	randUUID, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate resource ID of the TMS tags management: %s", err)
+	}
+	d.SetId(randUUID)
+
+	mErr := multierror.Append(nil,
+		d.Set("project_id", projectId),
+		d.Set("resources", resources),
+		d.Set("tags", sharedTags),
+	)
+	return []*schema.ResourceData{d}, mErr.ErrorOrNil()
+}
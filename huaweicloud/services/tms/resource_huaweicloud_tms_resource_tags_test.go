@@ -0,0 +1,54 @@
+package tms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseResourceTagsImportID(t *testing.T) {
+	cases := []struct {
+		importedID string
+		projectID  string
+		resources  []interface{}
+		isErr      bool
+	}{
+		{
+			importedID: "vpc:vpc-id",
+			resources: []interface{}{
+				map[string]interface{}{"resource_type": "vpc", "resource_id": "vpc-id"},
+			},
+		},
+		{
+			importedID: "project-id/vpc:vpc-id,disk:disk-id",
+			projectID:  "project-id",
+			resources: []interface{}{
+				map[string]interface{}{"resource_type": "vpc", "resource_id": "vpc-id"},
+				map[string]interface{}{"resource_type": "disk", "resource_id": "disk-id"},
+			},
+		},
+		{importedID: "vpc", isErr: true},
+		{importedID: "vpc:", isErr: true},
+		{importedID: ":vpc-id", isErr: true},
+		{importedID: "vpc:vpc-id,", isErr: true},
+		{importedID: "/vpc:vpc-id", isErr: true},
+		{importedID: "project-id/vpc:vpc-id/extra", isErr: true},
+	}
+
+	for _, tc := range cases {
+		projectID, resources, err := parseResourceTagsImportID(tc.importedID)
+		if tc.isErr {
+			if err == nil {
+				t.Errorf("expected an error when parsing '%s', but got nil", tc.importedID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error when parsing '%s': %s", tc.importedID, err)
+			continue
+		}
+		if projectID != tc.projectID || !reflect.DeepEqual(resources, tc.resources) {
+			t.Errorf("the result of parsing '%s' is not as expected, want (%s, %v), but got (%s, %v)",
+				tc.importedID, tc.projectID, tc.resources, projectID, resources)
+		}
+	}
+}
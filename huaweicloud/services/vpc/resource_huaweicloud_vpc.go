@@ -11,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/chnsz/golangsdk"
-	"github.com/chnsz/golangsdk/openstack/common/tags"
 	"github.com/chnsz/golangsdk/openstack/eps/v1/enterpriseprojects"
 	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
 
@@ -33,6 +32,8 @@ func ResourceVirtualPrivateCloudV1() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: utils.SetTagsAllDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(3 * time.Minute),
@@ -100,7 +101,8 @@ func ResourceVirtualPrivateCloudV1() *schema.Resource {
 					},
 				},
 			},
-			"tags": common.TagsSchema(),
+			"tags":     common.TagsSchema(),
+			"tags_all": common.TagsComputedSchema(),
 		},
 	}
 }
@@ -149,16 +151,12 @@ func resourceVirtualPrivateCloudCreate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
-	if len(tagRaw) > 0 {
-		v2Client, err := conf.NetworkingV2Client(region)
-		if err != nil {
-			return diag.Errorf("error creating VPC v2 client: %s", err)
-		}
-		taglist := utils.ExpandResourceTags(tagRaw)
-		if tagErr := tags.Create(v2Client, "vpcs", n.ID, taglist).ExtractErr(); tagErr != nil {
-			return diag.Errorf("error setting tags of VPC %q: %s", n.ID, tagErr)
-		}
+	v2Client, err := conf.NetworkingV2Client(region)
+	if err != nil {
+		return diag.Errorf("error creating VPC v2 client: %s", err)
+	}
+	if tagErr := utils.CreateResourceTags(v2Client, d, conf.ProviderTags, "vpcs", n.ID); tagErr != nil {
+		return diag.Errorf("error setting tags of VPC %q: %s", n.ID, tagErr)
 	}
 
 	var extendCidrs []string
@@ -224,13 +222,8 @@ func resourceVirtualPrivateCloudRead(_ context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.Errorf("error creating VPC client: %s", err)
 	}
-	if resourceTags, err := tags.Get(v2Client, "vpcs", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
-		if err := d.Set("tags", tagmap); err != nil {
-			return diag.Errorf("error saving tags to state for VPC (%s): %s", d.Id(), err)
-		}
-	} else {
-		log.Printf("[WARN] error fetching tags of VPC (%s): %s", d.Id(), err)
+	if err := utils.SetResourceTagsAllToState(d, conf.ProviderTags, v2Client, "vpcs", d.Id()); err != nil {
+		return diag.FromErr(err)
 	}
 
 	// save VirtualPrivateCloudV3 extend_cidrs
@@ -287,13 +280,13 @@ func resourceVirtualPrivateCloudUpdate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	// update tags
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		v2Client, err := conf.NetworkingV2Client(region)
 		if err != nil {
 			return diag.Errorf("error creating VPC v2 client: %s", err)
 		}
 
-		tagErr := utils.UpdateResourceTags(v2Client, d, "vpcs", vpcID)
+		tagErr := utils.UpdateResourceTagsAll(v2Client, d, conf.ProviderTags, "vpcs", vpcID)
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of VPC %s: %s", vpcID, tagErr)
 		}
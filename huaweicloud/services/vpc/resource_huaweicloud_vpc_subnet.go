@@ -350,7 +350,7 @@ func resourceVpcSubnetRead(_ context.Context, d *schema.ResourceData, meta inter
 	if vpcSubnetV2Client, err := config.NetworkingV2Client(region); err == nil {
 		if resourceTags, err := tags.Get(vpcSubnetV2Client, "subnets", d.Id()).Extract(); err == nil {
 			tagmap := utils.TagsToMap(resourceTags.Tags)
-			mErr = multierror.Append(mErr, d.Set("tags", tagmap))
+			mErr = multierror.Append(mErr, d.Set("tags", utils.FilterResourceTags(d, config.ProviderTags, tagmap)))
 		} else {
 			log.Printf("[WARN] Error fetching tags of Subnet (%s): %s", d.Id(), err)
 		}
@@ -431,7 +431,7 @@ func resourceVpcSubnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			return diag.Errorf("error creating VpcSubnet client: %s", err)
 		}
 
-		tagErr := utils.UpdateResourceTags(vpcSubnetV2Client, d, "subnets", d.Id())
+		tagErr := utils.UpdateResourceTags(vpcSubnetV2Client, d, config.ProviderTags, "subnets", d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of VPC subnet %s: %s", d.Id(), tagErr)
 		}
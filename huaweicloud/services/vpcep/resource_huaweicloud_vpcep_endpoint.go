@@ -185,7 +185,7 @@ func resourceVPCEndpointRead(_ context.Context, d *schema.ResourceData, meta int
 		d.Set("enable_dns", ep.EnableDNS),
 		d.Set("enable_whitelist", ep.EnableWhitelist),
 		d.Set("packet_id", ep.MarkerID),
-		d.Set("tags", utils.TagsToMap(ep.Tags)),
+		d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, utils.TagsToMap(ep.Tags))),
 	)
 
 	if len(ep.Whitelist) == 0 {
@@ -221,7 +221,7 @@ func resourceVPCEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(vpcepClient, d, tagVPCEP, d.Id())
+		tagErr := utils.UpdateResourceTags(vpcepClient, d, cfg.ProviderTags, tagVPCEP, d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of VPC endpoint %s: %s", d.Id(), tagErr)
 		}
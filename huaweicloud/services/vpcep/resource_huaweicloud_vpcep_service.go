@@ -322,7 +322,7 @@ func resourceVPCEndpointServiceRead(_ context.Context, d *schema.ResourceData, m
 		d.Set("service_type", n.ServiceType),
 		d.Set("description", n.Description),
 		d.Set("port_mapping", flattenVPCEndpointServicePorts(n)),
-		d.Set("tags", utils.TagsToMap(n.Tags)),
+		d.Set("tags", utils.FilterResourceTags(d, cfg.ProviderTags, utils.TagsToMap(n.Tags))),
 		d.Set("enable_policy", n.EnablePolicy),
 	)
 
@@ -377,7 +377,7 @@ func resourceVPCEndpointServiceUpdate(ctx context.Context, d *schema.ResourceDat
 
 	// update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(vpcepClient, d, tagVPCEPService, d.Id())
+		tagErr := utils.UpdateResourceTags(vpcepClient, d, cfg.ProviderTags, tagVPCEPService, d.Id())
 		if tagErr != nil {
 			return diag.Errorf("error updating tags of VPC endpoint service %s: %s", d.Id(), tagErr)
 		}
@@ -449,7 +449,7 @@ func resourceDesktopRead(_ context.Context, d *schema.ResourceData, meta interfa
 		d.Set("availability_zone", resp.AvailabilityZone),
 		d.Set("user_group", resp.UserGroup),
 		d.Set("name", resp.Name),
-		d.Set("tags", utils.TagsToMap(resp.Tags)),
+		d.Set("tags", utils.FilterResourceTags(d, conf.ProviderTags, utils.TagsToMap(resp.Tags))),
 		d.Set("enterprise_project_id", resp.EnterpriseProjectId),
 		d.Set("status", resp.Status),
 	)
@@ -742,7 +742,7 @@ func resourceDesktopUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 
 	if d.HasChange("tags") {
-		err = utils.UpdateResourceTags(client, d, "desktops", desktopId)
+		err = utils.UpdateResourceTags(client, d, conf.ProviderTags, "desktops", desktopId)
 		if err != nil {
 			return diag.Errorf("error updating tags of Workspace desktop (%s): %s", desktopId, err)
 		}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/chnsz/golangsdk/openstack/obs"
+)
+
+// ZipArchiveModifiedTime is the modification time of all files in the archives written by WriteZipArchive, so the
+// archive of the same content always has the same hash.
+var ZipArchiveModifiedTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// WriteZipArchive writes the ZIP archive of the source directory or file to w. The files are added in lexical order
+// with the fixed modification time, so the archive only changes when the file names, modes or contents change.
+func WriteZipArchive(sourceDir, sourceFile string, w io.Writer) error {
+	zipWriter := zip.NewWriter(w)
+	if sourceFile != "" {
+		info, err := os.Stat(sourceFile)
+		if err != nil {
+			return fmt.Errorf("error reading the source file: %s", err)
+		}
+		if err := addZipArchiveFile(zipWriter, sourceFile, filepath.Base(sourceFile), info); err != nil {
+			return err
+		}
+		return zipWriter.Close()
+	}
+
+	err := filepath.WalkDir(sourceDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		// the symbolic links are archived as the files which they refer to
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(sourceDir, filePath)
+		if err != nil {
+			return err
+		}
+		return addZipArchiveFile(zipWriter, filePath, filepath.ToSlash(relPath), info)
+	})
+	if err != nil {
+		return fmt.Errorf("error archiving the source directory: %s", err)
+	}
+	return zipWriter.Close()
+}
+
+func addZipArchiveFile(zipWriter *zip.Writer, filePath, name string, info fs.FileInfo) error {
+	header := &zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: ZipArchiveModifiedTime,
+	}
+	// keep the permissions, e.g. the executable files
+	header.SetMode(info.Mode().Perm())
+
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(writer, file)
+	return err
+}
+
+// CreateZipArchive writes the ZIP archive of the source directory or file to a temporary file, the path and the SHA256
+// hash of the archive are returned. The caller should remove the archive after use.
+func CreateZipArchive(sourceDir, sourceFile string) (string, []byte, error) {
+	archive, err := os.CreateTemp("", "terraform-archive-*.zip")
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating the archive: %s", err)
+	}
+
+	hash := sha256.New()
+	err = WriteZipArchive(sourceDir, sourceFile, io.MultiWriter(archive, hash))
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(archive.Name())
+		return "", nil, err
+	}
+	return archive.Name(), hash.Sum(nil), nil
+}
+
+// UploadZipArchive archives the source directory or file and uploads the archive to the OBS bucket. The object key is
+// built from the SHA256 hash of the archive by buildKey, the key and the hash are returned.
+func UploadZipArchive(obsClient *obs.ObsClient, sourceDir, sourceFile, bucket string,
+	buildKey func(hash []byte) string) (string, []byte, error) {
+	archive, hash, err := CreateZipArchive(sourceDir, sourceFile)
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.Remove(archive)
+
+	key := buildKey(hash)
+	putInput := &obs.PutFileInput{}
+	putInput.Bucket = bucket
+	putInput.Key = key
+	putInput.SourceFile = archive
+	log.Printf("[DEBUG] uploading the archive to OBS bucket %s, key: %s", bucket, key)
+	if _, err = obsClient.PutFile(putInput); err != nil {
+		return "", nil, fmt.Errorf("error uploading the archive to OBS bucket %s: %s", bucket, err)
+	}
+	return key, hash, nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeArchiveTestFile(t *testing.T, filePath, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteZipArchive(t *testing.T) {
+	sourceDir := t.TempDir()
+	writeArchiveTestFile(t, filepath.Join(sourceDir, "index.py"), "def handler(event, context):\n    return 'ok'\n")
+	writeArchiveTestFile(t, filepath.Join(sourceDir, "lib", "util.py"), "VALUE = 1\n")
+
+	var buf bytes.Buffer
+	if err := WriteZipArchive(sourceDir, "", &buf); err != nil {
+		t.Fatalf("error archiving the source directory: %s", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid archive: %s", err)
+	}
+
+	expected := []string{"index.py", "lib/util.py"}
+	if len(reader.File) != len(expected) {
+		t.Fatalf("the archive should contain %d files, but got %d", len(expected), len(reader.File))
+	}
+	for i, f := range reader.File {
+		if f.Name != expected[i] {
+			t.Errorf("the file %d in the archive should be %s, but got %s", i, expected[i], f.Name)
+		}
+		if !f.Modified.Equal(ZipArchiveModifiedTime) {
+			t.Errorf("the modification time of %s should be fixed, but got %s", f.Name, f.Modified)
+		}
+	}
+}
+
+func TestCreateZipArchive(t *testing.T) {
+	sourceDir := t.TempDir()
+	writeArchiveTestFile(t, filepath.Join(sourceDir, "main.tf"), "resource \"huaweicloud_vpc\" \"test\" {}\n")
+
+	archive, hash, err := CreateZipArchive(sourceDir, "")
+	if err != nil {
+		t.Fatalf("error creating the archive: %s", err)
+	}
+	defer os.Remove(archive)
+
+	content, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatalf("error reading the archive: %s", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteZipArchive(sourceDir, "", &buf); err != nil {
+		t.Fatalf("error archiving the source directory: %s", err)
+	}
+	if !bytes.Equal(content, buf.Bytes()) {
+		t.Errorf("the archive file should be the same as the written archive")
+	}
+	if expected := sha256.Sum256(content); !bytes.Equal(hash, expected[:]) {
+		t.Errorf("the hash should be %x, but got %x", expected, hash)
+	}
+
+	if _, _, err := CreateZipArchive(filepath.Join(sourceDir, "not-exist"), ""); err == nil {
+		t.Errorf("expected an error of the nonexistent source directory, but got nil")
+	}
+}
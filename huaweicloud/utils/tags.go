@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"reflect"
+	"strings"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/chnsz/golangsdk"
@@ -12,43 +16,159 @@ import (
 
 const SysTagKeyEnterpriseProjectId = "_sys_enterprise_project_id"
 
-// CreateResourceTags is a helper to create the tags for a resource.
+// ProviderTagsConfig is the provider-level tags configuration, which includes the default tags merged into the
+// tags of the resources and the rules of the tags that should be ignored when reading from the cloud.
+// A nil configuration has neither default tags nor ignore rules.
+type ProviderTagsConfig struct {
+	DefaultTags       map[string]string
+	IgnoreKeys        []string
+	IgnoreKeyPrefixes []string
+}
+
+// providerTagsConfigGetter is implemented by the provider meta which holds the provider-level tags configuration.
+type providerTagsConfigGetter interface {
+	GetProviderTagsConfig() *ProviderTagsConfig
+}
+
+// IsIgnoredTagKey checks whether the tag key matches the provider-level ignore_tags rules.
+func (c *ProviderTagsConfig) IsIgnoredTagKey(key string) bool {
+	if c == nil {
+		return false
+	}
+	if StrSliceContains(c.IgnoreKeys, key) {
+		return true
+	}
+	for _, prefix := range c.IgnoreKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeDefaultTags returns the provider-level default tags merged with the resource tags, the value in resource tags
+// takes precedence over the default tags with the same key.
+func (c *ProviderTagsConfig) MergeDefaultTags(resourceTags map[string]interface{}) map[string]interface{} {
+	var defaultTags map[string]string
+	if c != nil {
+		defaultTags = c.DefaultTags
+	}
+
+	result := make(map[string]interface{}, len(defaultTags)+len(resourceTags))
+	for k, v := range defaultTags {
+		result[k] = v
+	}
+	for k, v := range resourceTags {
+		result[k] = v
+	}
+	return result
+}
+
+// RemoveIgnoredTags returns a copy of the tags without the keys that match the provider-level ignore_tags rules.
+func (c *ProviderTagsConfig) RemoveIgnoredTags(tagmap map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(tagmap))
+	for k, v := range tagmap {
+		if !c.IsIgnoredTagKey(k) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// isDefaultTag checks whether the tag is one of the provider-level default tags.
+func (c *ProviderTagsConfig) isDefaultTag(key, value string) bool {
+	if c == nil {
+		return false
+	}
+	dv, ok := c.DefaultTags[key]
+	return ok && dv == value
+}
+
+// SetTagsAllDiff is a CustomizeDiff function which calculates the "tags_all" attribute from the "tags" and the
+// provider-level default tags, so the merged tags can be shown in the plan.
+func SetTagsAllDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	var tagsConfig *ProviderTagsConfig
+	if getter, ok := meta.(providerTagsConfigGetter); ok {
+		tagsConfig = getter.GetProviderTagsConfig()
+	}
+
+	tagsAll := tagsConfig.RemoveIgnoredTags(tagsConfig.MergeDefaultTags(d.Get("tags").(map[string]interface{})))
+	if reflect.DeepEqual(d.Get("tags_all"), tagsAll) {
+		return nil
+	}
+	return d.SetNew("tags_all", tagsAll)
+}
+
+// CreateResourceTags is a helper to create the tags for a resource, the provider-level default tags are merged into
+// the "tags" and the tags matching the provider-level ignore_tags rules are skipped.
 // It expects the schema name must be "tags"
-func CreateResourceTags(client *golangsdk.ServiceClient, d *schema.ResourceData, resourceType, id string) error {
-	if tagRaw := d.Get("tags").(map[string]interface{}); len(tagRaw) > 0 {
+func CreateResourceTags(client *golangsdk.ServiceClient, d *schema.ResourceData, tagsConfig *ProviderTagsConfig,
+	resourceType, id string) error {
+	tagRaw := tagsConfig.RemoveIgnoredTags(tagsConfig.MergeDefaultTags(d.Get("tags").(map[string]interface{})))
+	if len(tagRaw) > 0 {
 		tagList := ExpandResourceTags(tagRaw)
 		return tags.Create(client, resourceType, id, tagList).ExtractErr()
 	}
 	return nil
 }
 
-// UpdateResourceTags is a helper to update the tags for a resource.
+// UpdateResourceTags is a helper to update the tags for a resource, both the old and the new tags are merged with
+// the provider-level default tags, and the tags matching the provider-level ignore_tags rules are left unchanged.
 // It expects the tags field to be named "tags"
-func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, resourceType, id string) error {
+func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, tagsConfig *ProviderTagsConfig,
+	resourceType, id string) error {
 	if d.HasChange("tags") {
 		oRaw, nRaw := d.GetChange("tags")
-		oMap := oRaw.(map[string]interface{})
-		nMap := nRaw.(map[string]interface{})
-
-		// remove old tags
-		if len(oMap) > 0 {
-			taglist := ExpandResourceTags(oMap)
-			err := tags.Delete(conn, resourceType, id, taglist).ExtractErr()
-			if err != nil {
-				return err
-			}
-		}
+		oMap := tagsConfig.MergeDefaultTags(oRaw.(map[string]interface{}))
+		nMap := tagsConfig.MergeDefaultTags(nRaw.(map[string]interface{}))
+		return updateResourceTags(conn, tagsConfig.RemoveIgnoredTags(oMap), tagsConfig.RemoveIgnoredTags(nMap),
+			resourceType, id)
+	}
 
-		// set new tags
-		if len(nMap) > 0 {
-			taglist := ExpandResourceTags(nMap)
-			err := tags.Create(conn, resourceType, id, taglist).ExtractErr()
-			if err != nil {
-				return err
-			}
+	return nil
+}
+
+// UpdateResourceTagsAll is a helper to update the tags for a resource which supports "tags_all", the old tags are
+// taken from "tags_all" and the new tags are merged with the provider-level default tags.
+func UpdateResourceTagsAll(conn *golangsdk.ServiceClient, d *schema.ResourceData, tagsConfig *ProviderTagsConfig,
+	resourceType, id string) error {
+	if !d.HasChanges("tags", "tags_all") {
+		return nil
+	}
+
+	oRaw, nRaw := d.GetChange("tags")
+	oMap := tagsConfig.MergeDefaultTags(oRaw.(map[string]interface{}))
+	nMap := tagsConfig.MergeDefaultTags(nRaw.(map[string]interface{}))
+	if oRawAll, _ := d.GetChange("tags_all"); len(oRawAll.(map[string]interface{})) > 0 {
+		oMap = oRawAll.(map[string]interface{})
+	}
+	return updateResourceTags(conn, tagsConfig.RemoveIgnoredTags(oMap), tagsConfig.RemoveIgnoredTags(nMap),
+		resourceType, id)
+}
+
+func updateResourceTags(conn *golangsdk.ServiceClient, oMap, nMap map[string]interface{}, resourceType,
+	id string) error {
+	// remove old tags
+	if len(oMap) > 0 {
+		taglist := ExpandResourceTags(oMap)
+		err := tags.Delete(conn, resourceType, id, taglist).ExtractErr()
+		if err != nil {
+			return err
 		}
 	}
 
+	// set new tags
+	if len(nMap) > 0 {
+		taglist := ExpandResourceTags(nMap)
+		err := tags.Create(conn, resourceType, id, taglist).ExtractErr()
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -67,11 +187,13 @@ func DeleteResourceTagsWithKeys(client *golangsdk.ServiceClient, tagKeys []strin
 }
 
 // SetResourceTagsToState is a helper to query tags of resource, then set to state.
+// The tags are filtered by the provider-level tags configuration, see FilterResourceTags.
 // The schema argument name must be: tags
-func SetResourceTagsToState(d *schema.ResourceData, client *golangsdk.ServiceClient, resourceType, id string) error {
+func SetResourceTagsToState(d *schema.ResourceData, tagsConfig *ProviderTagsConfig, client *golangsdk.ServiceClient,
+	resourceType, id string) error {
 	// set tags
 	if resourceTags, err := tags.Get(client, resourceType, id).Extract(); err == nil {
-		tagmap := TagsToMap(resourceTags.Tags)
+		tagmap := FilterResourceTags(d, tagsConfig, TagsToMap(resourceTags.Tags))
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmt.Errorf("error saving tags to state for %s (%s): %s", resourceType, id, err)
 		}
@@ -81,6 +203,58 @@ func SetResourceTagsToState(d *schema.ResourceData, client *golangsdk.ServiceCli
 	return nil
 }
 
+// FilterResourceTags returns the remote tags to be saved into "tags" of the state, the tags matching the
+// provider-level ignore_tags rules are skipped, and so are the provider-level default tags unless they are also
+// specified in the resource tags. It must be called before "tags" is set.
+func FilterResourceTags(d *schema.ResourceData, tagsConfig *ProviderTagsConfig,
+	remoteTags map[string]string) map[string]interface{} {
+	configTags := d.Get("tags").(map[string]interface{})
+	result := make(map[string]interface{}, len(remoteTags))
+	for k, v := range remoteTags {
+		if tagsConfig.IsIgnoredTagKey(k) {
+			continue
+		}
+		if _, ok := configTags[k]; !ok && tagsConfig.isDefaultTag(k, v) {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// SetResourceTagsAllToState is a helper to query tags of a resource which supports "tags_all", then set them to
+// "tags" and "tags_all" of the state.
+func SetResourceTagsAllToState(d *schema.ResourceData, tagsConfig *ProviderTagsConfig,
+	client *golangsdk.ServiceClient, resourceType, id string) error {
+	if resourceTags, err := tags.Get(client, resourceType, id).Extract(); err == nil {
+		tagmap := TagsToMap(resourceTags.Tags)
+		if err := SetTagsAllToState(d, tagsConfig, tagmap); err != nil {
+			return fmt.Errorf("error saving tags to state for %s (%s): %s", resourceType, id, err)
+		}
+	} else {
+		log.Printf("[WARN] Error fetching tags of %s (%s): %s", resourceType, id, err)
+	}
+	return nil
+}
+
+// SetTagsAllToState saves the remote tags into "tags" and "tags_all" of the state, the tags matching the
+// provider-level ignore_tags rules are skipped, and the provider-level default tags are only saved in "tags_all"
+// unless they are also specified in the resource tags.
+func SetTagsAllToState(d *schema.ResourceData, tagsConfig *ProviderTagsConfig, remoteTags map[string]string) error {
+	tagsAll := make(map[string]interface{}, len(remoteTags))
+	for k, v := range remoteTags {
+		if !tagsConfig.IsIgnoredTagKey(k) {
+			tagsAll[k] = v
+		}
+	}
+
+	mErr := multierror.Append(
+		d.Set("tags", FilterResourceTags(d, tagsConfig, remoteTags)),
+		d.Set("tags_all", tagsAll),
+	)
+	return mErr.ErrorOrNil()
+}
+
 // TagsToMap returns the list of tags into a map.
 func TagsToMap(tags []tags.ResourceTag) map[string]string {
 	result := make(map[string]string)
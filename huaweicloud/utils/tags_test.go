@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccFunction_MergeDefaultTags(t *testing.T) {
+	tagsConfig := &ProviderTagsConfig{
+		DefaultTags: map[string]string{
+			"owner": "platform",
+			"env":   "dev",
+		},
+	}
+
+	var (
+		testInput = map[string]interface{}{
+			"env":  "prod",
+			"name": "demo",
+		}
+
+		expected = map[string]interface{}{
+			"owner": "platform",
+			"env":   "prod",
+			"name":  "demo",
+		}
+	)
+
+	testOutput := tagsConfig.MergeDefaultTags(testInput)
+	if !reflect.DeepEqual(testOutput, expected) {
+		t.Fatalf("The processing result of MergeDefaultTags method is not as expected, want %s, but %s",
+			green(expected), yellow(testOutput))
+	}
+	t.Logf("The processing result of MergeDefaultTags method meets expectation: %s", green(expected))
+
+	// a nil configuration has no default tags
+	var nilConfig *ProviderTagsConfig
+	if testOutput := nilConfig.MergeDefaultTags(testInput); !reflect.DeepEqual(testOutput, testInput) {
+		t.Fatalf("The processing result of MergeDefaultTags method is not as expected, want %s, but %s",
+			green(testInput), yellow(testOutput))
+	}
+}
+
+func TestAccFunction_IsIgnoredTagKey(t *testing.T) {
+	tagsConfig := &ProviderTagsConfig{
+		IgnoreKeys:        []string{"CreatedBy"},
+		IgnoreKeyPrefixes: []string{"sys:", "CCE-"},
+	}
+
+	testCases := map[string]bool{
+		"CreatedBy":      true,
+		"sys:managed":    true,
+		"CCE-Cluster-ID": true,
+		"CreatedByTeam":  false,
+		"owner":          false,
+	}
+
+	for key, expected := range testCases {
+		if tagsConfig.IsIgnoredTagKey(key) != expected {
+			t.Fatalf("The processing result of IsIgnoredTagKey method is not as expected for %s, want %s",
+				key, green(expected))
+		}
+	}
+	t.Logf("The processing result of IsIgnoredTagKey method meets expectation")
+}
+
+func TestAccFunction_SetTagsAllToState(t *testing.T) {
+	tagsConfig := &ProviderTagsConfig{
+		DefaultTags: map[string]string{
+			"owner": "platform",
+			"env":   "dev",
+		},
+		IgnoreKeyPrefixes: []string{"sys:"},
+	}
+
+	resourceSchema := map[string]*schema.Schema{
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"tags_all": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"env":  "dev",
+			"name": "demo",
+		},
+	})
+
+	remoteTags := map[string]string{
+		"owner":       "platform",
+		"env":         "dev",
+		"name":        "demo",
+		"sys:managed": "true",
+	}
+	if err := SetTagsAllToState(d, tagsConfig, remoteTags); err != nil {
+		t.Fatalf("Error saving tags to state: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"env":  "dev",
+		"name": "demo",
+	}
+	testOutput := d.Get("tags").(map[string]interface{})
+	if !reflect.DeepEqual(testOutput, expected) {
+		t.Fatalf("The processing result of SetTagsAllToState method is not as expected, want %s, but %s",
+			green(expected), yellow(testOutput))
+	}
+	expectedAll := map[string]interface{}{
+		"owner": "platform",
+		"env":   "dev",
+		"name":  "demo",
+	}
+	if testOutput := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(testOutput, expectedAll) {
+		t.Fatalf("The processing result of SetTagsAllToState method is not as expected, want %s, but %s",
+			green(expectedAll), yellow(testOutput))
+	}
+	t.Logf("The processing result of SetTagsAllToState method meets expectation: %s", green(expected))
+}
+
+func TestAccFunction_FilterResourceTags(t *testing.T) {
+	tagsConfig := &ProviderTagsConfig{
+		DefaultTags: map[string]string{
+			"owner": "platform",
+			"env":   "dev",
+		},
+		IgnoreKeys: []string{"CreatedBy"},
+	}
+
+	resourceSchema := map[string]*schema.Schema{
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"env":  "dev",
+			"name": "demo",
+		},
+	})
+
+	remoteTags := map[string]string{
+		// the default tag which is not specified in the resource tags
+		"owner": "platform",
+		// the default tag which is also specified in the resource tags
+		"env":       "dev",
+		"name":      "demo",
+		"CreatedBy": "console",
+		// the tag added outside Terraform
+		"team": "ops",
+	}
+	expected := map[string]interface{}{
+		"env":  "dev",
+		"name": "demo",
+		"team": "ops",
+	}
+	testOutput := FilterResourceTags(d, tagsConfig, remoteTags)
+	if !reflect.DeepEqual(testOutput, expected) {
+		t.Fatalf("The processing result of FilterResourceTags method is not as expected, want %s, but %s",
+			green(expected), yellow(testOutput))
+	}
+
+	// the default tag with a different value is changed outside Terraform
+	remoteTags["owner"] = "someone"
+	expected["owner"] = "someone"
+	if testOutput := FilterResourceTags(d, tagsConfig, remoteTags); !reflect.DeepEqual(testOutput, expected) {
+		t.Fatalf("The processing result of FilterResourceTags method is not as expected, want %s, but %s",
+			green(expected), yellow(testOutput))
+	}
+
+	// a nil configuration keeps all tags
+	expected = map[string]interface{}{
+		"owner":     "someone",
+		"env":       "dev",
+		"name":      "demo",
+		"CreatedBy": "console",
+		"team":      "ops",
+	}
+	if testOutput := FilterResourceTags(d, nil, remoteTags); !reflect.DeepEqual(testOutput, expected) {
+		t.Fatalf("The processing result of FilterResourceTags method is not as expected, want %s, but %s",
+			green(expected), yellow(testOutput))
+	}
+	t.Logf("The processing result of FilterResourceTags method meets expectation")
+}
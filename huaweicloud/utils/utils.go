@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jmespath/go-jmespath"
@@ -571,3 +572,13 @@ func ConvertMemoryUnit(memory interface{}, diffLevel int) int {
 	}
 	return memoryInt * Power(1024, -diffLevel)
 }
+
+// GetWriteOnlyString returns the value of the write-only string argument from the raw configuration, because the
+// write-only arguments are never persisted in the plan or state. An empty string is returned if it is not configured.
+func GetWriteOnlyString(d *schema.ResourceData, path cty.Path) string {
+	v, diags := d.GetRawConfigAt(path)
+	if diags.HasError() || !v.Type().Equals(cty.String) || v.IsNull() || !v.IsKnown() {
+		return ""
+	}
+	return v.AsString()
+}
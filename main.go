@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 )
 
 func main() {
@@ -13,6 +16,21 @@ func main() {
 	// prevent duplicate timestamp and incorrect log level setting
 	log.SetFlags(log.Flags() &^ (log.Ldate | log.Ltime))
 
+	var debug bool
+	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.Parse()
+
+	serverFactory, err := huaweicloud.NewMuxProviderServer(context.Background(), huaweicloud.Provider())
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: huaweicloud.Provider})
+		GRPCProviderFunc: serverFactory,
+		ProviderAddr:     "registry.terraform.io/huaweicloud/huaweicloud",
+		Debug:            debug,
+	})
+
+	// plugin.Serve returns after Terraform stops the provider
+	config.CloseTracers()
 }